@@ -1,12 +1,34 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// ForkLondon describes the London hard fork.
+	ForkLondon = "london"
+	// ForkShanghai describes the Shanghai hard fork, which introduces the PUSH0 opcode.
+	ForkShanghai = "shanghai"
+	// ForkCancun describes the Cancun hard fork, which introduces transient storage (TLOAD/TSTORE opcodes).
+	ForkCancun = "cancun"
 )
 
+// SupportedForks describes the hard forks which a TestChainConfig can select.
+var SupportedForks = []string{ForkLondon, ForkShanghai, ForkCancun}
+
 // TestChainConfig represents the chain configuration.
 type TestChainConfig struct {
+	// Fork describes the hard fork whose rules the EVM should follow. It must be one of SupportedForks. If empty,
+	// ForkLondon is used.
+	Fork string `json:"fork"`
+
 	// CodeSizeCheckDisabled indicates whether code size checks should be disabled in the EVM. This allows for code
 	// size to be disabled without disabling the entire EIP it was introduced.
 	CodeSizeCheckDisabled bool `json:"codeSizeCheckDisabled"`
@@ -33,3 +55,40 @@ func (t *TestChainConfig) GetVMConfigExtensions() *vm.ConfigExtensions {
 		AdditionalPrecompiles: make(map[common.Address]vm.PrecompiledContract),
 	}
 }
+
+// ApplyFork configures the provided go-ethereum chain config to follow the rules of the hard fork selected by the
+// TestChainConfig.
+// Returns any additional EIPs which must be enabled in the EVM config to follow the selected fork, or an error if
+// the fork is not supported.
+func (t *TestChainConfig) ApplyFork(chainConfig *params.ChainConfig) ([]int, error) {
+	zeroTime := uint64(0)
+	switch t.Fork {
+	case "", ForkLondon:
+		return nil, nil
+	case ForkShanghai:
+		chainConfig.ShanghaiTime = &zeroTime
+		return nil, nil
+	case ForkCancun:
+		// The EVM does not yet include transient storage in the Cancun instruction set, so we enable it explicitly.
+		chainConfig.ShanghaiTime = &zeroTime
+		chainConfig.CancunTime = &zeroTime
+		return []int{1153}, nil
+	default:
+		return nil, fmt.Errorf("unsupported fork %q, valid options are: %s", t.Fork, strings.Join(SupportedForks, ", "))
+	}
+}
+
+// Validate validates that the TestChainConfig meets certain requirements.
+// Returns an error if one occurs.
+func (t *TestChainConfig) Validate() error {
+	// Verify the fork is supported.
+	if t.Fork != "" && !slices.Contains(SupportedForks, t.Fork) {
+		return fmt.Errorf("chain configuration must specify a supported fork, valid options are: %s", strings.Join(SupportedForks, ", "))
+	}
+
+	// Verify the FFI cheat code is only enabled alongside cheat codes, as it would otherwise be unavailable.
+	if t.CheatCodeConfig.EnableFFI && !t.CheatCodeConfig.CheatCodesEnabled {
+		return errors.New("chain configuration must enable cheat codes if the FFI cheat code is enabled")
+	}
+	return nil
+}
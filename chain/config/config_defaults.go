@@ -5,6 +5,7 @@ package config
 func DefaultTestChainConfig() (*TestChainConfig, error) {
 	// Create a default config and return it.
 	config := &TestChainConfig{
+		Fork:                  ForkLondon,
 		CodeSizeCheckDisabled: true,
 		CheatCodeConfig: CheatCodeConfig{
 			CheatCodesEnabled: true,
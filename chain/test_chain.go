@@ -49,6 +49,10 @@ type TestChain struct {
 	// vmConfigExtensions defines EVM extensions to use with each chain call or transaction.
 	vmConfigExtensions *vm.ConfigExtensions
 
+	// vmExtraEips defines additional EIPs to enable in the EVM with each chain call or transaction, as required by
+	// the configured fork.
+	vmExtraEips []int
+
 	// genesisDefinition represents the Genesis information used to generate the chain's initial state.
 	genesisDefinition *core.Genesis
 
@@ -116,6 +120,12 @@ func NewTestChain(genesisAlloc core.GenesisAlloc, testChainConfig *config.TestCh
 		}
 	}
 
+	// Configure our chain to follow the rules of the selected fork.
+	vmExtraEips, err := testChainConfig.ApplyFork(chainConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	// Obtain our VM extensions from our config
 	vmConfigExtensions := testChainConfig.GetVMConfigExtensions()
 
@@ -173,6 +183,7 @@ func NewTestChain(genesisAlloc core.GenesisAlloc, testChainConfig *config.TestCh
 		testChainConfig:         testChainConfig,
 		chainConfig:             genesisDefinition.Config,
 		vmConfigExtensions:      vmConfigExtensions,
+		vmExtraEips:             vmExtraEips,
 	}
 
 	// Add our internal tracers to this chain.
@@ -216,7 +227,7 @@ func (t *TestChain) Clone(onCreateFunc func(chain *TestChain) error) (*TestChain
 	for i := 1; i < len(t.blocks); i++ {
 		// First create a new pending block to commit
 		blockHeader := t.blocks[i].Header
-		_, err = targetChain.PendingBlockCreateWithParameters(blockHeader.Number.Uint64(), blockHeader.Time, &blockHeader.GasLimit)
+		_, err = targetChain.PendingBlockCreateWithCoinbase(blockHeader.Number.Uint64(), blockHeader.Time, &blockHeader.GasLimit, blockHeader.Coinbase)
 		if err != nil {
 			return nil, err
 		}
@@ -556,6 +567,7 @@ func (t *TestChain) CallContract(msg core.Message, state *state.StateDB, additio
 		Tracer:           extendedTracerRouter,
 		NoBaseFee:        true,
 		ConfigExtensions: t.vmConfigExtensions,
+		ExtraEips:        t.vmExtraEips,
 	})
 
 	// Fund the gas pool, so it can execute endlessly (no block gas limit).
@@ -592,6 +604,14 @@ func (t *TestChain) PendingBlockCreate() (*chainTypes.Block, error) {
 // blocks between.
 // Returns the constructed block, or an error if one occurred.
 func (t *TestChain) PendingBlockCreateWithParameters(blockNumber uint64, blockTime uint64, blockGasLimit *uint64) (*chainTypes.Block, error) {
+	return t.PendingBlockCreateWithCoinbase(blockNumber, blockTime, blockGasLimit, t.Head().Header.Coinbase)
+}
+
+// PendingBlockCreateWithCoinbase constructs an empty block which is pending addition to the chain, using the block
+// properties provided, as with PendingBlockCreateWithParameters. Additionally, the block's coinbase is set to the
+// provided address, rather than inherited from the previous block.
+// Returns the constructed block, or an error if one occurred.
+func (t *TestChain) PendingBlockCreateWithCoinbase(blockNumber uint64, blockTime uint64, blockGasLimit *uint64, coinbase common.Address) (*chainTypes.Block, error) {
 	// If we already have a pending block, return an error.
 	if t.pendingBlock != nil {
 		return nil, fmt.Errorf("could not create a new pending block for chain, as a block is already pending")
@@ -636,7 +656,7 @@ func (t *TestChain) PendingBlockCreateWithParameters(blockNumber uint64, blockTi
 	header := &types.Header{
 		ParentHash:  parentBlockHash,
 		UncleHash:   types.EmptyUncleHash,
-		Coinbase:    t.Head().Header.Coinbase,
+		Coinbase:    coinbase,
 		Root:        t.Head().Header.Root,
 		TxHash:      types.EmptyRootHash,
 		ReceiptHash: types.EmptyRootHash,
@@ -696,6 +716,7 @@ func (t *TestChain) PendingBlockAddTx(message core.Message) error {
 		Tracer:           t.transactionTracerRouter,
 		NoBaseFee:        true,
 		ConfigExtensions: t.vmConfigExtensions,
+		ExtraEips:        t.vmExtraEips,
 	})
 
 	// Apply our transaction
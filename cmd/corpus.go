@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crytic/medusa/fuzzing"
+	"github.com/spf13/cobra"
+)
+
+// corpusCmd represents the command provider for corpus management
+var corpusCmd = &cobra.Command{
+	Use:   "corpus",
+	Short: "Manages a fuzzing corpus",
+	Long:  `Manages a fuzzing corpus`,
+}
+
+// corpusMinimizeCmd represents the command provider for corpus minimization
+var corpusMinimizeCmd = &cobra.Command{
+	Use:   "minimize",
+	Short: "Minimizes a fuzzing corpus",
+	Long:  `Replays a fuzzing corpus and removes any call sequences which do not contribute to its total coverage`,
+	Args:  cmdValidateCorpusMinimizeArgs,
+	RunE:  cmdRunCorpusMinimize,
+}
+
+// cmdValidateCorpusMinimizeArgs makes sure that there are no positional arguments provided to the corpus minimize command
+func cmdValidateCorpusMinimizeArgs(cmd *cobra.Command, args []string) error {
+	// Make sure we have no positional args
+	if err := cobra.NoArgs(cmd, args); err != nil {
+		return fmt.Errorf("corpus minimize does not accept any positional arguments, only flags and their associated values")
+	}
+	return nil
+}
+
+func init() {
+	// Add the flags allowed for the corpus minimize command
+	corpusMinimizeCmd.Flags().SortFlags = false
+	corpusMinimizeCmd.Flags().String("config", "", "path to config file")
+	corpusMinimizeCmd.Flags().String("corpus-dir", "", "directory path for corpus items (overrides the config file)")
+
+	// Add the corpus command and its subcommands to the root command
+	corpusCmd.AddCommand(corpusMinimizeCmd)
+	rootCmd.AddCommand(corpusCmd)
+}
+
+// cmdRunCorpusMinimize executes the CLI corpus minimize command. It resolves the project configuration, deploys the
+// target contracts, and replays the corpus, deleting any call sequences which do not contribute to its total coverage.
+func cmdRunCorpusMinimize(cmd *cobra.Command, args []string) error {
+	// Resolve our project configuration
+	projectConfig, configPath, err := resolveProjectConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Update the corpus directory if --corpus-dir was used
+	if cmd.Flags().Changed("corpus-dir") {
+		projectConfig.Fuzzing.CorpusDirectory, err = cmd.Flags().GetString("corpus-dir")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Change our working directory to the parent directory of the project configuration file, so relative paths
+	// resolve the same way they do when fuzzing.
+	err = os.Chdir(filepath.Dir(configPath))
+	if err != nil {
+		return err
+	}
+
+	// Create our fuzzer and minimize the corpus with it
+	fuzzer, err := fuzzing.NewFuzzer(*projectConfig)
+	if err != nil {
+		return err
+	}
+	removedCount, err := fuzzer.MinimizeCorpus()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("corpus minimized, removed %d call sequence(s)\n", removedCount)
+	return nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crytic/medusa/fuzzing"
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the command provider for exporting call sequences
+var exportCmd = &cobra.Command{
+	Use:   "export <sequence-file>",
+	Short: "Exports a call sequence as a Foundry test",
+	Long:  `Exports a corpus entry or failing call sequence JSON file as a standalone Foundry test which reproduces it`,
+	Args:  cmdValidateExportArgs,
+	RunE:  cmdRunExport,
+}
+
+// cmdValidateExportArgs makes sure that exactly one positional argument, the call sequence file, is provided to the
+// export command
+func cmdValidateExportArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+		return fmt.Errorf("export requires exactly one positional argument, the path of the call sequence file to export")
+	}
+	return nil
+}
+
+func init() {
+	// Add the flags allowed for the export command
+	exportCmd.Flags().SortFlags = false
+	exportCmd.Flags().String("config", "", "path to config file")
+	exportCmd.Flags().String("out", "", "path of the file to write the test to (default is stdout)")
+	exportCmd.Flags().String("name", "MedusaReplayTest", "name of the test contract to generate")
+
+	// Add the export command to the root command
+	rootCmd.AddCommand(exportCmd)
+}
+
+// cmdRunExport executes the CLI export command. It reads the provided call sequence, resolves it against the target
+// contracts deployed per the project configuration, and renders it as a Foundry test.
+func cmdRunExport(cmd *cobra.Command, args []string) error {
+	// Read our call sequence prior to changing directories, so relative paths resolve from the working directory.
+	sequencePath, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(sequencePath)
+	if err != nil {
+		return err
+	}
+	var sequence calls.CallSequence
+	err = json.Unmarshal(b, &sequence)
+	if err != nil {
+		return fmt.Errorf("could not parse call sequence file: %v", err)
+	}
+
+	// Resolve the output path prior to changing directories, for the same reason.
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+	if outPath != "" {
+		outPath, err = filepath.Abs(outPath)
+		if err != nil {
+			return err
+		}
+	}
+	testContractName, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+
+	// Resolve our project configuration
+	projectConfig, configPath, err := resolveProjectConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Change our working directory to the parent directory of the project configuration file, so relative paths
+	// resolve the same way they do when fuzzing.
+	err = os.Chdir(filepath.Dir(configPath))
+	if err != nil {
+		return err
+	}
+
+	// Create our fuzzer and export the call sequence with it
+	fuzzer, err := fuzzing.NewFuzzer(*projectConfig)
+	if err != nil {
+		return err
+	}
+	testSource, err := fuzzer.ExportCallSequence(sequence, testContractName)
+	if err != nil {
+		return err
+	}
+
+	// Write the test to the output file, or stdout if none was provided.
+	if outPath == "" {
+		fmt.Print(testSource)
+		return nil
+	}
+	return os.WriteFile(outPath, []byte(testSource), 0644)
+}
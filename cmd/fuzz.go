@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
@@ -40,26 +41,28 @@ func init() {
 	rootCmd.AddCommand(fuzzCmd)
 }
 
-// cmdRunFuzz executes the CLI fuzz command and navigates through the following possibilities:
+// resolveProjectConfig resolves the project configuration to use for a command and navigates through the following
+// possibilities:
 // #1: We will search for either a custom config file (via --config) or the default (medusa.json).
 // If we find it, read it. If we can't read it, throw an error.
 // #2: If a custom file was provided (--config was used), and we can't find the file, throw an error.
 // #3: If medusa.json can't be found, use the default project configuration.
-func cmdRunFuzz(cmd *cobra.Command, args []string) error {
+// Returns the project configuration and the path it was resolved from, or an error if one occurs.
+func resolveProjectConfig(cmd *cobra.Command) (*config.ProjectConfig, string, error) {
 	var projectConfig *config.ProjectConfig
 
 	// Check to see if --config flag was used and store the value of --config flag
 	configFlagUsed := cmd.Flags().Changed("config")
 	configPath, err := cmd.Flags().GetString("config")
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
 	// If --config was not used, look for `medusa.json` in the current work directory
 	if !configFlagUsed {
 		workingDirectory, err := os.Getwd()
 		if err != nil {
-			return err
+			return nil, "", err
 		}
 		configPath = filepath.Join(workingDirectory, DefaultProjectConfigFilename)
 	}
@@ -72,13 +75,13 @@ func cmdRunFuzz(cmd *cobra.Command, args []string) error {
 		// Try to read the configuration file and throw an error if something goes wrong
 		projectConfig, err = config.ReadProjectConfigFromFile(configPath)
 		if err != nil {
-			return err
+			return nil, "", err
 		}
 	}
 
 	// Possibility #2: If the --config flag was used, and we couldn't find the file, we'll throw an error
 	if configFlagUsed && existenceError != nil {
-		return existenceError
+		return nil, "", existenceError
 	}
 
 	// Possibility #3: --config flag was not used and medusa.json was not found, so use the default project config
@@ -88,9 +91,20 @@ func cmdRunFuzz(cmd *cobra.Command, args []string) error {
 
 		projectConfig, err = config.GetDefaultProjectConfig(DefaultCompilationPlatform)
 		if err != nil {
-			return err
+			return nil, "", err
 		}
 	}
+	return projectConfig, configPath, nil
+}
+
+// cmdRunFuzz executes the CLI fuzz command, resolving the project configuration, applying any flags to it, and
+// running a fuzzing campaign with it.
+func cmdRunFuzz(cmd *cobra.Command, args []string) error {
+	// Resolve our project configuration
+	projectConfig, configPath, err := resolveProjectConfig(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Update the project configuration given whatever flags were set using the CLI
 	err = updateProjectConfigWithFuzzFlags(cmd, projectConfig)
@@ -98,6 +112,20 @@ func cmdRunFuzz(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// If --print-config was used, print the effective project configuration and exit without fuzzing.
+	printConfig, err := cmd.Flags().GetBool("print-config")
+	if err != nil {
+		return err
+	}
+	if printConfig {
+		b, err := json.MarshalIndent(projectConfig, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
 	// Change our working directory to the parent directory of the project configuration file
 	// This is important as when we compile for a given platform, the paths may be relative to wherever the
 	// configuration is supplied from. Providing a file path explicitly is optional anyways, so we _should_
@@ -113,16 +141,32 @@ func cmdRunFuzz(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Stop our fuzzing on keyboard interrupts
-	c := make(chan os.Signal, 1)
+	// Stop our fuzzing on keyboard interrupts. The fuzzer flushes the corpus and prints its results once stopped, which
+	// may take some time, so a second interrupt forces an immediate exit.
+	c := make(chan os.Signal, 2)
 	signal.Notify(c, os.Interrupt)
 	go func() {
 		<-c
+		fmt.Printf("\nInterrupt received, stopping the fuzzer and flushing the corpus (interrupt again to force exit) ...\n")
 		fuzzer.Stop()
+
+		<-c
+		fmt.Printf("\nInterrupt received again, forcing exit without flushing the corpus\n")
+		os.Exit(1)
 	}()
 
 	// Start the fuzzing process with our cancellable context.
 	err = fuzzer.Start()
+	if err != nil {
+		return err
+	}
 
-	return err
+	// If we only replayed the corpus, fail if any test failed, so this may be used as a regression check.
+	if projectConfig.Fuzzing.ReplayOnly {
+		failedTestCount := len(fuzzer.TestCasesWithStatus(fuzzing.TestCaseStatusFailed))
+		if failedTestCount > 0 {
+			return fmt.Errorf("replaying the corpus resulted in %d failed test(s)", failedTestCount)
+		}
+	}
+	return nil
 }
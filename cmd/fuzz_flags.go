@@ -49,6 +49,14 @@ func addFuzzFlags() error {
 	fuzzCmd.Flags().String("corpus-dir", "",
 		fmt.Sprintf("directory path for corpus items (unless a config file is provided, default is %q)", defaultConfig.Fuzzing.CorpusDirectory))
 
+	// Replay only
+	fuzzCmd.Flags().Bool("replay-only", false,
+		fmt.Sprintf("only replay call sequences from the corpus, without generating new ones, failing if any test fails (unless a config file is provided, default is %t)", defaultConfig.Fuzzing.ReplayOnly))
+
+	// Clean room
+	fuzzCmd.Flags().Bool("clean-room", false,
+		fmt.Sprintf("generate call sequences from scratch, ignoring the corpus while still recording coverage (unless a config file is provided, default is %t)", defaultConfig.Fuzzing.CleanRoom))
+
 	// Senders
 	fuzzCmd.Flags().StringSlice("senders", []string{},
 		"account address(es) used to send state-changing txns")
@@ -64,6 +72,10 @@ func addFuzzFlags() error {
 	// Trace all
 	fuzzCmd.Flags().Bool("trace-all", false,
 		fmt.Sprintf("print the execution trace for every element in a shrunken call sequence instead of only the last element (unless a config file is provided, default is %t)", defaultConfig.Fuzzing.Testing.TraceAll))
+
+	// Print config
+	fuzzCmd.Flags().Bool("print-config", false,
+		"print the effective project configuration, after applying the config file and CLI flags, as JSON and exit")
 	return nil
 }
 
@@ -133,6 +145,22 @@ func updateProjectConfigWithFuzzFlags(cmd *cobra.Command, projectConfig *config.
 		}
 	}
 
+	// Update replay only enablement
+	if cmd.Flags().Changed("replay-only") {
+		projectConfig.Fuzzing.ReplayOnly, err = cmd.Flags().GetBool("replay-only")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Update clean room enablement
+	if cmd.Flags().Changed("clean-room") {
+		projectConfig.Fuzzing.CleanRoom, err = cmd.Flags().GetBool("clean-room")
+		if err != nil {
+			return err
+		}
+	}
+
 	// Update senders
 	if cmd.Flags().Changed("senders") {
 		projectConfig.Fuzzing.SenderAddresses, err = cmd.Flags().GetStringSlice("senders")
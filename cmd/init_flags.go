@@ -1,18 +1,40 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/crytic/medusa/fuzzing/config"
 	"github.com/spf13/cobra"
 )
 
 // addInitFlags adds the various flags for the init command
 func addInitFlags() error {
+	// Get the default project config and throw an error if we cant
+	defaultConfig, err := config.GetDefaultProjectConfig(DefaultCompilationPlatform)
+	if err != nil {
+		return err
+	}
+
 	// Output path for configuration
 	initCmd.Flags().String("out", "", "output path for the new project configuration file")
 
 	// Target file / directory
 	initCmd.Flags().String("target", "", TargetFlagDescription)
 
+	// Assertion testing
+	initCmd.Flags().Bool("assertion", false,
+		fmt.Sprintf("enable assertion testing (default is %t)", defaultConfig.Fuzzing.Testing.AssertionTesting.Enabled))
+	initCmd.Flags().Bool("no-assertion", false, "disable assertion testing")
+
+	// Property testing
+	initCmd.Flags().Bool("property", false,
+		fmt.Sprintf("enable property testing (default is %t)", defaultConfig.Fuzzing.Testing.PropertyTesting.Enabled))
+	initCmd.Flags().Bool("no-property", false, "disable property testing")
+
+	// Test view methods
+	initCmd.Flags().Bool("test-view-methods", false,
+		fmt.Sprintf("test view methods for assertion failures (default is %t)", defaultConfig.Fuzzing.Testing.AssertionTesting.TestViewMethods))
+
 	return nil
 }
 
@@ -32,5 +54,51 @@ func updateProjectConfigWithInitFlags(cmd *cobra.Command, projectConfig *config.
 		}
 	}
 
+	// If --assertion or --no-assertion was used
+	err := updateEnabledWithInitFlagPair(cmd, "assertion", &projectConfig.Fuzzing.Testing.AssertionTesting.Enabled)
+	if err != nil {
+		return err
+	}
+
+	// If --property or --no-property was used
+	err = updateEnabledWithInitFlagPair(cmd, "property", &projectConfig.Fuzzing.Testing.PropertyTesting.Enabled)
+	if err != nil {
+		return err
+	}
+
+	// If --test-view-methods was used
+	if cmd.Flags().Changed("test-view-methods") {
+		projectConfig.Fuzzing.Testing.AssertionTesting.TestViewMethods, err = cmd.Flags().GetBool("test-view-methods")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateEnabledWithInitFlagPair updates the provided enabled field using a pair of flags, where the flag with the
+// provided name enables it, and the same flag prefixed with "no-" disables it. An error is returned if both flags
+// were used.
+func updateEnabledWithInitFlagPair(cmd *cobra.Command, name string, enabled *bool) error {
+	enableFlagUsed := cmd.Flags().Changed(name)
+	disableFlagUsed := cmd.Flags().Changed("no-" + name)
+	if enableFlagUsed && disableFlagUsed {
+		return fmt.Errorf("--%s and --no-%s cannot be used together", name, name)
+	}
+
+	if enableFlagUsed {
+		enable, err := cmd.Flags().GetBool(name)
+		if err != nil {
+			return err
+		}
+		*enabled = enable
+	} else if disableFlagUsed {
+		disable, err := cmd.Flags().GetBool("no-" + name)
+		if err != nil {
+			return err
+		}
+		*enabled = !disable
+	}
 	return nil
 }
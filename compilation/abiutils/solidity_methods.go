@@ -0,0 +1,70 @@
+package abiutils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// typeAliasRegexes maps regular expressions matching Solidity type aliases to the canonical type they alias.
+var typeAliasRegexes = map[*regexp.Regexp]string{
+	regexp.MustCompile(`\buint\b`): "uint256",
+	regexp.MustCompile(`\bint\b`):  "int256",
+	regexp.MustCompile(`\bbyte\b`): "bytes1",
+}
+
+// CanonicalizeMethodSignature normalizes a user-provided method signature (e.g. "transfer(address, uint)") into the
+// canonical form used by abi.Method.Sig (e.g. "transfer(address,uint256)"). Whitespace is removed and type aliases in
+// the argument list are expanded. A method name without an argument list is returned with only whitespace removed.
+func CanonicalizeMethodSignature(signature string) string {
+	// Remove all whitespace from the signature.
+	signature = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, signature)
+
+	// If there is no argument list, there are no types to expand.
+	argsIndex := strings.Index(signature, "(")
+	if argsIndex < 0 {
+		return signature
+	}
+
+	// Expand any type aliases in the argument list.
+	args := signature[argsIndex:]
+	for aliasRegex, canonicalType := range typeAliasRegexes {
+		args = aliasRegex.ReplaceAllString(args, canonicalType)
+	}
+	return signature[:argsIndex] + args
+}
+
+// ResolveMethod resolves a user-provided method reference against the provided contract ABI. The reference may be a
+// method signature (e.g. "transfer(address,uint256)"), which is canonicalized prior to matching, or a method name
+// (e.g. "transfer"), which must not be overloaded.
+// Returns the resolved method, or an error if the reference could not be resolved to exactly one method.
+func ResolveMethod(contractAbi *abi.ABI, reference string) (*abi.Method, error) {
+	reference = CanonicalizeMethodSignature(reference)
+	matchBySignature := strings.Contains(reference, "(")
+
+	// Search for methods matching the reference.
+	var resolvedMethod *abi.Method
+	for _, method := range contractAbi.Methods {
+		method := method
+		if (matchBySignature && method.Sig != reference) || (!matchBySignature && method.RawName != reference) {
+			continue
+		}
+		if resolvedMethod != nil {
+			return nil, fmt.Errorf("method reference '%v' is ambiguous, matching both '%v' and '%v'", reference, resolvedMethod.Sig, method.Sig)
+		}
+		resolvedMethod = &method
+	}
+
+	if resolvedMethod == nil {
+		return nil, fmt.Errorf("method reference '%v' does not match any method", reference)
+	}
+	return resolvedMethod, nil
+}
@@ -0,0 +1,68 @@
+package abiutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCanonicalizeMethodSignature runs tests to ensure method signatures are normalized into the canonical form used
+// by abi.Method.Sig.
+func TestCanonicalizeMethodSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		expected  string
+	}{
+		{signature: "transfer(address,uint256)", expected: "transfer(address,uint256)"},
+		{signature: " transfer( address , uint ) ", expected: "transfer(address,uint256)"},
+		{signature: "set(int,byte,bytes)", expected: "set(int256,bytes1,bytes)"},
+		{signature: "set(uint[],int[2],(uint,byte))", expected: "set(uint256[],int256[2],(uint256,bytes1))"},
+		{signature: "set(uint8,int128,bytes32,uint256)", expected: "set(uint8,int128,bytes32,uint256)"},
+		{signature: "uint(uint)", expected: "uint(uint256)"},
+		{signature: "transfer", expected: "transfer"},
+		{signature: " transfer\t", expected: "transfer"},
+		{signature: "reset()", expected: "reset()"},
+	}
+	for _, test := range tests {
+		assert.EqualValues(t, test.expected, CanonicalizeMethodSignature(test.signature), test.signature)
+	}
+}
+
+// TestResolveMethod runs tests to ensure method references are resolved to the method they reference by signature or
+// name, and that ambiguous or unknown references are rejected.
+func TestResolveMethod(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"function","name":"mint","inputs":[{"name":"amount","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"function","name":"mint","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"}
+	]`))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		reference   string
+		expectedSig string
+		expectError bool
+	}{
+		{reference: "transfer", expectedSig: "transfer(address,uint256)"},
+		{reference: "transfer(address,uint256)", expectedSig: "transfer(address,uint256)"},
+		{reference: "transfer(address, uint)", expectedSig: "transfer(address,uint256)"},
+		{reference: "mint(uint)", expectedSig: "mint(uint256)"},
+		{reference: "mint(address,uint256)", expectedSig: "mint(address,uint256)"},
+		{reference: "mint", expectError: true},
+		{reference: "burn", expectError: true},
+		{reference: "transfer(address)", expectError: true},
+	}
+	for _, test := range tests {
+		method, err := ResolveMethod(&contractAbi, test.reference)
+		if test.expectError {
+			assert.Error(t, err, test.reference)
+			continue
+		}
+		assert.NoError(t, err, test.reference)
+		if err == nil {
+			assert.EqualValues(t, test.expectedSig, method.Sig, test.reference)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package platforms
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crytic/medusa/compilation/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// abiFileExtension describes the file extension of ABI definition files in an AbiCompilationConfig target directory.
+const abiFileExtension = ".abi"
+
+// runtimeBytecodeFileExtension describes the file extension of runtime bytecode files in an AbiCompilationConfig
+// target directory.
+const runtimeBytecodeFileExtension = ".bin-runtime"
+
+// AbiCompilationConfig describes a compilation platform which does not compile any source code. Instead, contracts
+// are defined by an ABI definition and runtime bytecode, allowing contracts without available source code (e.g.
+// already deployed contracts) to be fuzzed. Each contract is deployed with init bytecode which simply returns its
+// runtime bytecode, so constructors are not executed, unless it is given an address at which it already exists.
+type AbiCompilationConfig struct {
+	// Target describes a directory containing a "<ContractName>.abi" ABI definition file, and a
+	// "<ContractName>.bin-runtime" file with the hex-encoded runtime bytecode, for each contract.
+	Target string `json:"target"`
+
+	// Addresses describes the addresses at which contracts already exist on chain (e.g. in forked state), keyed by
+	// contract name. Contracts with an address are targeted there rather than deployed, and their runtime bytecode
+	// file is optional. If it is provided, the runtime bytecode is placed at the address in the genesis state.
+	// Otherwise, code must already exist at the address.
+	Addresses map[string]string `json:"addresses"`
+}
+
+// NewAbiCompilationConfig returns an AbiCompilationConfig with the provided target directory.
+func NewAbiCompilationConfig(target string) *AbiCompilationConfig {
+	return &AbiCompilationConfig{
+		Target:    target,
+		Addresses: make(map[string]string),
+	}
+}
+
+// Platform returns the identifier of the compilation platform.
+func (a *AbiCompilationConfig) Platform() string {
+	return "abi"
+}
+
+// GetTarget returns the target for compilation
+func (a *AbiCompilationConfig) GetTarget() string {
+	return a.Target
+}
+
+// SetTarget sets the new target for compilation
+func (a *AbiCompilationConfig) SetTarget(newTarget string) {
+	a.Target = newTarget
+}
+
+// Compile loads the ABI definition and runtime bytecode of every contract in the target directory.
+// Returns the compilations, no command-line output, or an error if one occurs.
+func (a *AbiCompilationConfig) Compile() ([]types.Compilation, string, error) {
+	// Find every ABI definition in our target directory.
+	abiPaths, err := filepath.Glob(filepath.Join(a.Target, "*"+abiFileExtension))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(abiPaths) == 0 {
+		return nil, "", fmt.Errorf("could not find any '%v' ABI definition files in '%v'", abiFileExtension, a.Target)
+	}
+
+	// Parse the addresses of contracts which already exist on chain, ensuring each has an ABI definition. We check in
+	// sorted order so the reported contract is deterministic.
+	existingAddresses := make(map[string]common.Address, len(a.Addresses))
+	addressContractNames := maps.Keys(a.Addresses)
+	slices.Sort(addressContractNames)
+	for _, contractName := range addressContractNames {
+		if !common.IsHexAddress(a.Addresses[contractName]) {
+			return nil, "", fmt.Errorf("invalid address '%s' specified for contract '%s'", a.Addresses[contractName], contractName)
+		}
+		if !slices.Contains(abiPaths, filepath.Join(a.Target, contractName+abiFileExtension)) {
+			return nil, "", fmt.Errorf("an address was specified for contract '%s', but no ABI definition was found for it", contractName)
+		}
+		existingAddresses[contractName] = common.HexToAddress(a.Addresses[contractName])
+	}
+
+	// Create a compilation with a source for each ABI definition.
+	compilation := types.NewCompilation()
+	for _, abiPath := range abiPaths {
+		contractName := strings.TrimSuffix(filepath.Base(abiPath), abiFileExtension)
+
+		// Parse our ABI definition
+		abiData, err := os.ReadFile(abiPath)
+		if err != nil {
+			return nil, "", err
+		}
+		contractAbi, err := abi.JSON(bytes.NewReader(abiData))
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to parse ABI definition for contract '%s': %v", contractName, err)
+		}
+
+		// Parse our runtime bytecode, which must be provided alongside the ABI definition unless the contract already
+		// exists at an address.
+		var existingAddress *common.Address
+		if address, ok := existingAddresses[contractName]; ok {
+			existingAddress = &address
+		}
+		runtimeBytecodePath := strings.TrimSuffix(abiPath, abiFileExtension) + runtimeBytecodeFileExtension
+		runtimeBytecodeData, err := os.ReadFile(runtimeBytecodePath)
+		if err != nil && (existingAddress == nil || !os.IsNotExist(err)) {
+			return nil, "", fmt.Errorf("unable to read runtime bytecode for contract '%s': %v", contractName, err)
+		}
+		var runtimeBytecode, initBytecode []byte
+		if runtimeBytecodeData != nil {
+			runtimeBytecode, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(runtimeBytecodeData)), "0x"))
+			if err != nil || len(runtimeBytecode) == 0 {
+				return nil, "", fmt.Errorf("unable to parse runtime bytecode for contract '%s'", contractName)
+			}
+		}
+
+		// Contracts which already exist at an address are not deployed, so they do not need init bytecode.
+		if existingAddress == nil {
+			initBytecode, err = createInitBytecodeForRuntimeBytecode(runtimeBytecode)
+			if err != nil {
+				return nil, "", fmt.Errorf("unable to create init bytecode for contract '%s': %v", contractName, err)
+			}
+		}
+
+		// Construct our compiled source and contract. There is no source code, so we use the ABI definition path as
+		// the source path, and there is no AST or source maps.
+		compilation.SourceList = append(compilation.SourceList, abiPath)
+		compilation.Sources[abiPath] = types.CompiledSource{
+			Ast: nil,
+			Contracts: map[string]types.CompiledContract{
+				contractName: {
+					Abi:             contractAbi,
+					InitBytecode:    initBytecode,
+					RuntimeBytecode: runtimeBytecode,
+					ExistingAddress: existingAddress,
+				},
+			},
+		}
+	}
+	return []types.Compilation{*compilation}, "", nil
+}
+
+// createInitBytecodeForRuntimeBytecode creates init bytecode which deploys the provided runtime bytecode as-is.
+// Returns the init bytecode, or an error if the runtime bytecode is too large.
+func createInitBytecodeForRuntimeBytecode(runtimeBytecode []byte) ([]byte, error) {
+	if len(runtimeBytecode) > 0xffff {
+		return nil, fmt.Errorf("runtime bytecode size %d exceeds the maximum supported size", len(runtimeBytecode))
+	}
+
+	// PUSH2 <size>, DUP1, PUSH1 <offset>, PUSH1 0, CODECOPY, PUSH1 0, RETURN, followed by the runtime bytecode.
+	size := len(runtimeBytecode)
+	initBytecode := []byte{0x61, byte(size >> 8), byte(size), 0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3}
+	return append(initBytecode, runtimeBytecode...), nil
+}
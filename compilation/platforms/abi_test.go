@@ -0,0 +1,82 @@
+package platforms
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crytic/medusa/utils/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAbiCompilation tests that contracts defined by an ABI definition and runtime bytecode can be loaded, and that
+// their init bytecode deploys the runtime bytecode.
+func TestAbiCompilation(t *testing.T) {
+	// Copy our testdata over to our testing directory
+	contractDirectory := testutils.CopyToTestDirectory(t, "testdata/abi/basic/")
+
+	// Execute our tests in the given test path
+	testutils.ExecuteInDirectory(t, contractDirectory, func() {
+		// Create an ABI provider
+		abiConfig := NewAbiCompilationConfig(contractDirectory)
+
+		// Obtain our compilations and ensure we didn't encounter an error
+		compilations, _, err := abiConfig.Compile()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, len(compilations))
+
+		// Verify our contract was loaded with its ABI and bytecode
+		for _, source := range compilations[0].Sources {
+			contract, ok := source.Contracts["Counter"]
+			assert.True(t, ok)
+			assert.EqualValues(t, 2, len(contract.Abi.Methods))
+			assert.True(t, bytes.HasSuffix(contract.InitBytecode, contract.RuntimeBytecode))
+		}
+	})
+}
+
+// TestAbiCompilationExistingAddresses tests that contracts given an address they already exist at are not given init
+// bytecode, do not require runtime bytecode, and that invalid addresses are reported.
+func TestAbiCompilationExistingAddresses(t *testing.T) {
+	// Copy our testdata over to our testing directory
+	contractDirectory := testutils.CopyToTestDirectory(t, "testdata/abi/basic/")
+
+	// Execute our tests in the given test path
+	testutils.ExecuteInDirectory(t, contractDirectory, func() {
+		// Create an ABI provider with an existing address for our contract
+		abiConfig := NewAbiCompilationConfig(contractDirectory)
+		existingAddress := common.HexToAddress("0x1234")
+		abiConfig.Addresses["Counter"] = existingAddress.Hex()
+
+		// Verify our contract has an existing address and runtime bytecode, but no init bytecode.
+		compilations, _, err := abiConfig.Compile()
+		assert.NoError(t, err)
+		for _, source := range compilations[0].Sources {
+			contract := source.Contracts["Counter"]
+			assert.EqualValues(t, &existingAddress, contract.ExistingAddress)
+			assert.Empty(t, contract.InitBytecode)
+			assert.NotEmpty(t, contract.RuntimeBytecode)
+		}
+
+		// Remove our runtime bytecode, and verify our contract is still loaded without it.
+		err = os.Remove(filepath.Join(contractDirectory, "Counter"+runtimeBytecodeFileExtension))
+		assert.NoError(t, err)
+		compilations, _, err = abiConfig.Compile()
+		assert.NoError(t, err)
+		for _, source := range compilations[0].Sources {
+			contract := source.Contracts["Counter"]
+			assert.EqualValues(t, &existingAddress, contract.ExistingAddress)
+			assert.Empty(t, contract.RuntimeBytecode)
+		}
+
+		// Invalid addresses, or addresses for contracts without an ABI definition, are reported.
+		abiConfig.Addresses["Counter"] = "0x1234zz"
+		_, _, err = abiConfig.Compile()
+		assert.Error(t, err)
+		abiConfig.Addresses = map[string]string{"Missing": existingAddress.Hex()}
+		_, _, err = abiConfig.Compile()
+		assert.Error(t, err)
+	})
+}
@@ -1,7 +1,6 @@
 package platforms
 
 import (
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -213,22 +212,24 @@ func (c *CryticCompilationConfig) Compile() ([]types.Compilation, string, error)
 			}
 
 			// Decode our init and runtime bytecode
-			initBytecode, err := hex.DecodeString(strings.TrimPrefix(contract.Bin, "0x"))
+			initBytecode, libraryPlaceholders, err := types.ParseBytecodeWithLibraryPlaceholders(contract.Bin)
 			if err != nil {
 				return nil, "", fmt.Errorf("unable to parse init bytecode for contract '%s'\n", contractName)
 			}
-			runtimeBytecode, err := hex.DecodeString(strings.TrimPrefix(contract.BinRuntime, "0x"))
+			runtimeBytecode, runtimeLibraryPlaceholders, err := types.ParseBytecodeWithLibraryPlaceholders(contract.BinRuntime)
 			if err != nil {
 				return nil, "", fmt.Errorf("unable to parse runtime bytecode for contract '%s'\n", contractName)
 			}
 
 			// Add contract details
 			compilation.Sources[sourcePath].Contracts[contractName] = types.CompiledContract{
-				Abi:             *contractAbi,
-				InitBytecode:    initBytecode,
-				RuntimeBytecode: runtimeBytecode,
-				SrcMapsInit:     contract.SrcMap,
-				SrcMapsRuntime:  contract.SrcMapRuntime,
+				Abi:                        *contractAbi,
+				InitBytecode:               initBytecode,
+				RuntimeBytecode:            runtimeBytecode,
+				LibraryPlaceholders:        libraryPlaceholders,
+				RuntimeLibraryPlaceholders: runtimeLibraryPlaceholders,
+				SrcMapsInit:                contract.SrcMap,
+				SrcMapsRuntime:             contract.SrcMapRuntime,
 			}
 		}
 
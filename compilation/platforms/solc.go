@@ -1,7 +1,6 @@
 package platforms
 
 import (
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -161,22 +160,24 @@ func (s *SolcCompilationConfig) Compile() ([]types.Compilation, string, error) {
 		}
 
 		// Decode our init and runtime bytecode
-		initBytecode, err := hex.DecodeString(strings.TrimPrefix(contract.Code, "0x"))
+		initBytecode, libraryPlaceholders, err := types.ParseBytecodeWithLibraryPlaceholders(contract.Code)
 		if err != nil {
 			return nil, "", fmt.Errorf("unable to parse init bytecode for contract '%s'\n", contractName)
 		}
-		runtimeBytecode, err := hex.DecodeString(strings.TrimPrefix(contract.RuntimeCode, "0x"))
+		runtimeBytecode, runtimeLibraryPlaceholders, err := types.ParseBytecodeWithLibraryPlaceholders(contract.RuntimeCode)
 		if err != nil {
 			return nil, "", fmt.Errorf("unable to parse runtime bytecode for contract '%s'\n", contractName)
 		}
 
 		// Construct our compiled contract
 		compilation.Sources[sourcePath].Contracts[contractName] = types.CompiledContract{
-			Abi:             *contractAbi,
-			InitBytecode:    initBytecode,
-			RuntimeBytecode: runtimeBytecode,
-			SrcMapsInit:     contract.Info.SrcMap.(string),
-			SrcMapsRuntime:  contract.Info.SrcMapRuntime,
+			Abi:                        *contractAbi,
+			InitBytecode:               initBytecode,
+			RuntimeBytecode:            runtimeBytecode,
+			LibraryPlaceholders:        libraryPlaceholders,
+			RuntimeLibraryPlaceholders: runtimeLibraryPlaceholders,
+			SrcMapsInit:                contract.Info.SrcMap.(string),
+			SrcMapsRuntime:             contract.Info.SrcMapRuntime,
 		}
 	}
 
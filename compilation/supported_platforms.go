@@ -17,6 +17,7 @@ func init() {
 	generators := []func() platforms.PlatformConfig{
 		func() platforms.PlatformConfig { return platforms.NewSolcCompilationConfig("contract.sol") },
 		func() platforms.PlatformConfig { return platforms.NewCryticCompilationConfig(".") },
+		func() platforms.PlatformConfig { return platforms.NewAbiCompilationConfig(".") },
 	}
 
 	// Initialize our platform config generator.
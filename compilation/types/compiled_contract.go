@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/exp/slices"
 	"strings"
 )
@@ -23,11 +24,26 @@ type CompiledContract struct {
 	// deployed. This may differ at runtime based on constructor arguments, immutables, linked libraries, etc.
 	RuntimeBytecode []byte
 
+	// LibraryPlaceholders describes the byte offsets in InitBytecode at which the address of a linked library must be
+	// substituted before deployment, keyed by the placeholder which referenced the library in the unlinked bytecode.
+	// It is nil or empty if the contract does not reference any unlinked libraries.
+	LibraryPlaceholders map[string][]int
+
+	// RuntimeLibraryPlaceholders describes the byte offsets in RuntimeBytecode at which the address of a linked library
+	// must be substituted, keyed by the placeholder which referenced the library in the unlinked bytecode. It is nil or
+	// empty if the runtime bytecode does not reference any unlinked libraries.
+	RuntimeLibraryPlaceholders map[string][]int
+
 	// SrcMapsInit describes the source mappings to associate source file and bytecode segments in InitBytecode.
 	SrcMapsInit string
 
 	// SrcMapsRuntime describes the source mappings to associate source file and bytecode segments in RuntimeBytecode.
 	SrcMapsRuntime string
+
+	// ExistingAddress describes the address at which the contract already exists on chain (e.g. in forked state), if
+	// it should be targeted there rather than deployed. If nil, the contract is deployed from InitBytecode. If set,
+	// InitBytecode is empty, and RuntimeBytecode is only set if the contract's code should be placed at the address.
+	ExistingAddress *common.Address
 }
 
 // IsMatch returns a boolean indicating whether provided contract bytecode is a match to this compiled contract
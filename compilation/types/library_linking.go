@@ -0,0 +1,142 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/exp/slices"
+)
+
+// libraryPlaceholderLength describes the length of a library address placeholder in hex-encoded bytecode. Placeholders
+// occupy the space of the 20-byte library address they are substituted with when linked.
+const libraryPlaceholderLength = common.AddressLength * 2
+
+// ParseBytecodeWithLibraryPlaceholders decodes hex-encoded bytecode which may contain unlinked library address
+// placeholders (e.g. "__$<hash>$__"). Each placeholder is replaced with a zero address in the decoded bytecode.
+// Returns the decoded bytecode, a lookup of placeholders to the byte offsets at which they occurred, or an error if one
+// occurs.
+func ParseBytecodeWithLibraryPlaceholders(bytecode string) ([]byte, map[string][]int, error) {
+	bytecode = strings.TrimPrefix(bytecode, "0x")
+	placeholders := make(map[string][]int)
+
+	// Search for each placeholder, recording its offset and substituting it with a zero address.
+	var builder strings.Builder
+	for i := 0; i < len(bytecode); {
+		// Placeholders always begin with two underscores, at an offset which aligns with a byte.
+		if i%2 == 0 && strings.HasPrefix(bytecode[i:], "__") {
+			if i+libraryPlaceholderLength > len(bytecode) {
+				return nil, nil, fmt.Errorf("bytecode contains a truncated library placeholder at offset %d", i/2)
+			}
+			placeholder := bytecode[i : i+libraryPlaceholderLength]
+			placeholders[placeholder] = append(placeholders[placeholder], i/2)
+			builder.WriteString(strings.Repeat("0", libraryPlaceholderLength))
+			i += libraryPlaceholderLength
+			continue
+		}
+		builder.WriteByte(bytecode[i])
+		i++
+	}
+
+	// Decode the bytecode with substituted placeholders.
+	decodedBytecode, err := hex.DecodeString(builder.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodedBytecode, placeholders, nil
+}
+
+// GetLibraryPlaceholders obtains the placeholders which may be used to reference a library in unlinked bytecode, given
+// the path of the source file declaring it and its name. This includes the placeholder used by Solidity 0.5.0 and
+// later, derived from the hash of the library's fully qualified name, as well as the legacy placeholder derived from
+// the name itself.
+func GetLibraryPlaceholders(sourcePath string, libraryName string) []string {
+	fullyQualifiedName := sourcePath + ":" + libraryName
+
+	// The current placeholder format is the first 34 hex characters of the hash of the fully qualified name.
+	nameHash := hex.EncodeToString(crypto.Keccak256([]byte(fullyQualifiedName)))
+	placeholder := "__$" + nameHash[:34] + "$__"
+
+	// The legacy placeholder format is the fully qualified name, truncated or padded with underscores to fit.
+	legacyName := fullyQualifiedName
+	if len(legacyName) > libraryPlaceholderLength-4 {
+		legacyName = legacyName[:libraryPlaceholderLength-4]
+	}
+	legacyPlaceholder := "__" + legacyName + strings.Repeat("_", libraryPlaceholderLength-2-len(legacyName))
+	return []string{placeholder, legacyPlaceholder}
+}
+
+// GetSourceUnitNames obtains the names a compiler may have referred to the source file at the provided path by when
+// deriving library placeholders. Compilation platforms may key sources by a path which differs from the source unit
+// name provided to the compiler (e.g. an absolute path rather than one relative to the project directory), so this
+// includes the source unit name recorded in the source's AST, as well as the absolute and working directory-relative
+// forms of the provided path.
+func GetSourceUnitNames(sourcePath string, ast any) []string {
+	sourceUnitNames := []string{sourcePath}
+	addSourceUnitName := func(name string) {
+		if name != "" && !slices.Contains(sourceUnitNames, name) {
+			sourceUnitNames = append(sourceUnitNames, name)
+		}
+	}
+
+	// Solidity records the source unit name in the root AST node.
+	if astMap, ok := ast.(map[string]any); ok {
+		if absolutePath, ok := astMap["absolutePath"].(string); ok {
+			addSourceUnitName(absolutePath)
+		}
+	}
+
+	// Source unit names always use forward slashes, and are commonly relative to the directory compilation occurred in.
+	if absolutePath, err := filepath.Abs(sourcePath); err == nil {
+		addSourceUnitName(filepath.ToSlash(absolutePath))
+		if workingDirectory, err := os.Getwd(); err == nil {
+			if relativePath, err := filepath.Rel(workingDirectory, absolutePath); err == nil && !strings.HasPrefix(relativePath, "..") {
+				addSourceUnitName(filepath.ToSlash(relativePath))
+			}
+		}
+	}
+	return sourceUnitNames
+}
+
+// LinkLibraries creates a copy of the CompiledContract whose init and runtime bytecode have each library placeholder
+// substituted with the address of the library it references, so it may be deployed.
+// Returns the linked CompiledContract, or an error if a placeholder could not be resolved.
+func (c *CompiledContract) LinkLibraries(placeholderAddresses map[string]common.Address) (*CompiledContract, error) {
+	linkedContract := *c
+	var err error
+	linkedContract.InitBytecode, err = linkLibraries(c.InitBytecode, c.LibraryPlaceholders, placeholderAddresses)
+	if err != nil {
+		return nil, err
+	}
+	linkedContract.RuntimeBytecode, err = linkLibraries(c.RuntimeBytecode, c.RuntimeLibraryPlaceholders, placeholderAddresses)
+	if err != nil {
+		return nil, err
+	}
+	linkedContract.LibraryPlaceholders = nil
+	linkedContract.RuntimeLibraryPlaceholders = nil
+	return &linkedContract, nil
+}
+
+// linkLibraries creates a copy of the provided bytecode with the address of each library substituted at the offsets
+// of the placeholders which reference it.
+// Returns the linked bytecode, or an error if a placeholder could not be resolved.
+func linkLibraries(bytecode []byte, placeholders map[string][]int, placeholderAddresses map[string]common.Address) ([]byte, error) {
+	linkedBytecode := slices.Clone(bytecode)
+	for placeholder, offsets := range placeholders {
+		address, ok := placeholderAddresses[placeholder]
+		if !ok {
+			return nil, fmt.Errorf("could not resolve the address of the library referenced by placeholder '%v'", placeholder)
+		}
+		for _, offset := range offsets {
+			if offset < 0 || offset+common.AddressLength > len(linkedBytecode) {
+				return nil, fmt.Errorf("library placeholder '%v' at offset %d is out of bounds of the bytecode", placeholder, offset)
+			}
+			copy(linkedBytecode[offset:offset+common.AddressLength], address.Bytes())
+		}
+	}
+	return linkedBytecode, nil
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseBytecodeWithLibraryPlaceholders runs tests to ensure library placeholders are substituted with zero
+// addresses and their byte offsets are recorded, and that malformed bytecode is rejected.
+func TestParseBytecodeWithLibraryPlaceholders(t *testing.T) {
+	placeholders := GetLibraryPlaceholders("contracts/Math.sol", "Math")
+	assert.Len(t, placeholders, 2)
+	for _, placeholder := range placeholders {
+		assert.Len(t, placeholder, libraryPlaceholderLength)
+	}
+
+	// Parse bytecode referencing the library by its current and legacy placeholders.
+	bytecode, offsets, err := ParseBytecodeWithLibraryPlaceholders("0x6001" + placeholders[0] + "60" + placeholders[1] + placeholders[0])
+	assert.NoError(t, err)
+	expected := append([]byte{0x60, 0x01}, make([]byte, common.AddressLength)...)
+	expected = append(expected, 0x60)
+	expected = append(expected, make([]byte, common.AddressLength*2)...)
+	assert.EqualValues(t, expected, bytecode)
+	assert.EqualValues(t, map[string][]int{placeholders[0]: {2, 43}, placeholders[1]: {23}}, offsets)
+
+	// Bytecode without placeholders is decoded as-is.
+	bytecode, offsets, err = ParseBytecodeWithLibraryPlaceholders("600160")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []byte{0x60, 0x01, 0x60}, bytecode)
+	assert.Empty(t, offsets)
+
+	// Truncated placeholders and invalid hex are rejected.
+	_, _, err = ParseBytecodeWithLibraryPlaceholders("6001" + placeholders[0][:10])
+	assert.Error(t, err)
+	_, _, err = ParseBytecodeWithLibraryPlaceholders("60zz")
+	assert.Error(t, err)
+}
+
+// TestLinkLibraries runs tests to ensure library addresses are linked into both the init and runtime bytecode of a
+// copy of a contract, leaving the original unlinked, and that unresolved placeholders are rejected.
+func TestLinkLibraries(t *testing.T) {
+	placeholder := GetLibraryPlaceholders("contracts/Math.sol", "Math")[0]
+	initBytecode, initOffsets, err := ParseBytecodeWithLibraryPlaceholders("6001" + placeholder + "60")
+	assert.NoError(t, err)
+	runtimeBytecode, runtimeOffsets, err := ParseBytecodeWithLibraryPlaceholders(placeholder + "00")
+	assert.NoError(t, err)
+	contract := &CompiledContract{
+		InitBytecode:               initBytecode,
+		RuntimeBytecode:            runtimeBytecode,
+		LibraryPlaceholders:        initOffsets,
+		RuntimeLibraryPlaceholders: runtimeOffsets,
+	}
+
+	// Link the library's address into the contract.
+	address := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	linkedContract, err := contract.LinkLibraries(map[string]common.Address{placeholder: address})
+	assert.NoError(t, err)
+	assert.EqualValues(t, append(append([]byte{0x60, 0x01}, address.Bytes()...), 0x60), linkedContract.InitBytecode)
+	assert.EqualValues(t, append(address.Bytes(), 0x00), linkedContract.RuntimeBytecode)
+	assert.Empty(t, linkedContract.LibraryPlaceholders)
+	assert.Empty(t, linkedContract.RuntimeLibraryPlaceholders)
+
+	// The original contract remains unlinked.
+	assert.EqualValues(t, initBytecode, contract.InitBytecode)
+	assert.EqualValues(t, make([]byte, common.AddressLength), contract.RuntimeBytecode[:common.AddressLength])
+
+	// Placeholders without a resolved address are rejected.
+	_, err = contract.LinkLibraries(map[string]common.Address{})
+	assert.Error(t, err)
+}
+
+// TestGetSourceUnitNames runs tests to ensure library placeholders can be derived for the source unit name used by the
+// compiler, even if the source was keyed by a different form of its path.
+func TestGetSourceUnitNames(t *testing.T) {
+	workingDirectory, err := os.Getwd()
+	assert.NoError(t, err)
+	absolutePath := filepath.ToSlash(filepath.Join(workingDirectory, "contracts", "Math.sol"))
+
+	// A source keyed by its absolute path also yields the path relative to the working directory, and the name
+	// recorded in its AST.
+	sourceUnitNames := GetSourceUnitNames(absolutePath, map[string]any{"absolutePath": "src/Math.sol"})
+	assert.Contains(t, sourceUnitNames, absolutePath)
+	assert.Contains(t, sourceUnitNames, "contracts/Math.sol")
+	assert.Contains(t, sourceUnitNames, "src/Math.sol")
+
+	// A source keyed by a relative path also yields its absolute path, without duplicates.
+	sourceUnitNames = GetSourceUnitNames("contracts/Math.sol", map[string]any{"absolutePath": "contracts/Math.sol"})
+	assert.EqualValues(t, []string{"contracts/Math.sol", absolutePath}, sourceUnitNames)
+
+	// Placeholders derived from different source unit names differ.
+	assert.NotEqualValues(t, GetLibraryPlaceholders(absolutePath, "Math")[0], GetLibraryPlaceholders("contracts/Math.sol", "Math")[0])
+}
@@ -24,7 +24,10 @@ type CallMessage struct {
 	// MsgTo represents the receiving address for a given core.Message.
 	MsgTo *common.Address `json:"to"`
 
-	// MsgNonce represents the core.Message sender's nonce
+	// MsgNonce represents the core.Message sender's nonce. Because CallMessage reports itself as a fake message (see
+	// IsFake), nonce checks are skipped during execution, so this value is never generated by the fuzzer. Instead, it
+	// is populated from the sender's account nonce by FillFromTestChainProperties, so it remains representative of
+	// chain state (e.g. when deriving contract creation addresses for receipts).
 	MsgNonce uint64 `json:"nonce"`
 
 	// MsgValue represents ETH value to be sent to the receiver of the message.
@@ -106,7 +109,8 @@ func NewCallMessageWithAbiValueData(from common.Address, to *common.Address, non
 // FillFromTestChainProperties populates gas limit, price, nonce, and other fields automatically based on the worker's
 // underlying test chain properties if they are not yet set.
 func (m *CallMessage) FillFromTestChainProperties(chain *chain.TestChain) {
-	// Set our nonce for this
+	// Set our nonce for this message from the sender's current account nonce. Nonce checks are skipped during
+	// execution, so this keeps the value coherent without requiring per-sender nonce tracking during generation.
 	m.MsgNonce = chain.State().GetNonce(m.MsgFrom)
 
 	// If a gas limit was not provided, allow the entire block gas limit to be used for this message.
@@ -119,10 +123,14 @@ func (m *CallMessage) FillFromTestChainProperties(chain *chain.TestChain) {
 		m.MsgGasPrice = big.NewInt(1)
 	}
 
-	// Setting fee and tip cap to zero alongside the NoBaseFee for the vm.Config will bypass base fee validation.
-	// TODO: Set this appropriately for newer transaction types.
-	m.MsgGasFeeCap = big.NewInt(0)
-	m.MsgGasTipCap = big.NewInt(0)
+	// If a fee and tip cap were not provided, we set them to zero. Alongside the NoBaseFee for the vm.Config, this
+	// will bypass base fee validation.
+	if m.MsgGasFeeCap == nil {
+		m.MsgGasFeeCap = big.NewInt(0)
+	}
+	if m.MsgGasTipCap == nil {
+		m.MsgGasTipCap = big.NewInt(0)
+	}
 }
 
 func (m *CallMessage) From() common.Address { return m.MsgFrom }
@@ -147,14 +155,21 @@ func (m *CallMessage) Data() []byte {
 	return m.MsgData
 }
 func (m *CallMessage) AccessList() coreTypes.AccessList { return nil }
-func (m *CallMessage) IsFake() bool                     { return true }
+
+// IsFake indicates the message should not be subject to nonce or EOA checks during execution. This allows call
+// sequences to be reordered, shrunk, or replayed without invalidating nonces.
+func (m *CallMessage) IsFake() bool { return true }
 
 // Clone creates a copy of the given message and its underlying components, or an error if one occurs.
 func (m *CallMessage) Clone() (*CallMessage, error) {
 	// Clone our underlying ABI values data if we have any.
-	clonedAbiValues, err := m.MsgDataAbiValues.Clone()
-	if err != nil {
-		return nil, err
+	var clonedAbiValues *CallMessageDataAbiValues
+	if m.MsgDataAbiValues != nil {
+		var err error
+		clonedAbiValues, err = m.MsgDataAbiValues.Clone()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Create a message with the same data copied over.
@@ -27,13 +27,22 @@ type CallMessageDataAbiValues struct {
 	// encodedInputValues stores the raw encoded input values when decoding from JSON. The actual InputValues will be
 	// decoded using this and the resolved Method once Resolve is called.
 	encodedInputValues []any
+
+	// enumNames stores optional enum member names for input arguments when decoding from JSON, allowing enum input
+	// values to be specified by member name. It is used to decode encodedInputValues once Resolve is called.
+	enumNames valuegeneration.ABIEnumNames
+
+	// JSONArgumentCodecs optionally describes custom JSON representations used to encode InputValues when marshalling
+	// to JSON, by ABI type category. It is not serialized, and is set by Resolve when deserializing.
+	JSONArgumentCodecs valuegeneration.JSONArgumentCodecs
 }
 
 // callMessageDataAbiValuesMarshal is used as an internal struct to represent JSON serialized data for
 // CallMessageDataAbiValues.
 type callMessageDataAbiValuesMarshal struct {
-	MethodName         string `json:"methodName"`
-	EncodedInputValues []any  `json:"inputValues"`
+	MethodName         string                       `json:"methodName"`
+	EncodedInputValues []any                        `json:"inputValues"`
+	EnumNames          valuegeneration.ABIEnumNames `json:"enumNames,omitempty"`
 }
 
 // Clone creates a copy of the given message data and its underlying components, or an error if one occurs.
@@ -44,6 +53,8 @@ func (m *CallMessageDataAbiValues) Clone() (*CallMessageDataAbiValues, error) {
 		InputValues:        nil, // set lower
 		methodName:         m.methodName,
 		encodedInputValues: m.encodedInputValues,
+		enumNames:          m.enumNames,
+		JSONArgumentCodecs: m.JSONArgumentCodecs,
 	}
 
 	// If we have a method, clone our input values by packing/unpacking them.
@@ -63,8 +74,10 @@ func (m *CallMessageDataAbiValues) Clone() (*CallMessageDataAbiValues, error) {
 }
 
 // Resolve takes a previously unmarshalled CallMessageDataAbiValues and resolves all internal data needed for it to be
-// used at runtime by resolving the abi.Method it references from the provided contract ABI.
-func (d *CallMessageDataAbiValues) Resolve(contractAbi abi.ABI) error {
+// used at runtime by resolving the abi.Method it references from the provided contract ABI. Encoded dynamic arrays,
+// byte arrays and strings longer than maxDecodedLength are rejected with an error, unless it is zero. Input values are
+// decoded using the provided JSONArgumentCodecs, which are also used to encode them if they are marshalled again.
+func (d *CallMessageDataAbiValues) Resolve(contractAbi abi.ABI, maxDecodedLength int, codecs valuegeneration.JSONArgumentCodecs) error {
 	// Try to resolve the method from our contract ABI.
 	if resolvedMethod, ok := contractAbi.Methods[d.methodName]; ok {
 		d.Method = &resolvedMethod
@@ -73,14 +86,16 @@ func (d *CallMessageDataAbiValues) Resolve(contractAbi abi.ABI) error {
 	}
 
 	// Now that we've resolved the method, decode our encoded input values.
-	decodedArguments, err := valuegeneration.DecodeJSONArgumentsFromSlice(d.Method.Inputs, d.encodedInputValues, make(map[string]common.Address))
+	decodedArguments, err := valuegeneration.DecodeJSONArgumentsFromSlice(d.Method.Inputs, d.encodedInputValues, make(map[string]common.Address), d.enumNames, maxDecodedLength, codecs)
 	if err != nil {
 		return err
 	}
 
 	// If we've decoded arguments successfully, set them and clear our encoded arguments as they're no longer needed.
 	d.InputValues = decodedArguments
+	d.JSONArgumentCodecs = codecs
 	d.encodedInputValues = nil
+	d.enumNames = nil
 	return nil
 }
 
@@ -125,7 +140,7 @@ func (d *CallMessageDataAbiValues) MarshalJSON() ([]byte, error) {
 	}
 
 	// For every input we have, we serialize it.
-	inputValuesEncoded, err := valuegeneration.EncodeJSONArgumentsToSlice(d.Method.Inputs, d.InputValues)
+	inputValuesEncoded, err := valuegeneration.EncodeJSONArgumentsToSlice(d.Method.Inputs, d.InputValues, d.JSONArgumentCodecs)
 	if err != nil {
 		return nil, err
 	}
@@ -151,5 +166,6 @@ func (d *CallMessageDataAbiValues) UnmarshalJSON(b []byte) error {
 	// Set our data in our actual structure now
 	d.methodName = marshalData.MethodName
 	d.encodedInputValues = marshalData.EncodedInputValues
+	d.enumNames = marshalData.EnumNames
 	return nil
 }
@@ -70,6 +70,27 @@ func (cs CallSequence) Clone() (CallSequence, error) {
 	return r, nil
 }
 
+// PinBlockContexts sets the BlockContextOverrides of each executed element which was the first transaction in its
+// block to the block context it was executed in. Elements which were included in the same block as a previous element
+// are left as-is, so they are included in the same block again. This allows the sequence to be replayed in the same
+// block contexts it was executed in (e.g. to reproduce a failure).
+func (cs CallSequence) PinBlockContexts() {
+	for _, cse := range cs {
+		if cse == nil || cse.ChainReference == nil || cse.ChainReference.TransactionIndex != 0 {
+			continue
+		}
+		header := cse.ChainReference.Block.Header
+		blockNumber := header.Number.Uint64()
+		blockTimestamp := header.Time
+		coinbase := header.Coinbase
+		cse.BlockContextOverrides = &BlockContextOverrides{
+			BlockNumber:    &blockNumber,
+			BlockTimestamp: &blockTimestamp,
+			Coinbase:       &coinbase,
+		}
+	}
+}
+
 // Hash calculates a unique hash which represents the uniqueness of the call sequence and each element in it. It does
 // not hash execution/result data.
 // Returns the calculated hash, or an error if one occurs.
@@ -147,6 +168,11 @@ type CallSequenceElement struct {
 	// value will not be used.
 	BlockTimestampDelay uint64 `json:"blockTimestampDelay"`
 
+	// BlockContextOverrides optionally describes block context values which should be pinned when executing this
+	// transaction, overriding those derived from BlockNumberDelay and BlockTimestampDelay. If non-nil, the transaction
+	// is always executed in a new block. This allows the block context which triggered a failure to be replayed exactly.
+	BlockContextOverrides *BlockContextOverrides `json:"blockContextOverrides,omitempty"`
+
 	// ChainReference describes the inclusion of the Call as a transaction in a block. This block may not yet be
 	// committed to its underlying chain if this is a CallSequenceElement was just executed. Additional transactions
 	// may be included before the block is committed. This reference will remain compatible after the block finalizes.
@@ -179,24 +205,63 @@ func (cse *CallSequenceElement) Clone() (*CallSequenceElement, error) {
 
 	// Clone the element
 	clone := &CallSequenceElement{
-		Contract:            cse.Contract,
-		Call:                clonedCall,
-		BlockNumberDelay:    cse.BlockNumberDelay,
-		BlockTimestampDelay: cse.BlockTimestampDelay,
-		ChainReference:      cse.ChainReference,
-		ExecutionTrace:      cse.ExecutionTrace,
+		Contract:              cse.Contract,
+		Call:                  clonedCall,
+		BlockNumberDelay:      cse.BlockNumberDelay,
+		BlockTimestampDelay:   cse.BlockTimestampDelay,
+		BlockContextOverrides: cse.BlockContextOverrides.Clone(),
+		ChainReference:        cse.ChainReference,
+		ExecutionTrace:        cse.ExecutionTrace,
 	}
 	return clone, nil
 }
 
+// ResolveRuntimeReferences resolves the runtime references of a deserialized CallSequenceElement: the contract its
+// call targets, which is looked up by address in the provided deployed contracts, and the ABI values used to produce
+// its call data. Elements which deploy a contract have nothing to resolve. Encoded dynamic arrays, byte arrays and
+// strings in the ABI values longer than maxDecodedLength are rejected, unless it is zero. The ABI values are decoded
+// using the provided JSONArgumentCodecs.
+// Returns an error if the targeted contract or method could not be resolved.
+func (cse *CallSequenceElement) ResolveRuntimeReferences(deployedContracts map[common.Address]*fuzzingTypes.Contract, maxDecodedLength int, codecs valuegeneration.JSONArgumentCodecs) error {
+	// If we are deploying a contract and not targeting one with this call, there should be no work to do.
+	if cse.Call.MsgTo == nil {
+		return nil
+	}
+
+	// We are calling a contract with this call, ensure we can resolve the contract call is targeting.
+	resolvedContract, resolvedContractExists := deployedContracts[*cse.Call.MsgTo]
+	if !resolvedContractExists {
+		return fmt.Errorf("contract at address '%v' could not be resolved", cse.Call.MsgTo.String())
+	}
+	cse.Contract = resolvedContract
+
+	// Next, if our sequence element uses ABI values to produce call data, our deserialized data is not yet
+	// sufficient for runtime use, until we use it to resolve runtime references.
+	if cse.Call.MsgDataAbiValues != nil {
+		return cse.Call.MsgDataAbiValues.Resolve(resolvedContract.CompiledContract().Abi, maxDecodedLength, codecs)
+	}
+	return nil
+}
+
 // Method obtains the abi.Method targeted by the CallSequenceElement.Call, or an error if one occurred while obtaining
-// it.
+// it. Calls without data target the receive function, and calls which match no method target the fallback function,
+// if the contract defines them.
 func (cse *CallSequenceElement) Method() (*abi.Method, error) {
 	// If there is no resolved contract definition, we return no method.
 	if cse.Contract == nil {
 		return nil, nil
 	}
-	return cse.Contract.CompiledContract().Abi.MethodById(cse.Call.Data())
+
+	// Resolve the method by its selector, falling back to the receive or fallback functions as the EVM would.
+	contractAbi := &cse.Contract.CompiledContract().Abi
+	if len(cse.Call.Data()) == 0 && contractAbi.HasReceive() {
+		return &contractAbi.Receive, nil
+	}
+	method, err := contractAbi.MethodById(cse.Call.Data())
+	if err != nil && contractAbi.HasFallback() {
+		return &contractAbi.Fallback, nil
+	}
+	return method, err
 }
 
 // String returns a displayable string representing the CallSequenceElement.
@@ -210,17 +275,23 @@ func (cse *CallSequenceElement) String() string {
 	// Obtain our method name
 	method, err := cse.Method()
 	methodName := "<unresolved method>"
+	argsText := "<unable to unpack args>"
 	if err == nil && method != nil {
 		methodName = method.Name
-	}
-
-	// Next decode our arguments (we jump four bytes to skip the function selector)
-	args, err := method.Inputs.Unpack(cse.Call.Data()[4:])
-	argsText := "<unable to unpack args>"
-	if err == nil {
-		argsText, err = valuegeneration.EncodeABIArgumentsToString(method.Inputs, args)
-		if err != nil {
-			argsText = "<unresolved args>"
+		if method.Type == abi.Receive {
+			// Receive and fallback functions have no name or arguments to decode.
+			methodName, argsText = "receive", ""
+		} else if method.Type == abi.Fallback {
+			methodName, argsText = "fallback", ""
+		} else if len(cse.Call.Data()) >= 4 {
+			// Next decode our arguments (we jump four bytes to skip the function selector)
+			args, err := method.Inputs.Unpack(cse.Call.Data()[4:])
+			if err == nil {
+				argsText, err = valuegeneration.EncodeABIArgumentsToString(method.Inputs, args)
+				if err != nil {
+					argsText = "<unresolved args>"
+				}
+			}
 		}
 	}
 
@@ -271,6 +342,43 @@ func (cse *CallSequenceElement) AttachExecutionTrace(chain *chain.TestChain, con
 	return nil
 }
 
+// BlockContextOverrides describes block context values to pin when executing a CallSequenceElement. Each value is
+// optional, and is derived as usual if nil. Values which are invalid for the chain the element is executed on (e.g.
+// a block number which does not exceed that of the previous block) are skipped, and derived as usual instead.
+type BlockContextOverrides struct {
+	// BlockNumber describes the block number the transaction should be executed in. It must exceed the block number of
+	// the previous block.
+	BlockNumber *uint64 `json:"blockNumber,omitempty"`
+
+	// BlockTimestamp describes the timestamp of the block the transaction should be executed in. It must exceed the
+	// timestamp of the previous block by at least as much as the block number is advanced.
+	BlockTimestamp *uint64 `json:"blockTimestamp,omitempty"`
+
+	// Coinbase describes the coinbase address of the block the transaction should be executed in.
+	Coinbase *common.Address `json:"coinbase,omitempty"`
+}
+
+// Clone creates a copy of the underlying BlockContextOverrides. Returns nil if the overrides are nil.
+func (o *BlockContextOverrides) Clone() *BlockContextOverrides {
+	if o == nil {
+		return nil
+	}
+	clone := &BlockContextOverrides{}
+	if o.BlockNumber != nil {
+		blockNumber := *o.BlockNumber
+		clone.BlockNumber = &blockNumber
+	}
+	if o.BlockTimestamp != nil {
+		blockTimestamp := *o.BlockTimestamp
+		clone.BlockTimestamp = &blockTimestamp
+	}
+	if o.Coinbase != nil {
+		coinbase := *o.Coinbase
+		clone.Coinbase = &coinbase
+	}
+	return clone
+}
+
 // CallSequenceElementChainReference references the inclusion of a CallSequenceElement's underlying call being
 // included in a block as a transaction.
 type CallSequenceElementChainReference struct {
@@ -0,0 +1,55 @@
+package calls
+
+import (
+	"github.com/crytic/medusa/fuzzing/coverage"
+)
+
+// CallCoverageDelta describes the coverage contributed by a single call in an executed CallSequence.
+type CallCoverageDelta struct {
+	// CoverageIncreased indicates whether the call covered code which did not revert, and which was not covered by
+	// the base coverage or any previous call in the sequence.
+	CoverageIncreased bool
+	// RevertedCoverageIncreased indicates whether the call covered code which reverted, and which was not covered by
+	// the base coverage or any previous call in the sequence.
+	RevertedCoverageIncreased bool
+}
+
+// CoverageDeltas computes which calls in an executed CallSequence introduced new coverage, relative to the provided
+// base coverage maps and all previous calls in the sequence. The base coverage maps are not modified, and may be nil.
+// Coverage is obtained from the results recorded by a coverage.CoverageTracer when the sequence was executed. A
+// FuzzerWorker retains these results until it has finished testing a sequence, so this may be called by its hooks.
+// Calls with no recorded coverage (e.g. because CoverageEnabled was false, or the sequence has finished being tested)
+// are reported as introducing no new coverage.
+// Returns a CallCoverageDelta for each call in the sequence, or an error if one occurs.
+func (cs CallSequence) CoverageDeltas(baseCoverageMaps *coverage.CoverageMaps) ([]CallCoverageDelta, error) {
+	// Create the coverage maps we accumulate each call's coverage into.
+	var accumulatedCoverageMaps *coverage.CoverageMaps
+	if baseCoverageMaps != nil {
+		accumulatedCoverageMaps = baseCoverageMaps.Clone()
+	} else {
+		accumulatedCoverageMaps = coverage.NewCoverageMaps()
+	}
+
+	// Merge each call's coverage, in order, recording whether it changed our accumulated coverage.
+	deltas := make([]CallCoverageDelta, len(cs))
+	for i, element := range cs {
+		if element == nil || element.ChainReference == nil {
+			continue
+		}
+		callCoverageMaps := coverage.GetCoverageTracerResults(element.ChainReference.MessageResults())
+		if callCoverageMaps == nil {
+			continue
+		}
+
+		// Merge a copy of the call's coverage, so later merges do not modify the results recorded for the call.
+		coverageIncreased, revertedCoverageIncreased, err := accumulatedCoverageMaps.Update(callCoverageMaps.Clone())
+		if err != nil {
+			return nil, err
+		}
+		deltas[i] = CallCoverageDelta{
+			CoverageIncreased:         coverageIncreased,
+			RevertedCoverageIncreased: revertedCoverageIncreased,
+		}
+	}
+	return deltas, nil
+}
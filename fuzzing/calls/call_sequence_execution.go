@@ -52,8 +52,10 @@ func ExecuteCallSequenceIteratively(chain *chain.TestChain, fetchElementFunc Exe
 		// block that is empty to try adding this tx there instead.
 		// If we encounter an error on an empty block, we throw the error as there is nothing more we can do.
 		for {
-			// If we have a pending block, but we intend to delay this call from the last, we commit that block.
-			if chain.PendingBlock() != nil && callSequenceElement.BlockNumberDelay > 0 {
+			// If we have a pending block, but we intend to delay this call from the last, or to override its block
+			// context, we commit that block.
+			overrides := callSequenceElement.BlockContextOverrides
+			if chain.PendingBlock() != nil && (callSequenceElement.BlockNumberDelay > 0 || overrides != nil) {
 				err := chain.PendingBlockCommit()
 				if err != nil {
 					return callSequenceExecuted, err
@@ -77,7 +79,31 @@ func ExecuteCallSequenceIteratively(chain *chain.TestChain, fetchElementFunc Exe
 				if numberDelay > timeDelay {
 					numberDelay = timeDelay
 				}
-				_, err := chain.PendingBlockCreateWithParameters(chain.Head().Header.Number.Uint64()+numberDelay, chain.Head().Header.Time+timeDelay, nil)
+				headNumber := chain.Head().Header.Number.Uint64()
+				headTime := chain.Head().Header.Time
+				blockNumber := headNumber + numberDelay
+				blockTime := headTime + timeDelay
+				coinbase := chain.Head().Header.Coinbase
+
+				// Apply any block context overrides pinned for this element, skipping those which do not advance the
+				// chain (e.g. because earlier calls in the sequence were changed).
+				if overrides != nil {
+					if overrides.BlockNumber != nil && *overrides.BlockNumber > headNumber {
+						blockNumber = *overrides.BlockNumber
+					}
+					if overrides.BlockTimestamp != nil && *overrides.BlockTimestamp > headTime {
+						blockTime = *overrides.BlockTimestamp
+					}
+					if overrides.Coinbase != nil {
+						coinbase = *overrides.Coinbase
+					}
+
+					// As above, we cannot jump more block numbers than time, so we clamp the block number if needed.
+					if blockNumber-headNumber > blockTime-headTime {
+						blockNumber = headNumber + (blockTime - headTime)
+					}
+				}
+				_, err := chain.PendingBlockCreateWithCoinbase(blockNumber, blockTime, nil, coinbase)
 				if err != nil {
 					return callSequenceExecuted, err
 				}
@@ -0,0 +1,83 @@
+package calls
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/crytic/medusa/chain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBlockContextSequence creates a TestChain with a funded sender, and a call sequence of value transfers from
+// it with the provided block delays.
+func newTestBlockContextSequence(t *testing.T, blockDelays []uint64) (*chain.TestChain, CallSequence) {
+	sender := common.HexToAddress("0x1000")
+	recipient := common.HexToAddress("0x2000")
+	testChain, err := chain.NewTestChain(core.GenesisAlloc{sender: {Balance: big.NewInt(1_000_000_000)}}, nil)
+	assert.NoError(t, err)
+
+	sequence := make(CallSequence, len(blockDelays))
+	for i, blockDelay := range blockDelays {
+		msg := NewCallMessage(sender, &recipient, uint64(i), big.NewInt(1), 21_000, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil)
+		sequence[i] = NewCallSequenceElement(nil, msg, blockDelay, blockDelay)
+	}
+	return testChain, sequence
+}
+
+// TestPinBlockContexts runs tests to ensure a call sequence with pinned block contexts is replayed in the same block
+// contexts it was executed in, and calls included in a previous call's block are included in it again.
+func TestPinBlockContexts(t *testing.T) {
+	// Execute our sequence, then pin the block contexts it was executed in.
+	testChain, sequence := newTestBlockContextSequence(t, []uint64{5, 0, 20})
+	_, err := ExecuteCallSequence(testChain, sequence)
+	assert.NoError(t, err)
+	sequence.PinBlockContexts()
+	assert.NotNil(t, sequence[0].BlockContextOverrides)
+	assert.Nil(t, sequence[1].BlockContextOverrides)
+	assert.NotNil(t, sequence[2].BlockContextOverrides)
+	executedHeaders := []uint64{
+		sequence[0].ChainReference.Block.Header.Number.Uint64(),
+		sequence[1].ChainReference.Block.Header.Number.Uint64(),
+		sequence[2].ChainReference.Block.Header.Number.Uint64(),
+	}
+
+	// Change the block delays of our sequence, then replay it on a new chain. The pinned block contexts should be used.
+	replayChain, _ := newTestBlockContextSequence(t, nil)
+	for _, element := range sequence {
+		element.BlockNumberDelay = 1
+		element.BlockTimestampDelay = 1
+	}
+	sequence[1].BlockNumberDelay = 0
+	_, err = ExecuteCallSequence(replayChain, sequence)
+	assert.NoError(t, err)
+	for i, element := range sequence {
+		assert.EqualValues(t, executedHeaders[i], element.ChainReference.Block.Header.Number.Uint64())
+	}
+}
+
+// TestBlockContextOverridesSkipped runs tests to ensure block context overrides which do not advance the chain are
+// skipped when executing a call sequence, rather than causing execution to fail.
+func TestBlockContextOverridesSkipped(t *testing.T) {
+	testChain, sequence := newTestBlockContextSequence(t, []uint64{1, 1, 1})
+
+	// Pin the second call to a block number and timestamp preceding the first call's block.
+	blockNumber := uint64(0)
+	blockTimestamp := uint64(0)
+	sequence[1].BlockContextOverrides = &BlockContextOverrides{BlockNumber: &blockNumber, BlockTimestamp: &blockTimestamp}
+
+	// Pin the third call to a block number which advances further than its timestamp can.
+	blockNumber = uint64(1000)
+	sequence[2].BlockContextOverrides = &BlockContextOverrides{BlockNumber: &blockNumber}
+
+	// Execute our sequence and verify each call was included in a new block, with valid block contexts.
+	_, err := ExecuteCallSequence(testChain, sequence)
+	assert.NoError(t, err)
+	for i := 1; i < len(sequence); i++ {
+		previousHeader := sequence[i-1].ChainReference.Block.Header
+		header := sequence[i].ChainReference.Block.Header
+		assert.Greater(t, header.Number.Uint64(), previousHeader.Number.Uint64())
+		assert.Greater(t, header.Time, previousHeader.Time)
+	}
+}
@@ -0,0 +1,224 @@
+package calls
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	fuzzingTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/utils/reflectionutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ExportedContractDeployment describes a contract deployed before a CallSequence executed, which a test exported by
+// CallSequence.ExportSolidityTest deploys in its setUp function.
+type ExportedContractDeployment struct {
+	// Address describes the address the contract was deployed to.
+	Address common.Address
+
+	// Contract describes the contract definition which was deployed.
+	Contract *fuzzingTypes.Contract
+
+	// ConstructorArgs describes the ABI-encoded constructor arguments the contract was deployed with.
+	ConstructorArgs []byte
+}
+
+// ExportSolidityTest renders the CallSequence as a standalone Foundry test contract with the provided name. Its setUp
+// function funds each sender of the call sequence with its provided balance, and deploys the provided contracts to the
+// addresses they were deployed to while fuzzing. Senders without a provided balance are not funded. Its test function
+// replays each call in order from the same sender, with the same value, block number and timestamp delays, asserting
+// each call succeeds or reverts as it did when it was executed. Calls with ABI values whose arguments are all
+// elementary types are rendered with their decoded arguments, while other calls are rendered with their raw call data.
+// Note: The call sequence must have been executed prior to calling this method, so the outcome of each call is known.
+// Contracts are deployed by the test contract rather than the original deployer, so constructors which depend on
+// msg.sender may require the setUp function to be adjusted.
+// Returns the Solidity source code of the test, or an error if one occurs.
+func (cs CallSequence) ExportSolidityTest(testContractName string, deployments []ExportedContractDeployment, senderBalances map[common.Address]*big.Int) (string, error) {
+	var b strings.Builder
+	b.WriteString("// SPDX-License-Identifier: UNLICENSED\n")
+	b.WriteString("pragma solidity ^0.8.0;\n\n")
+	b.WriteString("import \"forge-std/Test.sol\";\n\n")
+	b.WriteString(fmt.Sprintf("contract %s is Test {\n", testContractName))
+	b.WriteString("    function setUp() public {\n")
+	dealtSenders := make(map[common.Address]bool)
+	for _, cse := range cs {
+		sender := cse.Call.MsgFrom
+		balance, ok := senderBalances[sender]
+		if !ok || dealtSenders[sender] {
+			continue
+		}
+		dealtSenders[sender] = true
+		b.WriteString(fmt.Sprintf("        vm.deal(%s, %s);\n", exportSolidityAddress(sender), balance.String()))
+	}
+	for _, deployment := range deployments {
+		b.WriteString(fmt.Sprintf(
+			"        deployCodeTo(%q, hex\"%s\", %s);\n",
+			fmt.Sprintf("%s:%s", filepath.Base(deployment.Contract.SourcePath()), deployment.Contract.Name()),
+			hex.EncodeToString(deployment.ConstructorArgs),
+			exportSolidityAddress(deployment.Address),
+		))
+	}
+	b.WriteString("    }\n\n")
+	b.WriteString("    function test_replay() public {\n")
+	b.WriteString("        bool success;\n")
+	for i, cse := range cs {
+		lines, err := cse.exportSolidityStatements()
+		if err != nil {
+			return "", fmt.Errorf("could not export call %d of the call sequence: %v", i+1, err)
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		for _, line := range lines {
+			b.WriteString("        " + line + "\n")
+		}
+	}
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// exportSolidityStatements renders the CallSequenceElement as a list of Solidity statements used by
+// CallSequence.ExportSolidityTest.
+// Returns the rendered statements, or an error if one occurs.
+func (cse *CallSequenceElement) exportSolidityStatements() ([]string, error) {
+	// Contract deployments cannot be replayed without the contract source, so we do not support them.
+	if cse.Call.MsgTo == nil {
+		return nil, fmt.Errorf("contract deployments cannot be exported")
+	}
+
+	// We must know the outcome of the call to assert it is reproduced.
+	if cse.ChainReference == nil {
+		return nil, fmt.Errorf("the call must be executed before it can be exported")
+	}
+
+	// Describe the call being made, and advance the block if needed.
+	statements := []string{fmt.Sprintf("// %s", cse.describe())}
+	if cse.BlockNumberDelay > 0 {
+		statements = append(statements, fmt.Sprintf("vm.roll(block.number + %d);", cse.BlockNumberDelay))
+	}
+	if cse.BlockTimestampDelay > 0 {
+		statements = append(statements, fmt.Sprintf("vm.warp(block.timestamp + %d);", cse.BlockTimestampDelay))
+	}
+
+	// Render our call data, using the decoded arguments where possible.
+	callData, err := exportSolidityCallData(cse.Call)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the call from the same sender with the same value.
+	valueText := ""
+	if cse.Call.MsgValue != nil && cse.Call.MsgValue.Sign() > 0 {
+		valueText = fmt.Sprintf("{value: %s}", cse.Call.MsgValue.String())
+	}
+	statements = append(statements,
+		fmt.Sprintf("vm.prank(%s);", exportSolidityAddress(cse.Call.MsgFrom)),
+		fmt.Sprintf("(success, ) = %s.call%s(%s);", exportSolidityAddress(*cse.Call.MsgTo), valueText, callData),
+	)
+
+	// Assert the call succeeds or reverts as it did when it was executed.
+	if cse.ChainReference.MessageResults().ExecutionResult.Failed() {
+		statements = append(statements, "assertFalse(success);")
+	} else {
+		statements = append(statements, "assertTrue(success);")
+	}
+	return statements, nil
+}
+
+// describe returns a short description of the contract and method targeted by the CallSequenceElement.
+func (cse *CallSequenceElement) describe() string {
+	contractName := "<unresolved contract>"
+	if cse.Contract != nil {
+		contractName = cse.Contract.Name()
+	}
+	methodName := "<unresolved method>"
+	if cse.Call.MsgDataAbiValues != nil && cse.Call.MsgDataAbiValues.Method != nil {
+		methodName = cse.Call.MsgDataAbiValues.Method.Name
+	}
+	return fmt.Sprintf("%s.%s", contractName, methodName)
+}
+
+// exportSolidityCallData renders the call data for a CallMessage as a Solidity expression. If the message uses ABI
+// values with elementary argument types only, an abi.encodeWithSignature expression with the decoded arguments is
+// returned. Otherwise, a hex literal of the raw call data is returned.
+// Returns the Solidity expression, or an error if one occurs.
+func exportSolidityCallData(msg *CallMessage) (string, error) {
+	abiValues := msg.MsgDataAbiValues
+	if abiValues != nil && abiValues.Method != nil && len(abiValues.Method.Inputs) == len(abiValues.InputValues) {
+		args := []string{fmt.Sprintf("%q", abiValues.Method.Sig)}
+		for i, input := range abiValues.Method.Inputs {
+			arg, ok := exportSolidityElementaryValue(input.Type, abiValues.InputValues[i])
+			if !ok {
+				args = nil
+				break
+			}
+			args = append(args, arg)
+		}
+		if args != nil {
+			return fmt.Sprintf("abi.encodeWithSignature(%s)", strings.Join(args, ", ")), nil
+		}
+	}
+
+	// Fall back to the raw call data.
+	if abiValues != nil {
+		data, err := abiValues.Pack()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("hex\"%s\"", hex.EncodeToString(data)), nil
+	}
+	return fmt.Sprintf("hex\"%s\"", hex.EncodeToString(msg.MsgData)), nil
+}
+
+// exportSolidityElementaryValue renders an ABI value of an elementary type as a Solidity expression, explicitly
+// converted to its type so it is ABI encoded identically.
+// Returns the Solidity expression, and a boolean indicating whether the type was supported.
+func exportSolidityElementaryValue(inputType abi.Type, value any) (string, bool) {
+	switch inputType.T {
+	case abi.AddressTy:
+		addr, ok := value.(common.Address)
+		if !ok {
+			return "", false
+		}
+		return exportSolidityAddress(addr), true
+	case abi.UintTy, abi.IntTy:
+		return fmt.Sprintf("%s(%v)", inputType.String(), value), true
+	case abi.BoolTy:
+		b, ok := value.(bool)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%t", b), true
+	case abi.StringTy:
+		str, ok := value.(string)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("string(hex\"%s\")", hex.EncodeToString([]byte(str))), true
+	case abi.BytesTy:
+		b, ok := value.([]byte)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("bytes(hex\"%s\")", hex.EncodeToString(b)), true
+	case abi.FixedBytesTy:
+		reflectedValue := reflect.ValueOf(value)
+		if reflectedValue.Kind() != reflect.Array {
+			return "", false
+		}
+		b := reflectionutils.ArrayToSlice(reflectedValue).([]byte)
+		return fmt.Sprintf("%s(hex\"%s\")", inputType.String(), hex.EncodeToString(b)), true
+	default:
+		return "", false
+	}
+}
+
+// exportSolidityAddress renders an address as a Solidity address literal.
+func exportSolidityAddress(addr common.Address) string {
+	return fmt.Sprintf("address(%s)", addr.String())
+}
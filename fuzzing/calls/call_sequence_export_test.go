@@ -0,0 +1,59 @@
+package calls
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	chainTypes "github.com/crytic/medusa/chain/types"
+	"github.com/crytic/medusa/compilation/types"
+	fuzzingTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExportSolidityTest runs tests to ensure an exported call sequence funds its senders and deploys the provided
+// contracts in its setUp function, and asserts each call succeeds or reverts as it did when it was executed.
+func TestExportSolidityTest(t *testing.T) {
+	// Create a contract deployment and a call sequence targeting it, with a call which succeeded and one which
+	// reverted.
+	contractAddress := common.HexToAddress("0x1000")
+	sender := common.HexToAddress("0x2000")
+	contract := fuzzingTypes.NewContract("TestContract", "contracts/TestContract.sol", &types.CompiledContract{}, nil)
+	deployments := []ExportedContractDeployment{{Address: contractAddress, Contract: contract, ConstructorArgs: []byte{0x01}}}
+
+	block := &chainTypes.Block{
+		MessageResults: []*chainTypes.MessageResults{
+			{ExecutionResult: &core.ExecutionResult{}},
+			{ExecutionResult: &core.ExecutionResult{Err: vm.ErrExecutionReverted}},
+		},
+	}
+	senderBalances := map[common.Address]*big.Int{sender: big.NewInt(1000), common.HexToAddress("0x3000"): big.NewInt(2000)}
+	sequence := make(CallSequence, 0)
+	for i := 0; i < len(block.MessageResults); i++ {
+		msg := NewCallMessage(sender, &contractAddress, 0, big.NewInt(0), 100000, nil, nil, nil, []byte{0x12, 0x34, 0x56, 0x78})
+		element := NewCallSequenceElement(contract, msg, 1, 1)
+		element.ChainReference = &CallSequenceElementChainReference{Block: block, TransactionIndex: i}
+		sequence = append(sequence, element)
+	}
+
+	// Export the test and verify its deployment and assertions.
+	source, err := sequence.ExportSolidityTest("ReplayTest", deployments, senderBalances)
+	assert.NoError(t, err)
+	assert.Contains(t, source, "contract ReplayTest is Test {")
+	assert.Contains(t, source, `deployCodeTo("TestContract.sol:TestContract", hex"01", address(0x0000000000000000000000000000000000001000));`)
+	assert.Equal(t, 1, strings.Count(source, "vm.deal("))
+	assert.Contains(t, source, "vm.deal(address(0x0000000000000000000000000000000000002000), 1000);")
+	assert.Less(t, strings.Index(source, "vm.deal("), strings.Index(source, "function test_replay()"))
+	assert.NotContains(t, source, "TODO")
+	assert.Less(t, strings.Index(source, "assertTrue(success);"), strings.Index(source, "assertFalse(success);"))
+	assert.Equal(t, 1, strings.Count(source, "assertTrue(success);"))
+	assert.Equal(t, 1, strings.Count(source, "assertFalse(success);"))
+
+	// Verify a call sequence which was never executed cannot be exported, as its outcome is unknown.
+	sequence[0].ChainReference = nil
+	_, err = sequence.ExportSolidityTest("ReplayTest", deployments, senderBalances)
+	assert.Error(t, err)
+}
@@ -0,0 +1,173 @@
+package calls
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/crytic/medusa/chain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"golang.org/x/exp/maps"
+)
+
+// StorageSlotDiff describes a storage slot of a contract whose value was changed by a CallSequence.
+type StorageSlotDiff struct {
+	// Slot describes the storage slot which was changed.
+	Slot common.Hash
+
+	// Before describes the value of the storage slot before the CallSequence executed.
+	Before common.Hash
+
+	// After describes the value of the storage slot after the CallSequence executed.
+	After common.Hash
+}
+
+// StorageDiff describes the storage slots changed by a CallSequence, keyed by the address of the contract which
+// owns them. Slots for each contract are sorted in ascending order.
+type StorageDiff map[common.Address][]StorageSlotDiff
+
+// StorageDiff takes a given chain which executed the call sequence, and it replays each call of the sequence with a
+// tracer attached to it to determine the storage slots it wrote to. It then compares the values of those storage
+// slots prior to the first call of the sequence executing and after the last call of the sequence executed.
+// Storage slots which were written to but whose final value matches their original value are not included.
+// Returns the StorageDiff of the sequence, or an error if one occurred.
+func (cs CallSequence) StorageDiff(chain *chain.TestChain) (StorageDiff, error) {
+	// If we have an empty call sequence, there can be no storage changes.
+	storageDiff := make(StorageDiff)
+	if len(cs) == 0 {
+		return storageDiff, nil
+	}
+
+	// Replay each call on the state prior to its execution, recording the storage slots it writes to.
+	tracer := newStorageWriteTracer()
+	for _, cse := range cs {
+		// Verify the element has been executed before.
+		if cse.ChainReference == nil {
+			return nil, fmt.Errorf("failed to resolve storage diff as the chain reference is nil, indicating the call sequence element has never been executed")
+		}
+
+		state, err := chain.StateFromRoot(cse.ChainReference.MessageResults().PreStateRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve storage diff due to error loading root hash from database: %v", err)
+		}
+		_, err = chain.CallContract(cse.Call, state, tracer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve storage diff due to error replaying the call: %v", err)
+		}
+	}
+
+	// Obtain the state before the first call and after the last call of the sequence.
+	stateBefore, err := chain.StateFromRoot(cs[0].ChainReference.MessageResults().PreStateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage diff due to error loading root hash from database: %v", err)
+	}
+	stateAfter, err := chain.StateFromRoot(cs[len(cs)-1].ChainReference.MessageResults().PostStateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage diff due to error loading root hash from database: %v", err)
+	}
+
+	// Compare the values of every storage slot written to, recording those which changed.
+	for address, slots := range tracer.writtenSlots {
+		for slot := range slots {
+			before := stateBefore.GetState(address, slot)
+			after := stateAfter.GetState(address, slot)
+			if before != after {
+				storageDiff[address] = append(storageDiff[address], StorageSlotDiff{Slot: slot, Before: before, After: after})
+			}
+		}
+
+		// Sort our slots so the result is deterministic.
+		sort.Slice(storageDiff[address], func(i, j int) bool {
+			return bytes.Compare(storageDiff[address][i].Slot[:], storageDiff[address][j].Slot[:]) < 0
+		})
+	}
+	return storageDiff, nil
+}
+
+// String returns a displayable string representing the StorageDiff.
+func (d StorageDiff) String() string {
+	// If we have no changes, return a special string
+	if len(d) == 0 {
+		return "<none>"
+	}
+
+	// Sort our addresses so the result is deterministic.
+	addresses := maps.Keys(d)
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	// Construct a list of strings for each contract and its changed storage slots.
+	var lines []string
+	for _, address := range addresses {
+		lines = append(lines, fmt.Sprintf("%s:", address.String()))
+		for _, slotDiff := range d[address] {
+			lines = append(lines, fmt.Sprintf(
+				"\tslot %s: %s -> %s",
+				new(big.Int).SetBytes(slotDiff.Slot[:]).String(),
+				slotDiff.Before.String(),
+				slotDiff.After.String(),
+			))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// storageWriteTracer implements vm.EVMLogger to collect the storage slots written to by SSTORE instructions, across
+// all transactions it traces.
+type storageWriteTracer struct {
+	// writtenSlots describes the storage slots written to, keyed by the address of the contract which owns them.
+	writtenSlots map[common.Address]map[common.Hash]struct{}
+}
+
+// newStorageWriteTracer returns a new storageWriteTracer.
+func newStorageWriteTracer() *storageWriteTracer {
+	return &storageWriteTracer{
+		writtenSlots: make(map[common.Address]map[common.Hash]struct{}),
+	}
+}
+
+// CaptureTxStart is called upon the start of transaction execution, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureTxStart(gasLimit uint64) {
+}
+
+// CaptureTxEnd is called upon the end of transaction execution, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureTxEnd(restGas uint64) {
+}
+
+// CaptureStart initializes the tracing operation for the top of a call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+}
+
+// CaptureEnd is called after a call to finalize tracing completes for the top of a call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+}
+
+// CaptureEnter is called upon entering of the call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+}
+
+// CaptureExit is called upon exiting of the call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+}
+
+// CaptureState records data from an EVM state update, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, vmErr error) {
+	// We only record the storage slots written by SSTORE instructions, prior to their execution. Writes in call frames
+	// which later revert are recorded too, but are excluded from diffs as they do not change the slot's value.
+	if op != vm.SSTORE || len(scope.Stack.Data()) < 1 {
+		return
+	}
+	address := scope.Contract.Address()
+	if _, ok := t.writtenSlots[address]; !ok {
+		t.writtenSlots[address] = make(map[common.Hash]struct{})
+	}
+	t.writtenSlots[address][common.Hash(scope.Stack.Back(0).Bytes32())] = struct{}{}
+}
+
+// CaptureFault records an execution fault, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
@@ -0,0 +1,62 @@
+package calls
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/crytic/medusa/chain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCallSequenceStorageDiff runs tests to ensure the storage diff of an executed call sequence includes the storage
+// slots whose values it changed, but excludes slots it wrote to and later restored to their original value.
+func TestCallSequenceStorageDiff(t *testing.T) {
+	// Create a contract which stores the second 32-byte word of its call data in the slot described by the first:
+	// PUSH1 0x20 CALLDATALOAD PUSH1 0x00 CALLDATALOAD SSTORE STOP
+	sender := common.HexToAddress("0x1000")
+	contractAddress := common.HexToAddress("0x2000")
+	testChain, err := chain.NewTestChain(core.GenesisAlloc{
+		sender: {Balance: big.NewInt(1_000_000_000)},
+		contractAddress: {
+			Balance: big.NewInt(0),
+			Code:    common.FromHex("0x6020356000355500"),
+			Storage: map[common.Hash]common.Hash{common.BigToHash(big.NewInt(0)): common.BigToHash(big.NewInt(1))},
+		},
+	}, nil)
+	assert.NoError(t, err)
+
+	// Create a sequence which changes slot 0 and later restores it, while changing slot 1.
+	writes := [][2]int64{{0, 5}, {1, 7}, {0, 1}}
+	sequence := make(CallSequence, len(writes))
+	for i, write := range writes {
+		data := append(common.BigToHash(big.NewInt(write[0])).Bytes(), common.BigToHash(big.NewInt(write[1])).Bytes()...)
+		msg := NewCallMessage(sender, &contractAddress, uint64(i), big.NewInt(0), 100_000, big.NewInt(0), big.NewInt(0), big.NewInt(0), data)
+		sequence[i] = NewCallSequenceElement(nil, msg, 1, 1)
+	}
+
+	// A sequence which was never executed has no storage diff.
+	_, err = sequence.StorageDiff(testChain)
+	assert.Error(t, err)
+
+	// Execute the sequence and verify only the changed slot is included in the storage diff.
+	_, err = ExecuteCallSequence(testChain, sequence)
+	assert.NoError(t, err)
+	storageDiff, err := sequence.StorageDiff(testChain)
+	assert.NoError(t, err)
+	assert.EqualValues(t, StorageDiff{
+		contractAddress: {{
+			Slot:   common.BigToHash(big.NewInt(1)),
+			Before: common.Hash{},
+			After:  common.BigToHash(big.NewInt(7)),
+		}},
+	}, storageDiff)
+
+	// The storage diff of the calls up to the restoring call includes both slots.
+	storageDiff, err = sequence[:2].StorageDiff(testChain)
+	assert.NoError(t, err)
+	assert.Len(t, storageDiff[contractAddress], 2)
+	assert.EqualValues(t, common.BigToHash(big.NewInt(0)), storageDiff[contractAddress][0].Slot)
+	assert.EqualValues(t, common.BigToHash(big.NewInt(5)), storageDiff[contractAddress][0].After)
+}
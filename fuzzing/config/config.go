@@ -1,11 +1,15 @@
 package config
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/crytic/medusa/chain/config"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
 	"os"
+	"strings"
 
 	"github.com/crytic/medusa/compilation"
 	"github.com/crytic/medusa/utils"
@@ -21,13 +25,24 @@ type ProjectConfig struct {
 
 // FuzzingConfig describes the configuration options used by the fuzzing.Fuzzer.
 type FuzzingConfig struct {
-	// Workers describes the amount of threads to use in fuzzing campaigns.
+	// Workers describes the amount of threads to use in fuzzing campaigns. A value of 0 or -1 indicates the number of
+	// available CPUs should be used.
 	Workers int `json:"workers"`
 
 	// WorkerResetLimit describes how many call sequences a worker should test before it is destroyed and recreated
 	// so that memory from its underlying chain is freed.
 	WorkerResetLimit int `json:"workerResetLimit"`
 
+	// WorkerMemoryLimit describes a memory budget, in megabytes, for each worker. If a worker's share of the heap memory
+	// in use exceeds this budget, it is destroyed and recreated so that memory from its underlying chain is freed, even
+	// if WorkerResetLimit was not yet reached. A zero value indicates no memory budget should be enforced.
+	WorkerMemoryLimit uint64 `json:"workerMemoryLimit"`
+
+	// RandomSeed describes the seed used to initialize the fuzzer's random provider, from which all workers derive
+	// their own deterministic random providers. If zero, a random seed is chosen and printed at startup, so the
+	// campaign may be reproduced later.
+	RandomSeed int64 `json:"randomSeed"`
+
 	// Timeout describes a time in seconds for which the fuzzing operation should run. Providing negative or zero value
 	// will result in no timeout.
 	Timeout int `json:"timeout"`
@@ -36,22 +51,121 @@ type FuzzingConfig struct {
 	// must be non-negative. A zero value indicates the test limit should not be enforced.
 	TestLimit uint64 `json:"testLimit"`
 
+	// CoverageStallTimeout describes a time in seconds after which, if no new coverage was achieved, the campaign is
+	// considered stalled. A stalled campaign is usually misconfigured (e.g. all calls revert), so a summary of the
+	// reasons calls failed is printed. A zero value indicates the timeout should not be enforced.
+	CoverageStallTimeout uint64 `json:"coverageStallTimeout"`
+
+	// CoverageStallCallLimit describes a number of calls after which, if no new coverage was achieved, the campaign is
+	// considered stalled, as with CoverageStallTimeout. A zero value indicates the limit should not be enforced.
+	CoverageStallCallLimit uint64 `json:"coverageStallCallLimit"`
+
+	// StopOnCoverageStall describes whether the campaign should be stopped once it is considered stalled, rather than
+	// only emitting a warning.
+	StopOnCoverageStall bool `json:"stopOnCoverageStall"`
+
+	// MaxCallsPerSecond describes the maximum rate at which calls should be executed, across all workers. This may be
+	// used to avoid overloading an upstream RPC node. A zero value indicates the rate should not be limited.
+	MaxCallsPerSecond float64 `json:"maxCallsPerSecond"`
+
 	// CallSequenceLength describes the maximum length a transaction sequence can be generated as.
 	CallSequenceLength int `json:"callSequenceLength"`
 
+	// CallSequenceLengthMin describes the minimum length a transaction sequence can be generated as. The length of
+	// each generated sequence is selected uniformly from [CallSequenceLengthMin, CallSequenceLength]. A zero value
+	// indicates every sequence should be generated with a length of CallSequenceLength.
+	CallSequenceLengthMin int `json:"callSequenceLengthMin"`
+
+	// CallSequenceExtensionProbability describes the probability that a worker extends the prefix of its most recent
+	// call sequence which was deemed interesting (e.g. it increased coverage), rather than starting a new sequence.
+	// This allows state set up by interesting calls to be built on further. Value range is [0.0, 1.0].
+	CallSequenceExtensionProbability float32 `json:"callSequenceExtensionProbability"`
+
+	// CallSequenceExtensionMaxLength describes the maximum length a call sequence can grow to by being extended.
+	// Extended sequences are given up to CallSequenceLength new calls, without exceeding this length. A zero value
+	// indicates twice the CallSequenceLength should be used.
+	CallSequenceExtensionMaxLength int `json:"callSequenceExtensionMaxLength"`
+
+	// ReceiveCallProbability describes the probability that a generated call is a value transfer without call data to
+	// a deployed contract which defines a receive function, to exercise it. Value range is [0.0, 1.0].
+	ReceiveCallProbability float32 `json:"receiveCallProbability"`
+
+	// FallbackCallProbability describes the probability that a generated call targets the fallback function of a
+	// deployed contract which defines one, using short call data or a method selector the contract does not define.
+	// Value is only sent if the fallback function is payable. Value range is [0.0, 1.0].
+	FallbackCallProbability float32 `json:"fallbackCallProbability"`
+
 	// CorpusDirectory describes the name for the folder that will hold the corpus and the coverage files. If empty,
 	// the in-memory corpus will be used, but not flush to disk.
 	CorpusDirectory string `json:"corpusDirectory"`
 
+	// CorpusFlushInterval describes a time in seconds between periodic flushes of the corpus to the corpus directory
+	// while fuzzing, so an unexpected termination loses at most this interval of corpus entries. A zero value indicates
+	// the corpus should only be flushed once fuzzing stops.
+	CorpusFlushInterval uint64 `json:"corpusFlushInterval"`
+
+	// MaxDecodedLength describes the maximum length of a dynamic array, byte array or string decoded from JSON (e.g.
+	// arguments in corpus files or constructor arguments). Longer values are rejected with an error, so an untrusted
+	// corpus cannot cause excessive memory allocation. A zero value indicates no limit should be enforced.
+	MaxDecodedLength int `json:"maxDecodedLength"`
+
+	// ReplayOnly describes whether the fuzzer should only replay the call sequences stored in the corpus, without
+	// generating or mutating new ones. Fuzzing stops once every corpus call sequence has been replayed, making this
+	// useful as a deterministic regression check against past findings.
+	ReplayOnly bool `json:"replayOnly"`
+
+	// CleanRoom describes whether the fuzzer should ignore the corpus when generating call sequences, generating every
+	// call sequence from scratch rather than replaying or mutating corpus call sequences. Call sequences stored in the
+	// corpus directory are not loaded. Coverage is still recorded and interesting call sequences are still added to the
+	// corpus, so the coverage achieved by pure generation can be compared against corpus-guided fuzzing.
+	CleanRoom bool `json:"cleanRoom"`
+
+	// ZeroValueSmokeTest describes whether every state changing method should be called once with the zero value for
+	// each of its arguments before fuzzing, reporting the methods whose calls failed. This helps distinguish methods
+	// which can never be called (e.g. due to access control) from those which need specific arguments.
+	ZeroValueSmokeTest bool `json:"zeroValueSmokeTest"`
+
+	// ReportUnreachableMethods describes whether the state changing methods which were never successfully executed
+	// should be reported when the fuzzer exits, as they may indicate misconfiguration (e.g. access control).
+	ReportUnreachableMethods bool `json:"reportUnreachableMethods"`
+
 	// CoverageEnabled describes whether to use coverage-guided fuzzing
 	CoverageEnabled bool `json:"coverageEnabled"`
 
+	// SaveCoverageBaseline describes whether a coverage baseline should be saved to the corpus directory (as
+	// "coverage_baseline.json") once the campaign ends, so later campaigns can compare their coverage against it.
+	SaveCoverageBaseline bool `json:"saveCoverageBaseline"`
+
+	// CoverageBaselineFile describes the path of a coverage baseline saved by a previous campaign, to compare this
+	// campaign's coverage against once it ends. If empty, coverage is not compared against a baseline.
+	CoverageBaselineFile string `json:"coverageBaselineFile"`
+
+	// CoverageRegressionThreshold describes the amount of percentage points a source file's line coverage may drop
+	// below the coverage baseline before it is considered to have regressed.
+	CoverageRegressionThreshold float64 `json:"coverageRegressionThreshold"`
+
+	// FailOnCoverageRegression describes whether the campaign should fail if coverage regressed when compared against
+	// the coverage baseline, rather than only emitting a warning.
+	FailOnCoverageRegression bool `json:"failOnCoverageRegression"`
+
 	// DeploymentOrder determines the order in which the contracts should be deployed
 	DeploymentOrder []string `json:"deploymentOrder"`
 
 	// Constructor arguments for contracts deployment. It is available only in init mode
 	ConstructorArgs map[string]map[string]any `json:"constructorArgs"`
 
+	// LibraryAddresses describes the addresses of external libraries to link into contracts before they are deployed,
+	// keyed by library name (or "sourcePath:LibraryName" to disambiguate). Each address is either hex-encoded or
+	// references a contract deployed earlier in the deployment order (e.g. "DeployedContract:MathLib"). Libraries in
+	// the deployment order are linked automatically once deployed, unless an address is provided here.
+	LibraryAddresses map[string]string `json:"libraryAddresses"`
+
+	// MaxDynamicContractTargets describes the maximum number of contracts deployed during a call sequence (e.g. by
+	// factory contracts) whose methods are targeted by the fuzzer. Contracts deployed beyond this limit are still
+	// tracked, so calls into them are resolved, but their methods are not called directly. This avoids the targeted
+	// methods being diluted by unbounded deployments. A zero value indicates no limit should be enforced.
+	MaxDynamicContractTargets uint64 `json:"maxDynamicContractTargets"`
+
 	// DeployerAddress describe the account address to be used to deploy contracts.
 	DeployerAddress string `json:"deployerAddress"`
 
@@ -59,6 +173,16 @@ type FuzzingConfig struct {
 	// campaigns.
 	SenderAddresses []string `json:"senderAddresses"`
 
+	// SenderAddressWeights optionally describes weights for addresses in SenderAddresses, biasing how often each is
+	// selected to send a call. Its probability is calculated as its weight divided by the sum of all sender weights.
+	// Senders without a specified weight are assigned a weight of 1.
+	SenderAddressWeights map[string]uint64 `json:"senderAddressWeights"`
+
+	// LearnSenderRestrictions describes whether the fuzzer should learn which methods can only be successfully called
+	// by a single sender (e.g. due to access control modifiers such as onlyOwner), biasing the senders of generated
+	// calls to them towards that sender.
+	LearnSenderRestrictions bool `json:"learnSenderRestrictions"`
+
 	// MaxBlockNumberDelay describes the maximum distance in block numbers the fuzzer will use when generating blocks
 	// compared to the previous.
 	MaxBlockNumberDelay uint64 `json:"blockNumberDelayMax"`
@@ -74,6 +198,24 @@ type FuzzingConfig struct {
 	// TransactionGasLimit describes the maximum amount of gas that will be used by the fuzzer generated transactions.
 	TransactionGasLimit uint64 `json:"transactionGasLimit"`
 
+	// BaseFee describes the base fee, in wei, that fuzzer generated transactions are priced against. Generated
+	// transactions will specify a gas fee cap no less than this value (or the chain's current base fee, if greater) and
+	// a gas tip cap no greater than MaxGasTipCap. A zero value disables gas fee generation, leaving gas fee and tip caps
+	// at zero so base fee validation is bypassed.
+	BaseFee uint64 `json:"baseFee"`
+
+	// MaxGasTipCap describes the maximum gas tip cap, in wei, that fuzzer generated transactions will specify when
+	// BaseFee is non-zero.
+	MaxGasTipCap uint64 `json:"maxGasTipCap"`
+
+	// MaxCalldataSize describes the maximum size, in bytes, of the encoded call data of fuzzer generated transactions.
+	// Dynamic arguments of generated calls exceeding this size are truncated until the call data fits. If zero, the
+	// call data size is not limited.
+	MaxCalldataSize uint64 `json:"maxCalldataSize"`
+
+	// ValueGeneration describes the configuration used to generate values for fuzzed call arguments.
+	ValueGeneration ValueGenerationConfig `json:"valueGeneration"`
+
 	// Testing describes the configuration used for different testing strategies.
 	Testing TestingConfig `json:"testing"`
 
@@ -81,6 +223,87 @@ type FuzzingConfig struct {
 	TestChainConfig config.TestChainConfig `json:"chainConfig"`
 }
 
+// ValueGenerationConfig describes the configuration options used to generate values for fuzzed call arguments.
+type ValueGenerationConfig struct {
+	// GenerateRandomAddressBias describes the probability that a generated address is entirely random, rather than
+	// selected from the value set. Value range is [0.0, 1.0].
+	GenerateRandomAddressBias float32 `json:"generateRandomAddressBias"`
+
+	// GenerateRandomIntegerBias describes the probability that a generated integer is entirely random, rather than
+	// derived from the value set. Value range is [0.0, 1.0].
+	GenerateRandomIntegerBias float32 `json:"generateRandomIntegerBias"`
+
+	// GenerateRandomStringBias describes the probability that a generated string is entirely random, rather than
+	// derived from the value set. Value range is [0.0, 1.0].
+	GenerateRandomStringBias float32 `json:"generateRandomStringBias"`
+
+	// GenerateRandomBytesBias describes the probability that a generated byte array is entirely random, rather than
+	// derived from the value set. Value range is [0.0, 1.0].
+	GenerateRandomBytesBias float32 `json:"generateRandomBytesBias"`
+
+	// GenerateRandomFixedBytesBias describes the probability that a generated fixed-sized byte array (e.g. bytes4 or
+	// bytes32) is entirely random, rather than selected from known method selectors and event IDs of the same size.
+	// Value range is [0.0, 1.0].
+	GenerateRandomFixedBytesBias float32 `json:"generateRandomFixedBytesBias"`
+
+	// GenerateRandomArrayLengthBias describes the probability that a generated dynamic array length is entirely
+	// random, rather than selected from the lengths observed in interesting calls for arrays of the same type. Value
+	// range is [0.0, 1.0].
+	GenerateRandomArrayLengthBias float32 `json:"generateRandomArrayLengthBias"`
+
+	// GenerateBoundaryLengthBias describes the probability that a randomly generated byte array or string is empty or
+	// of the maximum length, rather than of a uniformly random length. Value range is [0.0, 1.0].
+	GenerateBoundaryLengthBias float32 `json:"generateBoundaryLengthBias"`
+
+	// GenerateScaledIntegerBias describes the probability that a generated integer argument of at least 64 bits is a
+	// multiple of a scale constant (e.g. 1e18 for ether-denominated amounts), rather than uniformly random. Such round
+	// amounts are realistic token amounts which uniform sampling never produces. Value range is [0.0, 1.0].
+	GenerateScaledIntegerBias float32 `json:"generateScaledIntegerBias"`
+
+	// ScaleConstantExponents describes the powers of ten used as scale constants when generating scaled integers
+	// (e.g. 18 for 1e18, or 6 for 1e6 as used by USDC).
+	ScaleConstantExponents []uint `json:"scaleConstantExponents"`
+
+	// StrategyWeights describes the weights with which a strategy is selected to generate each leaf value (address,
+	// integer, dynamic byte array or string) of a call argument. If all weights are zero, leaf values are generated as
+	// described by the biases above.
+	StrategyWeights ValueGenerationStrategyWeights `json:"strategyWeights"`
+
+	// Dictionary describes values (e.g. magic numbers or tokens known to be meaningful to the target contracts) which
+	// the dictionary strategy injects into generated leaf values. Entries prefixed with "0x" are decoded as hex, while
+	// other entries are used as UTF-8 encoded bytes.
+	Dictionary []string `json:"dictionary"`
+
+	// DeadlineArgumentPatterns describes case-insensitive substrings of argument names (e.g. "deadline" or "expiry")
+	// which identify unsigned integer arguments expected to hold a future timestamp. Such arguments may be generated
+	// relative to the block timestamp the call is expected to execute at, rather than entirely at random. If empty,
+	// no arguments are treated as deadlines.
+	DeadlineArgumentPatterns []string `json:"deadlineArgumentPatterns"`
+
+	// DeadlineArgumentProbability describes the probability that a deadline argument is generated relative to the
+	// expected block timestamp. Otherwise, it is generated as any other argument, so expired deadlines are still
+	// exercised. Value range is [0.0, 1.0].
+	DeadlineArgumentProbability float32 `json:"deadlineArgumentProbability"`
+
+	// DeadlineArgumentMaxOffset describes the maximum amount of seconds past the expected block timestamp which a
+	// generated deadline argument may be set to.
+	DeadlineArgumentMaxOffset uint64 `json:"deadlineArgumentMaxOffset"`
+
+	// CollectRevertComparisonValues describes whether the operands of comparisons (e.g. those of a `require`
+	// precondition) executed by calls which reverted should be added to the value set, so generated arguments are
+	// more likely to satisfy them.
+	CollectRevertComparisonValues bool `json:"collectRevertComparisonValues"`
+
+	// CollectStorageWriteValues describes whether values written to storage by calls should be added to the value
+	// set, so runtime values such as addresses and amounts held in contract state may be used in generated arguments.
+	CollectStorageWriteValues bool `json:"collectStorageWriteValues"`
+
+	// ReportGenerationStats describes whether statistics about generated values (e.g. counts by type, integer
+	// magnitudes and dynamic array lengths) should be collected and reported once fuzzing stops. This adds overhead to
+	// value generation, so it is disabled by default.
+	ReportGenerationStats bool `json:"reportGenerationStats"`
+}
+
 // TestingConfig describes the configuration options used for testing
 type TestingConfig struct {
 	// StopOnFailedTest describes whether the fuzzing.Fuzzer should stop after detecting the first failed test.
@@ -94,11 +317,23 @@ type TestingConfig struct {
 	// than just the contracts specified in the project configuration's deployment order.
 	TestAllContracts bool `json:"testAllContracts"`
 
+	// ExcludeContracts describes the names of contracts which should never be called or tested (e.g. libraries or
+	// mocks), even if all contracts are tested or they are specified in the deployment order.
+	ExcludeContracts []string `json:"excludeContracts"`
+
+	// ExcludeContractAddresses describes the addresses of deployed contracts which should never be called.
+	ExcludeContractAddresses []string `json:"excludeContractAddresses"`
+
 	// TraceAll describes whether a trace should be attached to each element of a finalized shrunken call sequence,
 	// e.g. when a call sequence triggers a test failure. Test providers may attach execution traces by default,
 	// even if this option is not enabled.
 	TraceAll bool `json:"traceAll"`
 
+	// ShrinkVerificationRetries describes how many additional times a finalized shrunken call sequence should be
+	// re-executed and verified if it fails to reproduce the original failure. This guards against non-deterministic
+	// failures. If verification still fails after all retries, the original, unshrunken call sequence is reported.
+	ShrinkVerificationRetries int `json:"shrinkVerificationRetries"`
+
 	// AssertionTesting describes the configuration used for assertion testing.
 	AssertionTesting AssertionTestingConfig `json:"assertionTesting"`
 
@@ -106,6 +341,23 @@ type TestingConfig struct {
 	PropertyTesting PropertyTestConfig `json:"propertyTesting"`
 }
 
+// ValueGenerationStrategyWeights describes the weights with which each strategy is selected to generate a leaf value.
+// A strategy with a weight of zero is never selected.
+type ValueGenerationStrategyWeights struct {
+	// Random describes the weight of generating a fresh random value.
+	Random uint64 `json:"random"`
+
+	// ValueSet describes the weight of reusing a value from the value set.
+	ValueSet uint64 `json:"valueSet"`
+
+	// Boundary describes the weight of generating a value at the boundaries of its type (e.g. the minimum or maximum
+	// integer, or an empty string).
+	Boundary uint64 `json:"boundary"`
+
+	// Dictionary describes the weight of injecting an entry of the configured dictionary.
+	Dictionary uint64 `json:"dictionary"`
+}
+
 // AssertionTestingConfig describes the configuration options used for assertion testing
 type AssertionTestingConfig struct {
 	// Enabled describes whether testing is enabled.
@@ -113,6 +365,49 @@ type AssertionTestingConfig struct {
 
 	// TestViewMethods dictates whether constant/pure/view methods should be tested.
 	TestViewMethods bool `json:"testViewMethods"`
+
+	// TestOnlyViewMethods dictates whether only constant/pure/view methods should be tested, excluding all
+	// state-changing methods. This is useful when view methods act as invariant checks. Unless TestViewMethods is
+	// enabled, only the view methods listed in IncludeViewMethods are tested.
+	TestOnlyViewMethods bool `json:"testOnlyViewMethods"`
+
+	// IncludeViewMethods describes a list of constant/pure/view methods which should be tested even if TestViewMethods
+	// is disabled. Each entry is either a method signature (e.g. "checkBalance(address)") matching the method in any
+	// contract, or a contract-qualified method signature (e.g. "TestContract.checkBalance(address)").
+	IncludeViewMethods []string `json:"includeViewMethods"`
+
+	// FailOnRevertReasons describes a list of revert reasons which should be reported as assertion failures when a
+	// tested method reverts with them. Each entry is either a revert reason string (as provided to `require` or
+	// `revert`) or a hex-encoded 4-byte error selector (e.g. "0x12345678") matching a custom error.
+	FailOnRevertReasons []string `json:"failOnRevertReasons"`
+
+	// PanicCodeFailures describes the Solidity panic codes which should be reported as assertion failures when a
+	// tested method reverts with a `Panic(uint256)` error carrying them. Codes are provided as decimal numbers, e.g.
+	// 1 (0x01, assertion failure), 17 (0x11, arithmetic overflow/underflow), 18 (0x12, division by zero), 33 (0x21,
+	// invalid enum conversion), 49 (0x31, pop on an empty array), 50 (0x32, out-of-bounds array access), or 65 (0x41,
+	// excessive memory allocation). By default, only assertion failures are reported, including those of Solidity
+	// versions prior to 0.8.0, which did not emit panic codes.
+	PanicCodeFailures []uint64 `json:"panicCodeFailures"`
+}
+
+// ParseFailOnRevertReasons splits FailOnRevertReasons into revert reason strings and error selectors, decoding entries
+// prefixed with "0x" as hex-encoded 4-byte error selectors.
+// Returns the revert reason strings and error selectors, or an error if an error selector is malformed.
+func (c *AssertionTestingConfig) ParseFailOnRevertReasons() ([]string, [][]byte, error) {
+	revertReasons := make([]string, 0)
+	selectors := make([][]byte, 0)
+	for _, revertReason := range c.FailOnRevertReasons {
+		if !strings.HasPrefix(revertReason, "0x") {
+			revertReasons = append(revertReasons, revertReason)
+			continue
+		}
+		selector, err := hex.DecodeString(revertReason[2:])
+		if err != nil || len(selector) != 4 {
+			return nil, nil, fmt.Errorf("invalid error selector to fail on provided: %v", revertReason)
+		}
+		selectors = append(selectors, selector)
+	}
+	return revertReasons, selectors, nil
 }
 
 // PropertyTestConfig describes the configuration options used for property testing
@@ -147,11 +442,25 @@ func ReadProjectConfigFromFile(path string) (*ProjectConfig, error) {
 	return projectConfig, nil
 }
 
-// WriteToFile writes the ProjectConfig to a provided file path in a JSON-serialized format.
+// WriteToFile writes the ProjectConfig to a provided file path in a JSON-serialized format, indented with tabs.
 // Returns an error if one occurs.
 func (p *ProjectConfig) WriteToFile(path string) error {
+	return p.WriteToFileWithIndent(path, "\t")
+}
+
+// WriteToFileWithIndent writes the ProjectConfig to a provided file path in a JSON-serialized format, using the
+// provided string to indent each nesting level (e.g. a tab, or a number of spaces). If the indent is empty, the
+// configuration is written in a compact format.
+// Returns an error if one occurs.
+func (p *ProjectConfig) WriteToFileWithIndent(path string, indent string) error {
 	// Serialize the configuration
-	b, err := json.MarshalIndent(p, "", "\t")
+	var b []byte
+	var err error
+	if indent == "" {
+		b, err = json.Marshal(p)
+	} else {
+		b, err = json.MarshalIndent(p, "", indent)
+	}
 	if err != nil {
 		return err
 	}
@@ -168,21 +477,66 @@ func (p *ProjectConfig) WriteToFile(path string) error {
 // Validate validates that the ProjectConfig meets certain requirements.
 // Returns an error if one occurs.
 func (p *ProjectConfig) Validate() error {
-	// Verify the worker count is a positive number.
-	if p.Fuzzing.Workers <= 0 {
-		return errors.New("project configuration must specify a positive number for the worker count")
+	// Verify the worker count is a positive number, or one indicating the number of available CPUs should be used.
+	if p.Fuzzing.Workers < -1 {
+		return errors.New("project configuration must specify a positive number for the worker count, or 0/-1 to use the number of available CPUs")
 	}
 
 	// Verify that the sequence length is a positive number
 	if p.Fuzzing.CallSequenceLength <= 0 {
 		return errors.New("project configuration must specify a positive number for the transaction sequence length")
 	}
+	if p.Fuzzing.CallSequenceLengthMin < 0 || p.Fuzzing.CallSequenceLengthMin > p.Fuzzing.CallSequenceLength {
+		return errors.New("project configuration must specify a non-negative minimum transaction sequence length which does not exceed the transaction sequence length")
+	}
+
+	// Verify the call sequence extension parameters are valid
+	if p.Fuzzing.CallSequenceExtensionProbability < 0 || p.Fuzzing.CallSequenceExtensionProbability > 1 {
+		return errors.New("project configuration must specify a call sequence extension probability in the range [0.0, 1.0]")
+	}
+	if p.Fuzzing.CallSequenceExtensionMaxLength < 0 {
+		return errors.New("project configuration must specify a non-negative call sequence extension max length")
+	}
+	if p.Fuzzing.CallSequenceExtensionProbability > 0 && p.Fuzzing.CallSequenceExtensionMaxLength != 0 && p.Fuzzing.CallSequenceExtensionMaxLength < p.Fuzzing.CallSequenceLength {
+		return errors.New("project configuration must specify a call sequence extension max length which is zero or not less than the transaction sequence length")
+	}
+
+	// Verify the receive and fallback call probabilities are valid
+	if p.Fuzzing.ReceiveCallProbability < 0 || p.Fuzzing.FallbackCallProbability < 0 || p.Fuzzing.ReceiveCallProbability+p.Fuzzing.FallbackCallProbability > 1 {
+		return errors.New("project configuration must specify receive and fallback call probabilities in the range [0.0, 1.0], which do not exceed 1.0 combined")
+	}
+
+	// Verify the maximum decoded length is non-negative
+	if p.Fuzzing.MaxDecodedLength < 0 {
+		return errors.New("project configuration must specify a non-negative maximum decoded length")
+	}
 
 	// Verify the worker reset limit is a positive number
 	if p.Fuzzing.WorkerResetLimit <= 0 {
 		return errors.New("project configuration must specify a positive number for the worker reset limit")
 	}
 
+	// Verify a corpus directory is provided if we are only replaying the corpus
+	if p.Fuzzing.ReplayOnly && p.Fuzzing.CorpusDirectory == "" {
+		return errors.New("project configuration must specify a corpus directory if only replaying the corpus")
+	}
+
+	// Verify we are not both only replaying and ignoring the corpus
+	if p.Fuzzing.ReplayOnly && p.Fuzzing.CleanRoom {
+		return errors.New("project configuration must not enable both replay only and clean room modes")
+	}
+
+	// Verify coverage baseline comparison parameters are valid
+	if p.Fuzzing.CoverageBaselineFile != "" && !p.Fuzzing.CoverageEnabled {
+		return errors.New("project configuration must enable coverage to compare coverage against a baseline")
+	}
+	if p.Fuzzing.SaveCoverageBaseline && (p.Fuzzing.CorpusDirectory == "" || !p.Fuzzing.CoverageEnabled) {
+		return errors.New("project configuration must enable coverage and specify a corpus directory to save a coverage baseline")
+	}
+	if p.Fuzzing.CoverageRegressionThreshold < 0 || p.Fuzzing.CoverageRegressionThreshold > 100 {
+		return errors.New("project configuration must specify a coverage regression threshold in the range [0.0, 100.0]")
+	}
+
 	// Verify gas limits are appropriate
 	if p.Fuzzing.BlockGasLimit < p.Fuzzing.TransactionGasLimit {
 		return errors.New("project configuration must specify a block gas limit which is not less than the transaction gas limit")
@@ -196,11 +550,88 @@ func (p *ProjectConfig) Validate() error {
 		return errors.New("project configuration must specify only well-formed sender address(es)")
 	}
 
+	// Verify that sender weights are only provided for sender addresses, and at least one sender can be selected.
+	senders, _ := utils.HexStringsToAddresses(p.Fuzzing.SenderAddresses)
+	senderWeights := make(map[common.Address]uint64)
+	for senderHexString, weight := range p.Fuzzing.SenderAddressWeights {
+		sender, err := utils.HexStringToAddress(senderHexString)
+		if err != nil || !slices.Contains(senders, sender) {
+			return fmt.Errorf("project configuration must specify sender weights only for sender addresses, invalid sender: %v", senderHexString)
+		}
+		senderWeights[sender] = weight
+	}
+	hasSelectableSender := false
+	for _, sender := range senders {
+		if weight, ok := senderWeights[sender]; !ok || weight > 0 {
+			hasSelectableSender = true
+			break
+		}
+	}
+	if len(senders) > 0 && !hasSelectableSender {
+		return errors.New("project configuration must specify at least one sender address with a non-zero weight")
+	}
+
+	// Verify that excluded contract addresses are well-formed addresses
+	if _, err := utils.HexStringsToAddresses(p.Fuzzing.Testing.ExcludeContractAddresses); err != nil {
+		return errors.New("project configuration must specify only well-formed excluded contract address(es)")
+	}
+
 	// Verify that deployer is a well-formed address
 	if _, err := utils.HexStringToAddress(p.Fuzzing.DeployerAddress); err != nil {
 		return errors.New("project configuration must specify only a well-formed deployer address")
 	}
 
+	// Verify the call rate limit is non-negative
+	if p.Fuzzing.MaxCallsPerSecond < 0 {
+		return errors.New("project configuration must specify a non-negative maximum calls per second")
+	}
+
+	// Verify the shrink verification retry count is non-negative
+	if p.Fuzzing.Testing.ShrinkVerificationRetries < 0 {
+		return errors.New("project configuration must specify a non-negative number for the shrink verification retries")
+	}
+
+	// Verify value generation biases are valid probabilities
+	valueGenerationBiases := []float32{
+		p.Fuzzing.ValueGeneration.GenerateRandomAddressBias,
+		p.Fuzzing.ValueGeneration.GenerateRandomIntegerBias,
+		p.Fuzzing.ValueGeneration.GenerateRandomStringBias,
+		p.Fuzzing.ValueGeneration.GenerateRandomBytesBias,
+		p.Fuzzing.ValueGeneration.GenerateRandomFixedBytesBias,
+		p.Fuzzing.ValueGeneration.GenerateRandomArrayLengthBias,
+		p.Fuzzing.ValueGeneration.GenerateBoundaryLengthBias,
+		p.Fuzzing.ValueGeneration.GenerateScaledIntegerBias,
+		p.Fuzzing.ValueGeneration.DeadlineArgumentProbability,
+	}
+	for _, bias := range valueGenerationBiases {
+		if bias < 0 || bias > 1 {
+			return errors.New("project configuration must specify value generation biases in the range [0.0, 1.0]")
+		}
+	}
+
+	// Verify the dictionary is well-formed, and provided if it should be used.
+	if p.Fuzzing.ValueGeneration.StrategyWeights.Dictionary > 0 && len(p.Fuzzing.ValueGeneration.Dictionary) == 0 {
+		return errors.New("project configuration must specify dictionary entries if the dictionary value generation strategy is weighted")
+	}
+	for _, entry := range p.Fuzzing.ValueGeneration.Dictionary {
+		if strings.HasPrefix(entry, "0x") {
+			if _, err := hex.DecodeString(entry[2:]); err != nil {
+				return errors.New("project configuration must specify hex-encoded dictionary entries with an even number of valid hex digits")
+			}
+		}
+	}
+
+	// Verify any error selectors to fail on are well-formed.
+	if _, _, err := p.Fuzzing.Testing.AssertionTesting.ParseFailOnRevertReasons(); err != nil {
+		return fmt.Errorf("project configuration must specify error selectors to fail on as hex-encoded 4-byte values: %v", err)
+	}
+
+	// Verify assertion testing would target view methods if only view methods should be tested.
+	assertionTesting := p.Fuzzing.Testing.AssertionTesting
+	if assertionTesting.TestOnlyViewMethods && !assertionTesting.TestViewMethods && len(assertionTesting.IncludeViewMethods) == 0 {
+		return errors.New("project configuration must enable view method testing or specify view methods to include if only view methods should be tested")
+	}
+
 	// Verify property testing fields.
 	if p.Fuzzing.Testing.PropertyTesting.Enabled {
 		// Test prefixes must be supplied if property testing is enabled.
@@ -208,5 +639,10 @@ func (p *ProjectConfig) Validate() error {
 			return errors.New("project configuration must specify test name prefixes if property testing is enabled")
 		}
 	}
+
+	// Verify the chain configuration.
+	if err := p.Fuzzing.TestChainConfig.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -3,6 +3,7 @@ package config
 import (
 	testChainConfig "github.com/crytic/medusa/chain/config"
 	"github.com/crytic/medusa/compilation"
+	"github.com/crytic/medusa/compilation/abiutils"
 )
 
 // GetDefaultProjectConfig obtains a default configuration for a project. It populates a default compilation config
@@ -31,15 +32,39 @@ func GetDefaultProjectConfig(platform string) (*ProjectConfig, error) {
 	// Create a project configuration
 	projectConfig := &ProjectConfig{
 		Fuzzing: FuzzingConfig{
-			Workers:            10,
-			WorkerResetLimit:   50,
-			Timeout:            0,
-			TestLimit:          0,
-			CallSequenceLength: 100,
-			DeploymentOrder:    []string{},
-			ConstructorArgs:    map[string]map[string]any{},
-			CorpusDirectory:    "",
-			CoverageEnabled:    true,
+			Workers:                          10,
+			WorkerResetLimit:                 50,
+			WorkerMemoryLimit:                0,
+			RandomSeed:                       0,
+			Timeout:                          0,
+			TestLimit:                        0,
+			CoverageStallTimeout:             0,
+			CoverageStallCallLimit:           0,
+			StopOnCoverageStall:              false,
+			MaxCallsPerSecond:                0,
+			CallSequenceLength:               100,
+			CallSequenceLengthMin:            0,
+			CallSequenceExtensionProbability: 0,
+			CallSequenceExtensionMaxLength:   0,
+			ReceiveCallProbability:           0,
+			FallbackCallProbability:          0,
+			DeploymentOrder:                  []string{},
+			ConstructorArgs:                  map[string]map[string]any{},
+			LibraryAddresses:                 map[string]string{},
+			MaxDynamicContractTargets:        0,
+			CorpusDirectory:                  "",
+			CorpusFlushInterval:              0,
+			MaxDecodedLength:                 100000,
+			ReplayOnly:                       false,
+			CleanRoom:                        false,
+			ZeroValueSmokeTest:               false,
+			ReportUnreachableMethods:         false,
+			CoverageEnabled:                  true,
+			SaveCoverageBaseline:             false,
+			CoverageBaselineFile:             "",
+			CoverageRegressionThreshold:      0,
+			FailOnCoverageRegression:         false,
+			LearnSenderRestrictions:          false,
 			SenderAddresses: []string{
 				"0x10000",
 				"0x20000",
@@ -50,14 +75,43 @@ func GetDefaultProjectConfig(platform string) (*ProjectConfig, error) {
 			MaxBlockTimestampDelay: 604800,
 			BlockGasLimit:          125_000_000,
 			TransactionGasLimit:    12_500_000,
+			BaseFee:                0,
+			MaxGasTipCap:           2_000_000_000,
+			MaxCalldataSize:        0,
+			ValueGeneration: ValueGenerationConfig{
+				GenerateRandomAddressBias:     0.5,
+				GenerateRandomIntegerBias:     0.5,
+				GenerateRandomStringBias:      0.5,
+				GenerateRandomBytesBias:       0.5,
+				GenerateRandomFixedBytesBias:  0.5,
+				GenerateRandomArrayLengthBias: 0.5,
+				GenerateBoundaryLengthBias:    0,
+				GenerateScaledIntegerBias:     0,
+				ScaleConstantExponents:        []uint{6, 8, 9, 18},
+				StrategyWeights:               ValueGenerationStrategyWeights{},
+				Dictionary:                    []string{},
+				DeadlineArgumentPatterns:      []string{},
+				DeadlineArgumentProbability:   0.8,
+				DeadlineArgumentMaxOffset:     86400,
+				CollectRevertComparisonValues: false,
+				CollectStorageWriteValues:     false,
+				ReportGenerationStats:         false,
+			},
 			Testing: TestingConfig{
 				StopOnFailedTest:             true,
 				StopOnFailedContractMatching: true,
 				TestAllContracts:             false,
+				ExcludeContracts:             []string{},
+				ExcludeContractAddresses:     []string{},
 				TraceAll:                     false,
+				ShrinkVerificationRetries:    2,
 				AssertionTesting: AssertionTestingConfig{
-					Enabled:         false,
-					TestViewMethods: false,
+					Enabled:             false,
+					TestViewMethods:     false,
+					TestOnlyViewMethods: false,
+					IncludeViewMethods:  []string{},
+					FailOnRevertReasons: []string{},
+					PanicCodeFailures:   []uint64{abiutils.PanicCodeAssertFailed},
 				},
 				PropertyTesting: PropertyTestConfig{
 					Enabled: true,
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ConfigDiff describes a single configuration field which differs between two ProjectConfig instances.
+type ConfigDiff struct {
+	// Path describes the path to the field, using the names of fields in the JSON-serialized configuration
+	// (e.g. "fuzzing.testing.traceAll"). Map entries are referenced by their key (e.g. "fuzzing.constructorArgs.Token").
+	Path string
+
+	// OldValue describes the value of the field in the original ProjectConfig, or nil if it was not set.
+	OldValue any
+
+	// NewValue describes the value of the field in the other ProjectConfig, or nil if it was not set.
+	NewValue any
+}
+
+// Diff compares the ProjectConfig against another, reporting every field whose value differs between them. Structs
+// and maps are compared field by field, while other values (e.g. slices) are compared as a whole. Compilation
+// platform configurations are compared by their JSON-serialized content.
+// Returns the differences, sorted by their path.
+func (p *ProjectConfig) Diff(other *ProjectConfig) []ConfigDiff {
+	diffs := make([]ConfigDiff, 0)
+	diffConfigValues("", reflect.ValueOf(p), reflect.ValueOf(other), &diffs)
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+// diffConfigValues recursively compares two configuration values at the provided path, appending any differences to
+// the provided list.
+func diffConfigValues(path string, oldValue reflect.Value, newValue reflect.Value, diffs *[]ConfigDiff) {
+	// If either value is missing or they are of different types, we can only compare them as a whole.
+	if !oldValue.IsValid() || !newValue.IsValid() || oldValue.Type() != newValue.Type() {
+		diffConfigLeafValues(path, oldValue, newValue, diffs)
+		return
+	}
+
+	switch oldValue.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if oldValue.IsNil() || newValue.IsNil() {
+			diffConfigLeafValues(path, oldValue, newValue, diffs)
+			return
+		}
+
+		// Raw JSON messages are decoded, so they are compared by content rather than formatting.
+		if rawOldValue, ok := oldValue.Interface().(*json.RawMessage); ok {
+			var decodedOldValue, decodedNewValue any
+			if json.Unmarshal(*rawOldValue, &decodedOldValue) != nil || json.Unmarshal(*newValue.Interface().(*json.RawMessage), &decodedNewValue) != nil {
+				diffConfigLeafValues(path, oldValue, newValue, diffs)
+				return
+			}
+			diffConfigValues(path, reflect.ValueOf(decodedOldValue), reflect.ValueOf(decodedNewValue), diffs)
+			return
+		}
+		diffConfigValues(path, oldValue.Elem(), newValue.Elem(), diffs)
+	case reflect.Struct:
+		for i := 0; i < oldValue.NumField(); i++ {
+			field := oldValue.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			// Use the field's JSON name, skipping fields which are not serialized.
+			fieldName := field.Name
+			if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonName == "-" {
+				continue
+			} else if jsonName != "" {
+				fieldName = jsonName
+			}
+			diffConfigValues(joinConfigPath(path, fieldName), oldValue.Field(i), newValue.Field(i), diffs)
+		}
+	case reflect.Map:
+		// Maps with keys other than strings are compared as a whole.
+		if oldValue.Type().Key().Kind() != reflect.String {
+			diffConfigLeafValues(path, oldValue, newValue, diffs)
+			return
+		}
+
+		// Compare the entries for the union of keys in both maps.
+		keys := make(map[string]reflect.Value)
+		for _, key := range append(oldValue.MapKeys(), newValue.MapKeys()...) {
+			keys[key.String()] = key
+		}
+		for keyString, key := range keys {
+			diffConfigValues(joinConfigPath(path, keyString), oldValue.MapIndex(key), newValue.MapIndex(key), diffs)
+		}
+	default:
+		diffConfigLeafValues(path, oldValue, newValue, diffs)
+	}
+}
+
+// diffConfigLeafValues compares two configuration values at the provided path as a whole, appending a difference to
+// the provided list if they are not equal.
+func diffConfigLeafValues(path string, oldValue reflect.Value, newValue reflect.Value, diffs *[]ConfigDiff) {
+	var oldInterface, newInterface any
+	if oldValue.IsValid() {
+		oldInterface = oldValue.Interface()
+	}
+	if newValue.IsValid() {
+		newInterface = newValue.Interface()
+	}
+	if !reflect.DeepEqual(oldInterface, newInterface) {
+		*diffs = append(*diffs, ConfigDiff{Path: path, OldValue: oldInterface, NewValue: newInterface})
+	}
+}
+
+// joinConfigPath appends the provided field name to a configuration path.
+func joinConfigPath(path string, fieldName string) string {
+	if path == "" {
+		return fieldName
+	}
+	return path + "." + fieldName
+}
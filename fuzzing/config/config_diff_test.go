@@ -0,0 +1,66 @@
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProjectConfigDiff runs tests to ensure nested struct fields and map entries are compared individually, while
+// slices are compared as a whole, and that equivalent configurations have no differences.
+func TestProjectConfigDiff(t *testing.T) {
+	oldConfig, err := GetDefaultProjectConfig("crytic-compile")
+	assert.NoError(t, err)
+	newConfig, err := GetDefaultProjectConfig("crytic-compile")
+	assert.NoError(t, err)
+	assert.Empty(t, oldConfig.Diff(newConfig))
+
+	// Change a nested field, a slice, and add and change map entries.
+	oldConfig.Fuzzing.ConstructorArgs = map[string]map[string]any{"Token": {"supply": "100", "owner": "0x1"}}
+	newConfig.Fuzzing.Testing.TraceAll = !oldConfig.Fuzzing.Testing.TraceAll
+	newConfig.Fuzzing.DeploymentOrder = []string{"Token", "Vault"}
+	newConfig.Fuzzing.ConstructorArgs = map[string]map[string]any{"Token": {"supply": "200", "owner": "0x1"}, "Vault": {"token": "0x2"}}
+	assert.EqualValues(t, []ConfigDiff{
+		{Path: "fuzzing.constructorArgs.Token.supply", OldValue: "100", NewValue: "200"},
+		{Path: "fuzzing.constructorArgs.Vault", OldValue: nil, NewValue: map[string]any{"token": "0x2"}},
+		{Path: "fuzzing.deploymentOrder", OldValue: oldConfig.Fuzzing.DeploymentOrder, NewValue: []string{"Token", "Vault"}},
+		{Path: "fuzzing.testing.traceAll", OldValue: oldConfig.Fuzzing.Testing.TraceAll, NewValue: newConfig.Fuzzing.Testing.TraceAll},
+	}, oldConfig.Diff(newConfig))
+
+	// Differences are reported symmetrically.
+	reverseDiffs := newConfig.Diff(oldConfig)
+	assert.Len(t, reverseDiffs, 4)
+	assert.EqualValues(t, "fuzzing.constructorArgs.Vault", reverseDiffs[1].Path)
+	assert.Nil(t, reverseDiffs[1].NewValue)
+}
+
+// TestProjectConfigDiffSerialized runs tests to ensure a configuration has no differences from itself once written to
+// and read from a file, and that platform configurations are compared by content rather than formatting.
+func TestProjectConfigDiffSerialized(t *testing.T) {
+	projectConfig, err := GetDefaultProjectConfig("crytic-compile")
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "medusa.json")
+	assert.NoError(t, projectConfig.WriteToFile(path))
+	readConfig, err := ReadProjectConfigFromFile(path)
+	assert.NoError(t, err)
+	assert.Empty(t, projectConfig.Diff(readConfig))
+
+	// Reformatting the platform configuration does not introduce differences, while changing its content does.
+	var platformConfig map[string]any
+	assert.NoError(t, json.Unmarshal(*readConfig.Compilation.PlatformConfig, &platformConfig))
+	reformatted, err := json.MarshalIndent(platformConfig, "", "    ")
+	assert.NoError(t, err)
+	*readConfig.Compilation.PlatformConfig = reformatted
+	assert.Empty(t, projectConfig.Diff(readConfig))
+
+	platformConfig["target"] = "contracts/"
+	changed, err := json.Marshal(platformConfig)
+	assert.NoError(t, err)
+	*readConfig.Compilation.PlatformConfig = changed
+	diffs := projectConfig.Diff(readConfig)
+	assert.Len(t, diffs, 1)
+	assert.EqualValues(t, "compilation.platformConfig.target", diffs[0].Path)
+	assert.EqualValues(t, "contracts/", diffs[0].NewValue)
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseFailOnRevertReasons runs tests to ensure revert reasons to fail on are split into revert reason strings and
+// error selectors, and that malformed error selectors are rejected, including by configuration validation.
+func TestParseFailOnRevertReasons(t *testing.T) {
+	assertionTesting := AssertionTestingConfig{FailOnRevertReasons: []string{"insolvent", "0x12345678", "", "0xABCDEF01"}}
+	revertReasons, selectors, err := assertionTesting.ParseFailOnRevertReasons()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"insolvent", ""}, revertReasons)
+	assert.EqualValues(t, [][]byte{{0x12, 0x34, 0x56, 0x78}, {0xab, 0xcd, 0xef, 0x01}}, selectors)
+
+	for _, selector := range []string{"0x", "0x123456", "0x1234567890", "0x1234567g", "0x1234567"} {
+		projectConfig, err := GetDefaultProjectConfig("crytic-compile")
+		assert.NoError(t, err)
+		assert.NoError(t, projectConfig.Validate())
+		projectConfig.Fuzzing.Testing.AssertionTesting.FailOnRevertReasons = []string{"insolvent", selector}
+		_, _, err = projectConfig.Fuzzing.Testing.AssertionTesting.ParseFailOnRevertReasons()
+		assert.Error(t, err, selector)
+		assert.Error(t, projectConfig.Validate(), selector)
+	}
+}
@@ -13,5 +13,18 @@ type ContractMethodID string
 // identify a contract and method definition and generate keys for mappings for a given method. It is unique
 // between multiple compilations of the same origin contract.
 func GetContractMethodID(contract *Contract, method *abi.Method) ContractMethodID {
-	return ContractMethodID(strings.Join([]string{contract.sourcePath, contract.Name(), method.Sig}, "/"))
+	return ContractMethodID(strings.Join([]string{contract.sourcePath, contract.Name(), GetMethodSignature(method)}, "/"))
+}
+
+// GetMethodSignature returns the signature of the provided method definition. Receive and fallback functions have no
+// signature in their ABI definition, so they are given one which distinguishes them from each other and from methods.
+func GetMethodSignature(method *abi.Method) string {
+	switch method.Type {
+	case abi.Receive:
+		return "receive()"
+	case abi.Fallback:
+		return "fallback()"
+	default:
+		return method.Sig
+	}
 }
@@ -6,11 +6,15 @@ import (
 	"github.com/crytic/medusa/chain"
 	"github.com/crytic/medusa/fuzzing/calls"
 	"github.com/crytic/medusa/fuzzing/coverage"
+	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/crytic/medusa/utils/randomutils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
 	"math/big"
+	"math/rand"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
@@ -24,6 +28,14 @@ type Corpus struct {
 	// storageDirectory describes the directory to save corpus callSequencesByFilePath within.
 	storageDirectory string
 
+	// maxDecodedLength describes the maximum length of a dynamic array, byte array or string decoded from the ABI
+	// values of call sequences read from storageDirectory. A zero value indicates no limit is enforced.
+	maxDecodedLength int
+
+	// jsonArgumentCodecs describes the custom JSON representations used to decode the ABI values of call sequences
+	// read from storageDirectory, by ABI type category.
+	jsonArgumentCodecs valuegeneration.JSONArgumentCodecs
+
 	// coverageMaps describes the total code coverage known to be achieved across all corpus call sequences.
 	coverageMaps *coverage.CoverageMaps
 
@@ -53,12 +65,18 @@ type Corpus struct {
 	callSequencesLock sync.Mutex
 }
 
-// NewCorpus initializes a new Corpus object, reading artifacts from the provided directory. If the directory refers
-// to an empty path, artifacts will not be persistently stored.
-func NewCorpus(corpusDirectory string) (*Corpus, error) {
+// NewCorpus initializes a new Corpus object, reading artifacts from the provided directory using the provided number
+// of concurrent workers. If the directory refers to an empty path, artifacts will not be persistently stored. If
+// loadCallSequences is false, call sequences stored in the directory are not read, so they are neither replayed nor
+// mutated, while new artifacts are still stored in it. Dynamic arrays, byte arrays and strings longer than
+// maxDecodedLength are rejected when decoding the ABI values of call sequences read, unless it is zero. The ABI values
+// are decoded using the provided JSONArgumentCodecs.
+func NewCorpus(corpusDirectory string, loadWorkerCount int, loadCallSequences bool, maxDecodedLength int, jsonArgumentCodecs valuegeneration.JSONArgumentCodecs) (*Corpus, error) {
 	var err error
 	corpus := &Corpus{
 		storageDirectory:        corpusDirectory,
+		maxDecodedLength:        maxDecodedLength,
+		jsonArgumentCodecs:      jsonArgumentCodecs,
 		coverageMaps:            coverage.NewCoverageMaps(),
 		mutableSequenceFiles:    newCorpusDirectory[calls.CallSequence](""),
 		immutableSequenceFiles:  newCorpusDirectory[calls.CallSequence](""),
@@ -66,25 +84,29 @@ func NewCorpus(corpusDirectory string) (*Corpus, error) {
 		unexecutedCallSequences: make([]calls.CallSequence, 0),
 	}
 
-	// If we have a corpus directory set, parse our call sequences.
+	// If we have a corpus directory set, set our storage paths and parse our call sequences, if requested.
 	if corpus.storageDirectory != "" {
-		// Read mutable call sequences.
 		corpus.mutableSequenceFiles.path = filepath.Join(corpus.storageDirectory, "call_sequences", "mutable")
-		err = corpus.mutableSequenceFiles.readFiles("*.json")
+		corpus.immutableSequenceFiles.path = filepath.Join(corpus.storageDirectory, "call_sequences", "immutable")
+		corpus.testResultSequenceFiles.path = filepath.Join(corpus.storageDirectory, "test_results")
+		if !loadCallSequences {
+			return corpus, nil
+		}
+
+		// Read mutable call sequences.
+		err = corpus.mutableSequenceFiles.readFiles("*.json", loadWorkerCount)
 		if err != nil {
 			return nil, err
 		}
 
 		// Read immutable call sequences.
-		corpus.immutableSequenceFiles.path = filepath.Join(corpus.storageDirectory, "call_sequences", "immutable")
-		err = corpus.immutableSequenceFiles.readFiles("*.json")
+		err = corpus.immutableSequenceFiles.readFiles("*.json", loadWorkerCount)
 		if err != nil {
 			return nil, err
 		}
 
 		// Read test case provider related call sequences (test failures, etc).
-		corpus.testResultSequenceFiles.path = filepath.Join(corpus.storageDirectory, "test_results")
-		err = corpus.testResultSequenceFiles.readFiles("*.json")
+		err = corpus.testResultSequenceFiles.readFiles("*.json", loadWorkerCount)
 		if err != nil {
 			return nil, err
 		}
@@ -139,6 +161,49 @@ func (c *Corpus) RandomMutationTargetSequence() (calls.CallSequence, error) {
 	return seq.Clone()
 }
 
+// replaySequence is a helper method which replays a call sequence on the provided chain, using the map of deployed
+// contracts to resolve contract/method definitions (e.g. to check for non-existent methods called, due to code
+// changes). Coverage for each call is merged into the Corpus coverage maps. Chain state is not reverted afterwards.
+// Returns a boolean indicating whether the sequence increased coverage, an error describing why the sequence is no
+// longer valid for use (if it is not), or an error if one occurs while executing it.
+func (c *Corpus) replaySequence(sequence calls.CallSequence, testChain *chain.TestChain, deployedContracts map[common.Address]*contracts.Contract) (bool, error, error) {
+	// Define a variable to track whether we should disable this sequence (if it is no longer applicable in some
+	// way), and whether it increased coverage.
+	sequenceInvalidError := error(nil)
+	coverageIncreased := false
+	fetchElementFunc := func(currentIndex int) (*calls.CallSequenceElement, error) {
+		// If we are at the end of our sequence, return nil indicating we should stop executing.
+		if currentIndex >= len(sequence) {
+			return nil, nil
+		}
+
+		// Resolve the contract and method the call targets. If we cannot, the sequence is no longer applicable.
+		currentSequenceElement := sequence[currentIndex]
+		sequenceInvalidError = currentSequenceElement.ResolveRuntimeReferences(deployedContracts, c.maxDecodedLength, c.jsonArgumentCodecs)
+		if sequenceInvalidError != nil {
+			return nil, nil
+		}
+		return currentSequenceElement, nil
+	}
+
+	// Define actions to perform after executing each call in the sequence.
+	executionCheckFunc := func(currentlyExecutedSequence calls.CallSequence) (bool, error) {
+		// Update our coverage maps for each call executed in our sequence.
+		lastExecutedSequenceElement := currentlyExecutedSequence[len(currentlyExecutedSequence)-1]
+		covMaps := coverage.GetCoverageTracerResults(lastExecutedSequenceElement.ChainReference.MessageResults())
+		coverageUpdated, revertedCoverageUpdated, covErr := c.coverageMaps.Update(covMaps)
+		if covErr != nil {
+			return true, covErr
+		}
+		coverageIncreased = coverageIncreased || coverageUpdated || revertedCoverageUpdated
+		return false, nil
+	}
+
+	// Execute each call sequence, populating runtime data and collecting coverage data along the way.
+	_, err := calls.ExecuteCallSequenceIteratively(testChain, fetchElementFunc, executionCheckFunc)
+	return coverageIncreased, sequenceInvalidError, err
+}
+
 // initializeSequences is a helper method for Initialize. It validates a list of call sequence files on a given
 // chain, using the map of deployed contracts (e.g. to check for non-existent method called, due to code changes).
 // Valid call sequences are added to the list of un-executed sequences the fuzzer should execute first.
@@ -149,60 +214,12 @@ func (c *Corpus) initializeSequences(sequenceFiles *corpusDirectory[calls.CallSe
 	baseBlockNumber := testChain.HeadBlockNumber()
 
 	// Loop for each sequence
-	var err error
 	for _, sequenceFileData := range sequenceFiles.files {
 		// Unwrap the underlying sequence.
 		sequence := sequenceFileData.data
 
-		// Define a variable to track whether we should disable this sequence (if it is no longer applicable in some
-		// way).
-		sequenceInvalidError := error(nil)
-		fetchElementFunc := func(currentIndex int) (*calls.CallSequenceElement, error) {
-			// If we are at the end of our sequence, return nil indicating we should stop executing.
-			if currentIndex >= len(sequence) {
-				return nil, nil
-			}
-
-			// If we are deploying a contract and not targeting one with this call, there should be no work to do.
-			currentSequenceElement := sequence[currentIndex]
-			if currentSequenceElement.Call.MsgTo == nil {
-				return currentSequenceElement, nil
-			}
-
-			// We are calling a contract with this call, ensure we can resolve the contract call is targeting.
-			resolvedContract, resolvedContractExists := deployedContracts[*currentSequenceElement.Call.MsgTo]
-			if !resolvedContractExists {
-				sequenceInvalidError = fmt.Errorf("contract at address '%v' could not be resolved", currentSequenceElement.Call.MsgTo.String())
-				return nil, nil
-			}
-			currentSequenceElement.Contract = resolvedContract
-
-			// Next, if our sequence element uses ABI values to produce call data, our deserialized data is not yet
-			// sufficient for runtime use, until we use it to resolve runtime references.
-			callAbiValues := currentSequenceElement.Call.MsgDataAbiValues
-			if callAbiValues != nil {
-				sequenceInvalidError = callAbiValues.Resolve(currentSequenceElement.Contract.CompiledContract().Abi)
-				if sequenceInvalidError != nil {
-					return nil, nil
-				}
-			}
-			return currentSequenceElement, nil
-		}
-
-		// Define actions to perform after executing each call in the sequence.
-		executionCheckFunc := func(currentlyExecutedSequence calls.CallSequence) (bool, error) {
-			// Update our coverage maps for each call executed in our sequence.
-			lastExecutedSequenceElement := currentlyExecutedSequence[len(currentlyExecutedSequence)-1]
-			covMaps := coverage.GetCoverageTracerResults(lastExecutedSequenceElement.ChainReference.MessageResults())
-			_, _, covErr := c.coverageMaps.Update(covMaps)
-			if covErr != nil {
-				return true, covErr
-			}
-			return false, nil
-		}
-
 		// Execute each call sequence, populating runtime data and collecting coverage data along the way.
-		_, err = calls.ExecuteCallSequenceIteratively(testChain, fetchElementFunc, executionCheckFunc)
+		_, sequenceInvalidError, err := c.replaySequence(sequence, testChain, deployedContracts)
 
 		// If we failed to replay a sequence and measure coverage due to an unexpected error, report it.
 		if err != nil {
@@ -228,17 +245,11 @@ func (c *Corpus) initializeSequences(sequenceFiles *corpusDirectory[calls.CallSe
 	return nil
 }
 
-// Initialize initializes any runtime data needed for a Corpus on startup. Call sequences are replayed on the post-setup
-// (deployment) test chain to calculate coverage, while resolving references to compiled contracts.
-func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions contracts.Contracts) error {
-	// Acquire our call sequences lock during the duration of this method.
-	c.callSequencesLock.Lock()
-	defer c.callSequencesLock.Unlock()
-
-	// Initialize our call sequence structures.
-	c.mutationTargetSequenceChooser = randomutils.NewWeightedRandomChooser[calls.CallSequence]()
-	c.unexecutedCallSequences = make([]calls.CallSequence, 0)
-
+// createReplayChain is a helper method which clones the provided base test chain with a coverage tracer attached,
+// resetting the Corpus coverage maps to the coverage achieved by the blocks in the base chain (e.g. deployments).
+// Returns the cloned chain, a map of deployed contract addresses to contract definitions which is kept up to date as
+// contracts are deployed on the chain, or an error if one occurs.
+func (c *Corpus) createReplayChain(baseTestChain *chain.TestChain, contractDefinitions contracts.Contracts) (*chain.TestChain, map[common.Address]*contracts.Contract, error) {
 	// Create a coverage tracer to track coverage across all blocks.
 	c.coverageMaps = coverage.NewCoverageMaps()
 	coverageTracer := coverage.NewCoverageTracer()
@@ -267,7 +278,7 @@ func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("failed to initialize coverage maps, base test chain cloning encountered error: %v", err)
+		return nil, nil, fmt.Errorf("failed to initialize coverage maps, base test chain cloning encountered error: %v", err)
 	}
 
 	// Set our coverage maps to those collected when replaying all blocks when cloning.
@@ -277,10 +288,30 @@ func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions
 			covMaps := coverage.GetCoverageTracerResults(messageResults)
 			_, _, covErr := c.coverageMaps.Update(covMaps)
 			if covErr != nil {
-				return err
+				return nil, nil, covErr
 			}
 		}
 	}
+	return testChain, deployedContracts, nil
+}
+
+// Initialize initializes any runtime data needed for a Corpus on startup. Call sequences are replayed on the post-setup
+// (deployment) test chain to calculate coverage, while resolving references to compiled contracts. The provided random
+// provider is used to select call sequences as mutation targets.
+func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions contracts.Contracts, randomProvider *rand.Rand) error {
+	// Acquire our call sequences lock during the duration of this method.
+	c.callSequencesLock.Lock()
+	defer c.callSequencesLock.Unlock()
+
+	// Initialize our call sequence structures.
+	c.mutationTargetSequenceChooser = randomutils.NewWeightedRandomChooserWithRand[calls.CallSequence](randomProvider, &sync.Mutex{})
+	c.unexecutedCallSequences = make([]calls.CallSequence, 0)
+
+	// Create a chain to replay our call sequences on, measuring coverage.
+	testChain, deployedContracts, err := c.createReplayChain(baseTestChain, contractDefinitions)
+	if err != nil {
+		return err
+	}
 
 	// Next we replay every call sequence, checking its validity on this chain and measuring coverage. Valid sequences
 	// are added to the corpus for mutations, re-execution, etc.
@@ -300,6 +331,57 @@ func (c *Corpus) Initialize(baseTestChain *chain.TestChain, contractDefinitions
 	return nil
 }
 
+// Minimize reduces the mutable and immutable call sequences in the Corpus to a minimal subset which preserves the
+// total coverage achieved by them. Call sequences are replayed from shortest to longest, and any which do not increase
+// coverage are removed from the Corpus and its storage directory. Call sequences which are no longer valid, as well as
+// test result call sequences, are left untouched.
+// Returns the count of call sequences removed, or an error if one occurs.
+func (c *Corpus) Minimize(baseTestChain *chain.TestChain, contractDefinitions contracts.Contracts) (int, error) {
+	// Acquire our call sequences lock during the duration of this method.
+	c.callSequencesLock.Lock()
+	defer c.callSequencesLock.Unlock()
+
+	// Create a chain to replay our call sequences on, measuring coverage.
+	testChain, deployedContracts, err := c.createReplayChain(baseTestChain, contractDefinitions)
+	if err != nil {
+		return 0, err
+	}
+	baseBlockNumber := testChain.HeadBlockNumber()
+
+	removedCount := 0
+	for _, sequenceFiles := range []*corpusDirectory[calls.CallSequence]{c.mutableSequenceFiles, c.immutableSequenceFiles} {
+		// Sort our call sequences so shorter ones are preferred when they achieve the same coverage.
+		files := slices.Clone(sequenceFiles.files)
+		sort.SliceStable(files, func(i, j int) bool {
+			return len(files[i].data) < len(files[j].data)
+		})
+
+		for _, file := range files {
+			// Replay the sequence to determine whether it contributes coverage.
+			coverageIncreased, sequenceInvalidError, err := c.replaySequence(file.data, testChain, deployedContracts)
+			if err != nil {
+				return removedCount, fmt.Errorf("failed to minimize corpus, encountered an error while executing call sequence: %v", err)
+			}
+
+			// Revert chain state to our starting point to test the next sequence.
+			err = testChain.RevertToBlockNumber(baseBlockNumber)
+			if err != nil {
+				return removedCount, fmt.Errorf("failed to reset the chain while minimizing corpus: %v", err)
+			}
+
+			// If the sequence is valid but did not contribute any coverage, remove it.
+			if sequenceInvalidError == nil && !coverageIncreased {
+				err = sequenceFiles.deleteFile(file.fileName)
+				if err != nil {
+					return removedCount, err
+				}
+				removedCount++
+			}
+		}
+	}
+	return removedCount, nil
+}
+
 // addCallSequence adds a call sequence to the corpus in a given corpus directory.
 // Returns an error, if one occurs.
 func (c *Corpus) addCallSequence(sequenceFiles *corpusDirectory[calls.CallSequence], sequence calls.CallSequence, useInMutations bool, mutationChooserWeight *big.Int, flushImmediately bool) error {
@@ -366,9 +448,28 @@ func (c *Corpus) AddTestResultCallSequence(callSequence calls.CallSequence, muta
 // and the Corpus coverage maps are updated accordingly.
 // Returns an error if one occurs.
 func (c *Corpus) CheckSequenceCoverageAndUpdate(callSequence calls.CallSequence, mutationChooserWeight *big.Int, flushImmediately bool) error {
-	// If we have coverage-guided fuzzing disabled or no calls in our sequence, there is nothing to do.
+	// Merge the coverage of our last call into our total coverage maps and check if we had an update.
+	coverageUpdated, revertedCoverageUpdated, err := c.UpdateSequenceCoverage(callSequence)
+	if err != nil {
+		return err
+	}
+
+	// If we had an increase in non-reverted or reverted coverage, we save the sequence.
+	// Note: We only want to save the sequence once. We're most interested if it can be used for mutations first.
+	if coverageUpdated || revertedCoverageUpdated {
+		return c.AddCallSequence(callSequence, coverageUpdated, mutationChooserWeight, flushImmediately)
+	}
+	return nil
+}
+
+// UpdateSequenceCoverage merges the coverage achieved by the most recent call executed in the provided call sequence
+// into the Corpus coverage maps. The call sequence is not added to the corpus.
+// Returns booleans indicating whether non-reverted and reverted coverage increased, respectively, or an error if one
+// occurs.
+func (c *Corpus) UpdateSequenceCoverage(callSequence calls.CallSequence) (bool, bool, error) {
+	// If we have no calls in our sequence, there is nothing to do.
 	if len(callSequence) == 0 {
-		return nil
+		return false, false, nil
 	}
 
 	// Obtain our coverage maps for our last call.
@@ -379,35 +480,24 @@ func (c *Corpus) CheckSequenceCoverageAndUpdate(callSequence calls.CallSequence,
 
 	// If we have none, because a coverage tracer wasn't attached when processing this call, we can stop.
 	if lastMessageCoverageMaps == nil {
-		return nil
+		return false, false, nil
 	}
 
-	// Memory optimization: Remove them from the results now that we obtained them, to free memory later.
-	coverage.RemoveCoverageTracerResults(lastMessageResult)
-
-	// Merge the coverage maps into our total coverage maps and check if we had an update.
-	coverageUpdated, revertedCoverageUpdated, err := c.coverageMaps.Update(lastMessageCoverageMaps)
-	if err != nil {
-		return err
-	}
+	// Merge a copy of the coverage maps into our total coverage maps and check if we had an update. We merge a copy
+	// as the results remain recorded for the call until the worker has finished testing the sequence (e.g. so hooks
+	// may compute CallSequence.CoverageDeltas), and merging may otherwise share them with our total coverage maps.
+	return c.coverageMaps.Update(lastMessageCoverageMaps.Clone())
+}
 
-	// If we had an increase in non-reverted or reverted coverage, we save the sequence.
-	// Note: We only want to save the sequence once. We're most interested if it can be used for mutations first.
-	if coverageUpdated {
-		// If we achieved new non-reverting coverage, save this sequence for mutation purposes.
-		err = c.addCallSequence(c.mutableSequenceFiles, callSequence, true, mutationChooserWeight, flushImmediately)
-		if err != nil {
-			return err
-		}
-	} else if revertedCoverageUpdated {
-		// If we did not achieve new successful coverage, but achieved an increase in reverted coverage, save this
-		// sequence for non-mutation purposes.
-		err = c.addCallSequence(c.immutableSequenceFiles, callSequence, false, mutationChooserWeight, flushImmediately)
-		if err != nil {
-			return err
-		}
+// AddCallSequence adds a call sequence to the corpus if it does not already exist. If the call sequence should be
+// used in mutations, it is added to the mutable call sequences with the provided weight, otherwise it is added to
+// the immutable call sequences.
+// Returns an error if one occurs.
+func (c *Corpus) AddCallSequence(callSequence calls.CallSequence, useInMutations bool, mutationChooserWeight *big.Int, flushImmediately bool) error {
+	if useInMutations {
+		return c.addCallSequence(c.mutableSequenceFiles, callSequence, true, mutationChooserWeight, flushImmediately)
 	}
-	return nil
+	return c.addCallSequence(c.immutableSequenceFiles, callSequence, false, mutationChooserWeight, flushImmediately)
 }
 
 // UnexecutedCallSequence returns a call sequence loaded from disk which has not yet been returned by this method.
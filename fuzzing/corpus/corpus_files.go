@@ -4,12 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/crytic/medusa/utils"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 )
 
+// maxCorpusFileSize describes the maximum size, in bytes, of a corpus file which will be read. Larger files are rejected
+// with an error before they are parsed, so an untrusted corpus cannot cause excessive memory allocation.
+const maxCorpusFileSize = 64 * 1024 * 1024
+
 // corpusFile represents corpus data and its state on the filesystem.
 type corpusFile[T any] struct {
 	// fileName describes the filename the file should be written with, in the corpusDirectory.path.
@@ -92,10 +97,27 @@ func (cd *corpusDirectory[T]) removeFile(fileName string) bool {
 	return false
 }
 
+// deleteFile removes a given file from the file list, and deletes it from disk if it was previously written there.
+// Returns an error, if one occurred.
+func (cd *corpusDirectory[T]) deleteFile(fileName string) error {
+	// Remove the file from our list, exiting early if it did not exist.
+	if !cd.removeFile(fileName) || cd.path == "" {
+		return nil
+	}
+
+	// Delete the file from disk, if it exists.
+	err := os.Remove(filepath.Join(cd.path, fileName))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("an error occurred while deleting corpus file: %v", err)
+	}
+	return nil
+}
+
 // readFiles takes a provided glob pattern representing files to parse within the corpusDirectory.path.
-// It parses any matching file into a corpusFile and adds it to the corpusDirectory.
+// It parses any matching file into a corpusFile and adds it to the corpusDirectory. Files are read and parsed
+// concurrently by the provided number of workers, but are added in the order they were discovered.
 // Returns an error, if one occurred.
-func (cd *corpusDirectory[T]) readFiles(filePattern string) error {
+func (cd *corpusDirectory[T]) readFiles(filePattern string, workerCount int) error {
 	// If our directory path specified is empty, we do not read/write to disk.
 	if cd.path == "" {
 		return nil
@@ -110,31 +132,73 @@ func (cd *corpusDirectory[T]) readFiles(filePattern string) error {
 	// Refresh our files list
 	cd.files = make([]*corpusFile[T], 0)
 
-	// Loop for every file path provided
-	for _, filePath := range filePaths {
-		// Read the file data.
-		b, err := os.ReadFile(filePath)
-		if err != nil {
-			return err
-		}
+	// Read and parse each file concurrently, storing results by index so our ordering is preserved.
+	if workerCount <= 0 {
+		workerCount = 1
+	}
+	files := make([]*corpusFile[T], len(filePaths))
+	fileErrors := make([]error, len(filePaths))
+	filePathIndexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range filePathIndexes {
+				files[index], fileErrors[index] = readCorpusFile[T](filePaths[index])
+			}
+		}()
+	}
+	for i := range filePaths {
+		filePathIndexes <- i
+	}
+	close(filePathIndexes)
+	wg.Wait()
 
-		// Parse the call sequence data.
-		var fileData T
-		err = json.Unmarshal(b, &fileData)
+	// Return the first error encountered, in file order, so errors are deterministic.
+	for _, err := range fileErrors {
 		if err != nil {
 			return err
 		}
-
-		// Add entry to corpus
-		cd.files = append(cd.files, &corpusFile[T]{
-			fileName:      filepath.Base(filePath),
-			data:          fileData,
-			writtenToDisk: true,
-		})
 	}
+
+	// Add the entries to the corpus
+	cd.files = append(cd.files, files...)
 	return nil
 }
 
+// readCorpusFile reads and parses the file at the provided path into a corpusFile. Files larger than
+// maxCorpusFileSize are rejected.
+// Returns the corpusFile, or an error if one occurred.
+func readCorpusFile[T any](filePath string) (*corpusFile[T], error) {
+	// Read the file data, without reading beyond our maximum file size.
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	b, err := io.ReadAll(io.LimitReader(file, maxCorpusFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxCorpusFileSize {
+		return nil, fmt.Errorf("corpus file '%v' exceeds the maximum corpus file size of %v bytes", filePath, maxCorpusFileSize)
+	}
+
+	// Parse the call sequence data.
+	var fileData T
+	err = json.Unmarshal(b, &fileData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corpusFile[T]{
+		fileName:      filepath.Base(filePath),
+		data:          fileData,
+		writtenToDisk: true,
+	}, nil
+}
+
 // writeFiles flushes all corpusDirectory.files to disk, if they have corpusFile.writtenToDisk set as false.
 // It then sets corpusFile.writtenToDisk as true for each flushed to disk.
 // Returns an error, if one occurred.
@@ -2,12 +2,17 @@ package corpus
 
 import (
 	"encoding/json"
+	"github.com/crytic/medusa/chain"
 	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/utils"
 	"github.com/crytic/medusa/utils/testutils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/stretchr/testify/assert"
 	"math/big"
 	"math/rand"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -15,7 +20,7 @@ import (
 // getMockSimpleCorpus creates a mock corpus with numEntries callSequencesByFilePath for testing
 func getMockSimpleCorpus(minSequences int, maxSequences, minBlocks int, maxBlocks int) (*Corpus, error) {
 	// Create a new corpus
-	corpus, err := NewCorpus("corpus")
+	corpus, err := NewCorpus("corpus", 1, true, 0, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -104,13 +109,55 @@ func TestCorpusReadWrite(t *testing.T) {
 		assert.NoError(t, err)
 		assert.EqualValues(t, len(corpus.mutableSequenceFiles.files), len(matches))
 
-		// Wipe corpus clean so that you can now read it in from disk
-		corpus, err = NewCorpus("corpus")
+		// Read the corpus back from disk with a single worker, and with multiple workers.
+		singleWorkerCorpus, err := NewCorpus(corpus.storageDirectory, 1, true, 0, nil)
 		assert.NoError(t, err)
+		multiWorkerCorpus, err := NewCorpus(corpus.storageDirectory, 4, true, 0, nil)
+		assert.NoError(t, err)
+
+		// Ensure both read the same files, in the order they were discovered.
+		assert.Len(t, singleWorkerCorpus.mutableSequenceFiles.files, len(matches))
+		assert.Len(t, multiWorkerCorpus.mutableSequenceFiles.files, len(matches))
+		for i, match := range matches {
+			singleWorkerFile := singleWorkerCorpus.mutableSequenceFiles.files[i]
+			multiWorkerFile := multiWorkerCorpus.mutableSequenceFiles.files[i]
+			assert.EqualValues(t, filepath.Base(match), singleWorkerFile.fileName)
+			assert.EqualValues(t, filepath.Base(match), multiWorkerFile.fileName)
+			testCorpusCallSequencesEqual(t, singleWorkerFile.data, multiWorkerFile.data)
+		}
+	})
+}
 
-		// Create a new corpus object and read our previously read artifacts.
-		corpus, err = NewCorpus(corpus.storageDirectory)
+// TestCorpusReadFilesFirstError ensures that when multiple corpus files cannot be parsed, the error returned when
+// reading them with multiple workers is that of the first invalid file in the order the files were discovered.
+func TestCorpusReadFilesFirstError(t *testing.T) {
+	// Create a mock corpus
+	corpus, err := getMockSimpleCorpus(30, 40, 1, 7)
+	assert.NoError(t, err)
+	testutils.ExecuteInDirectory(t, t.TempDir(), func() {
+		// Write to disk
+		err := corpus.Flush()
 		assert.NoError(t, err)
+
+		// Overwrite two files in the middle of the directory with data which fails to parse, each with a different
+		// error.
+		matches, err := filepath.Glob(filepath.Join(corpus.mutableSequenceFiles.path, "*.json"))
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(matches), 30)
+		firstInvalidFile, secondInvalidFile := matches[len(matches)/3], matches[2*len(matches)/3]
+		err = os.WriteFile(firstInvalidFile, []byte("{"), os.ModePerm)
+		assert.NoError(t, err)
+		err = os.WriteFile(secondInvalidFile, []byte("[1]"), os.ModePerm)
+		assert.NoError(t, err)
+		_, expectedErr := readCorpusFile[calls.CallSequence](firstInvalidFile)
+		assert.Error(t, expectedErr)
+
+		// Reading the files should always return the error of the first invalid file, regardless of worker count.
+		for _, workerCount := range []int{1, 4, len(matches)} {
+			sequenceFiles := newCorpusDirectory[calls.CallSequence](corpus.mutableSequenceFiles.path)
+			err = sequenceFiles.readFiles("*.json", workerCount)
+			assert.EqualError(t, err, expectedErr.Error(), "unexpected error with %d worker(s)", workerCount)
+		}
 	})
 }
 
@@ -145,3 +192,54 @@ func TestCorpusCallSequenceMarshaling(t *testing.T) {
 		assert.Empty(t, corpus.mutableSequenceFiles.files)
 	})
 }
+
+// TestCorpusWithoutLoadingCallSequences ensures that a corpus created without loading call sequences does not read or
+// replay the call sequences stored in its directory, while still storing new call sequences in it.
+func TestCorpusWithoutLoadingCallSequences(t *testing.T) {
+	// Create a mock corpus
+	corpus, err := getMockSimpleCorpus(10, 20, 1, 7)
+	assert.NoError(t, err)
+	testutils.ExecuteInDirectory(t, t.TempDir(), func() {
+		// Write to disk
+		err := corpus.Flush()
+		assert.NoError(t, err)
+		storedSequenceCount := len(corpus.mutableSequenceFiles.files)
+
+		// Create a new corpus from the directory without loading call sequences, and initialize it.
+		corpus, err = NewCorpus(corpus.storageDirectory, 1, false, 0, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, corpus.mutableSequenceFiles.files)
+		assert.Empty(t, corpus.immutableSequenceFiles.files)
+		assert.Empty(t, corpus.testResultSequenceFiles.files)
+		testChain, err := chain.NewTestChain(core.GenesisAlloc{}, nil)
+		assert.NoError(t, err)
+		err = corpus.Initialize(testChain, contracts.Contracts{}, rand.New(rand.NewSource(1)))
+		assert.NoError(t, err)
+		assert.Nil(t, corpus.UnexecutedCallSequence())
+		assert.Zero(t, corpus.ActiveMutableSequenceCount())
+
+		// Add a new call sequence and verify it is stored alongside the existing ones.
+		err = corpus.addCallSequence(corpus.mutableSequenceFiles, getMockCallSequence(3), true, nil, true)
+		assert.NoError(t, err)
+		matches, err := filepath.Glob(filepath.Join(corpus.mutableSequenceFiles.path, "*.json"))
+		assert.NoError(t, err)
+		assert.Len(t, matches, storedSequenceCount+1)
+	})
+}
+
+// TestCorpusMaxFileSize ensures that corpus files exceeding the maximum corpus file size are rejected when the corpus
+// is read, rather than parsed.
+func TestCorpusMaxFileSize(t *testing.T) {
+	testutils.ExecuteInDirectory(t, t.TempDir(), func() {
+		// Write a corpus file exceeding our maximum size.
+		sequenceDirectory := filepath.Join("corpus", "call_sequences", "mutable")
+		err := utils.MakeDirectory(sequenceDirectory)
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(sequenceDirectory, "large.json"), make([]byte, maxCorpusFileSize+1), os.ModePerm)
+		assert.NoError(t, err)
+
+		// Reading the corpus should fail, describing the cause.
+		_, err = NewCorpus("corpus", 1, true, 0, nil)
+		assert.ErrorContains(t, err, "maximum corpus file size")
+	})
+}
@@ -0,0 +1,153 @@
+package coverage
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+
+	"golang.org/x/exp/maps"
+)
+
+// CoverageBaseline describes source line coverage achieved by a fuzzing campaign, in a serializable form which can be
+// compared against the coverage achieved by later campaigns. Coverage is described by source lines rather than
+// bytecode, so baselines remain comparable as long as the source files are unchanged.
+type CoverageBaseline struct {
+	// Files describes the coverage of each source file, keyed by source file path.
+	Files map[string]*CoverageBaselineFile `json:"files"`
+}
+
+// CoverageBaselineFile describes the source line coverage of a single source file in a CoverageBaseline.
+type CoverageBaselineFile struct {
+	// ActiveLineCount describes the count of lines that are executable within the source file.
+	ActiveLineCount int `json:"activeLineCount"`
+
+	// CoveredLines describes the line numbers (starting from 1) that were covered within the source file.
+	CoveredLines []int `json:"coveredLines"`
+}
+
+// CoverageRegression describes a source file whose coverage regressed when compared against a CoverageBaseline.
+type CoverageRegression struct {
+	// Path describes the path of the source file.
+	Path string
+
+	// BaselinePercent describes the percentage of active lines covered in the baseline.
+	BaselinePercent float64
+
+	// CurrentPercent describes the percentage of active lines covered currently.
+	CurrentPercent float64
+
+	// LostLines describes the line numbers (starting from 1) covered in the baseline, but not currently.
+	LostLines []int
+}
+
+// NewCoverageBaseline creates a CoverageBaseline from the results of a previously performed source analysis.
+func NewCoverageBaseline(sourceAnalysis *SourceAnalysis) *CoverageBaseline {
+	// Record the active line count and covered lines for each source file.
+	baseline := &CoverageBaseline{
+		Files: make(map[string]*CoverageBaselineFile),
+	}
+	for path, file := range sourceAnalysis.Files {
+		baselineFile := &CoverageBaselineFile{
+			ActiveLineCount: file.ActiveLineCount(),
+			CoveredLines:    make([]int, 0),
+		}
+		for i, line := range file.Lines {
+			if line.IsCovered || line.IsCoveredReverted {
+				baselineFile.CoveredLines = append(baselineFile.CoveredLines, i+1)
+			}
+		}
+		baseline.Files[path] = baselineFile
+	}
+	return baseline
+}
+
+// ReadCoverageBaselineFromFile reads a CoverageBaseline previously written with WriteToFile from the provided path.
+// Returns the CoverageBaseline, or an error if one occurred.
+func ReadCoverageBaselineFromFile(path string) (*CoverageBaseline, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var baseline CoverageBaseline
+	err = json.Unmarshal(b, &baseline)
+	if err != nil {
+		return nil, err
+	}
+	if baseline.Files == nil {
+		baseline.Files = make(map[string]*CoverageBaselineFile)
+	}
+	return &baseline, nil
+}
+
+// WriteToFile writes the CoverageBaseline to the provided path as JSON.
+// Returns an error if one occurred.
+func (b *CoverageBaseline) WriteToFile(path string) error {
+	data, err := json.MarshalIndent(b, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// CoveragePercent returns the percentage of active lines covered across all source files in the CoverageBaseline.
+func (b *CoverageBaseline) CoveragePercent() float64 {
+	activeLineCount, coveredLineCount := 0, 0
+	for _, file := range b.Files {
+		activeLineCount += file.ActiveLineCount
+		coveredLineCount += len(file.CoveredLines)
+	}
+	return coveragePercent(coveredLineCount, activeLineCount)
+}
+
+// Compare compares the coverage in this CoverageBaseline against the provided baseline, reporting every source file
+// present in both whose coverage percentage dropped by more than the provided threshold (in percentage points).
+// Source files missing from either baseline are ignored, as their coverage is not comparable.
+// Returns the regressions found, sorted by source file path.
+func (b *CoverageBaseline) Compare(baseline *CoverageBaseline, threshold float64) []CoverageRegression {
+	regressions := make([]CoverageRegression, 0)
+	paths := maps.Keys(baseline.Files)
+	sort.Strings(paths)
+	for _, path := range paths {
+		baselineFile := baseline.Files[path]
+		currentFile, ok := b.Files[path]
+		if !ok {
+			continue
+		}
+
+		// Compare coverage percentages, as a file's active line count may differ between compilations.
+		baselinePercent := coveragePercent(len(baselineFile.CoveredLines), baselineFile.ActiveLineCount)
+		currentPercent := coveragePercent(len(currentFile.CoveredLines), currentFile.ActiveLineCount)
+		if baselinePercent-currentPercent <= threshold {
+			continue
+		}
+
+		// Determine which lines are no longer covered.
+		currentCoveredLines := make(map[int]struct{}, len(currentFile.CoveredLines))
+		for _, line := range currentFile.CoveredLines {
+			currentCoveredLines[line] = struct{}{}
+		}
+		lostLines := make([]int, 0)
+		for _, line := range baselineFile.CoveredLines {
+			if _, ok := currentCoveredLines[line]; !ok {
+				lostLines = append(lostLines, line)
+			}
+		}
+
+		regressions = append(regressions, CoverageRegression{
+			Path:            path,
+			BaselinePercent: baselinePercent,
+			CurrentPercent:  currentPercent,
+			LostLines:       lostLines,
+		})
+	}
+	return regressions
+}
+
+// coveragePercent returns the percentage of active lines covered, treating a file without active lines as fully
+// covered.
+func coveragePercent(coveredLineCount int, activeLineCount int) float64 {
+	if activeLineCount == 0 {
+		return 100
+	}
+	return float64(coveredLineCount) * 100 / float64(activeLineCount)
+}
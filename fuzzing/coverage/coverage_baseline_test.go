@@ -0,0 +1,51 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCoverageBaselineCompare runs tests to ensure only source files present in both baselines whose coverage
+// percentage dropped by more than the threshold are reported as regressions, along with the lines no longer covered.
+func TestCoverageBaselineCompare(t *testing.T) {
+	baseline := &CoverageBaseline{
+		Files: map[string]*CoverageBaselineFile{
+			"a.sol":       {ActiveLineCount: 10, CoveredLines: []int{1, 2, 3, 4, 5, 6, 7, 8}},
+			"b.sol":       {ActiveLineCount: 4, CoveredLines: []int{1, 2}},
+			"removed.sol": {ActiveLineCount: 4, CoveredLines: []int{1, 2, 3, 4}},
+		},
+	}
+	current := &CoverageBaseline{
+		Files: map[string]*CoverageBaselineFile{
+			"a.sol":   {ActiveLineCount: 10, CoveredLines: []int{1, 2, 3, 4, 9, 10}},
+			"b.sol":   {ActiveLineCount: 4, CoveredLines: []int{1, 2, 3}},
+			"new.sol": {ActiveLineCount: 4, CoveredLines: []int{}},
+		},
+	}
+
+	// a.sol dropped from 80% to 60%, while b.sol improved and the other files are not comparable.
+	regressions := current.Compare(baseline, 0)
+	assert.Len(t, regressions, 1)
+	assert.EqualValues(t, "a.sol", regressions[0].Path)
+	assert.InDelta(t, 80, regressions[0].BaselinePercent, 0.001)
+	assert.InDelta(t, 60, regressions[0].CurrentPercent, 0.001)
+	assert.EqualValues(t, []int{5, 6, 7, 8}, regressions[0].LostLines)
+
+	// Drops within the threshold are not regressions.
+	assert.Len(t, current.Compare(baseline, 19.9), 1)
+	assert.Empty(t, current.Compare(baseline, 20))
+
+	// A baseline never regresses against itself, and files without active lines are considered fully covered.
+	assert.Empty(t, baseline.Compare(baseline, 0))
+	assert.Empty(t, (&CoverageBaseline{Files: map[string]*CoverageBaselineFile{"a.sol": {}}}).Compare(&CoverageBaseline{Files: map[string]*CoverageBaselineFile{"a.sol": {}}}, 0))
+
+	// Baselines are preserved when written to and read from a file.
+	path := filepath.Join(t.TempDir(), "coverage_baseline.json")
+	assert.NoError(t, baseline.WriteToFile(path))
+	readBaseline, err := ReadCoverageBaselineFromFile(path)
+	assert.NoError(t, err)
+	assert.EqualValues(t, baseline, readBaseline)
+	assert.InDelta(t, 14.0*100/18, readBaseline.CoveragePercent(), 0.001)
+}
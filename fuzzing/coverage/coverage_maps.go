@@ -7,6 +7,7 @@ import (
 	"github.com/crytic/medusa/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/exp/slices"
 	"sync"
 )
 
@@ -49,6 +50,28 @@ func (cm *CoverageMaps) Reset() {
 	cm.cachedMap = nil
 }
 
+// Clone creates a deep copy of the CoverageMaps, so it may be updated without affecting the original.
+// Returns the cloned CoverageMaps.
+func (cm *CoverageMaps) Clone() *CoverageMaps {
+	// Acquire our thread lock and defer our unlocking for when we exit this method
+	cm.updateLock.Lock()
+	defer cm.updateLock.Unlock()
+
+	// Copy every contract coverage map
+	clone := NewCoverageMaps()
+	for codeHash, mapsByAddress := range cm.maps {
+		clonedMapsByAddress := make(map[common.Address]*ContractCoverageMap, len(mapsByAddress))
+		for codeAddress, contractCoverageMap := range mapsByAddress {
+			clonedMapsByAddress[codeAddress] = &ContractCoverageMap{
+				successfulCoverage: contractCoverageMap.successfulCoverage.clone(),
+				revertedCoverage:   contractCoverageMap.revertedCoverage.clone(),
+			}
+		}
+		clone.maps[codeHash] = clonedMapsByAddress
+	}
+	return clone
+}
+
 // Equal checks whether two coverage maps are the same. Equality is determined if the keys and values are all the same.
 func (cm *CoverageMaps) Equal(b *CoverageMaps) bool {
 	// Iterate through all maps
@@ -305,6 +328,17 @@ func (cm *CoverageMapBytecodeData) Reset() {
 	cm.executedFlags = nil
 }
 
+// clone creates a copy of the CoverageMapBytecodeData.
+// Returns the cloned CoverageMapBytecodeData, or nil if the current one is nil.
+func (cm *CoverageMapBytecodeData) clone() *CoverageMapBytecodeData {
+	if cm == nil {
+		return nil
+	}
+	return &CoverageMapBytecodeData{
+		executedFlags: slices.Clone(cm.executedFlags),
+	}
+}
+
 // Equal checks whether the provided CoverageMapBytecodeData contains the same data as the current one.
 // Returns a boolean indicating whether the two maps match.
 func (cm *CoverageMapBytecodeData) Equal(b *CoverageMapBytecodeData) bool {
@@ -3,7 +3,6 @@ package coverage
 import (
 	_ "embed"
 	"fmt"
-	"github.com/crytic/medusa/compilation/types"
 	"github.com/crytic/medusa/utils"
 	"html/template"
 	"math"
@@ -18,21 +17,15 @@ var (
 	htmlReportTemplate []byte
 )
 
-// GenerateReport takes a set of CoverageMaps and compilations, and produces a coverage report using them, detailing
+// GenerateReport takes a previously performed source analysis, and produces a coverage report using it, detailing
 // all source mapped ranges of the source files which were covered or not.
 // Returns an error if one occurred.
-func GenerateReport(compilations []types.Compilation, coverageMaps *CoverageMaps, htmlReportPath string) error {
-	// Perform source analysis.
-	sourceAnalysis, err := AnalyzeSourceCoverage(compilations, coverageMaps)
-	if err != nil {
-		return err
-	}
-
-	// Finally, export the report data we analyzed.
+func GenerateReport(sourceAnalysis *SourceAnalysis, htmlReportPath string) error {
+	// Export the report data we analyzed.
 	if htmlReportPath != "" {
-		err = exportCoverageReport(sourceAnalysis, htmlReportPath)
+		return exportCoverageReport(sourceAnalysis, htmlReportPath)
 	}
-	return err
+	return nil
 }
 
 // exportCoverageReport takes a previously performed source analysis and generates an HTML coverage report from it.
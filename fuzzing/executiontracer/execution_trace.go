@@ -175,8 +175,18 @@ func (t *ExecutionTrace) generateCallFrameExitString(callFrame *CallFrame) strin
 
 	// Try to unpack a custom Solidity error from the return values.
 	matchedCustomError, unpackedCustomErrorArgs := abiutils.GetSolidityCustomRevertError(callFrame.CodeContractAbi, callFrame.ReturnError, callFrame.ReturnData)
+	if matchedCustomError == nil {
+		// If we couldn't resolve the error from our immediate contract ABI, it may be defined in a library or another
+		// contract which bubbled it up, so we try to resolve it from any contract definition.
+		for _, contract := range t.contractDefinitions {
+			matchedCustomError, unpackedCustomErrorArgs = abiutils.GetSolidityCustomRevertError(&contract.CompiledContract().Abi, callFrame.ReturnError, callFrame.ReturnData)
+			if matchedCustomError != nil {
+				break
+			}
+		}
+	}
 	if matchedCustomError != nil {
-		customErrorArgsDisplayText, err := valuegeneration.EncodeABIArgumentsToString(matchedCustomError.Inputs, unpackedCustomErrorArgs)
+		customErrorArgsDisplayText, err := valuegeneration.EncodeABIArgumentsToNamedString(matchedCustomError.Inputs, unpackedCustomErrorArgs)
 		if err == nil {
 			return fmt.Sprintf("[revert (error: %v(%v))]", matchedCustomError.Name, customErrorArgsDisplayText)
 		}
@@ -2,14 +2,19 @@ package fuzzing
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/crytic/medusa/fuzzing/coverage"
 	"math/big"
 	"math/rand"
+	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/crytic/medusa/fuzzing/calls"
@@ -26,9 +31,14 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
 
+// corpusRandomSeedIndex describes the index used to derive the seed of the corpus' random provider from the Fuzzer's
+// random seed. It is negative so it never collides with the index of a worker.
+const corpusRandomSeedIndex = -1
+
 // Fuzzer represents an Ethereum smart contract fuzzing provider.
 type Fuzzer struct {
 	// ctx describes the context for the fuzzing run, used to cancel running operations.
@@ -40,6 +50,18 @@ type Fuzzer struct {
 	config config.ProjectConfig
 	// senders describes a set of account addresses used to send state changing calls in fuzzing campaigns.
 	senders []common.Address
+	// senderWeights describes the weight of each sender address, determining how likely it is to be selected as the
+	// sender of a state changing call.
+	senderWeights map[common.Address]uint64
+	// senderRestrictions tracks which methods can only be successfully called by a single sender, so generated calls
+	// to them can be biased towards that sender. This is nil if sender restrictions should not be learned.
+	senderRestrictions *SenderRestrictionFeedbackProvider
+	// methodExecutions tracks how often each state changing method was selected, executed and succeeded, so methods
+	// which were never successfully executed can be reported. This is nil if neither unreachable method reporting nor
+	// coverage stall detection is enabled.
+	methodExecutions *MethodExecutionFeedbackProvider
+	// excludedContractAddresses describes the addresses of deployed contracts which should never be called.
+	excludedContractAddresses []common.Address
 	// deployer describes an account address used to deploy contracts in fuzzing campaigns.
 	deployer common.Address
 
@@ -50,17 +72,41 @@ type Fuzzer struct {
 	contractDefinitions fuzzerTypes.Contracts
 	// baseValueSet represents a valuegeneration.ValueSet containing input values for our fuzz tests.
 	baseValueSet *valuegeneration.ValueSet
+	// failingValueSet represents a valuegeneration.ValueSet containing argument values of calls in failing test
+	// sequences. It is persisted to the corpus directory so later campaigns can be seeded with them. It is guarded by
+	// testCasesLock.
+	failingValueSet *valuegeneration.ValueSet
+	// failingValuesFileLock serializes writes of the failing value set, so an older copy never overwrites a newer one.
+	failingValuesFileLock sync.Mutex
 
 	// workers represents the work threads created by this Fuzzer when Start invokes a fuzz operation.
 	workers []*FuzzerWorker
 	// metrics represents the metrics for the fuzzing campaign.
 	metrics *FuzzerMetrics
+
+	// zeroValueSmokeTestOnce ensures the zero value smoke test is only performed by a single worker per campaign.
+	zeroValueSmokeTestOnce sync.Once
+	// zeroValueSmokeTestFailures describes the methods which failed when called with zero-valued arguments by the
+	// zero value smoke test, if it was performed.
+	zeroValueSmokeTestFailures []string
+	// generationStats describes the value generation statistics merged from destroyed workers, or nil if they are not
+	// collected.
+	generationStats *valuegeneration.AbiValueGenerationStats
 	// corpus stores a list of transaction sequences that can be used for coverage-guided fuzzing
 	corpus *corpus.Corpus
 
-	// randomProvider describes the provider used to generate random values in the Fuzzer. All other random providers
-	// used by the Fuzzer's subcomponents are derived from this one.
+	// randomProvider describes the provider used to generate random values in the Fuzzer itself. The random providers
+	// used by the Fuzzer's subcomponents (e.g. workers and the corpus) are instead seeded with seeds derived from
+	// randomSeed, so they do not depend on the order in which they are created.
 	randomProvider *rand.Rand
+	// callThrottle limits the rate at which workers execute calls. If nil, calls are not throttled.
+	callThrottle *callThrottle
+	// heapAlloc describes the heap memory allocated by the process in bytes, as last sampled by
+	// memoryUsageSampleLoop. It is accessed atomically, and is only sampled if a WorkerMemoryLimit is configured.
+	heapAlloc uint64
+
+	// randomSeed describes the seed used to initialize randomProvider. Each worker derives its own seed from it.
+	randomSeed int64
 
 	// testCases contains every TestCase registered with the Fuzzer.
 	testCases []TestCase
@@ -85,31 +131,106 @@ func NewFuzzer(config config.ProjectConfig) (*Fuzzer, error) {
 		return nil, err
 	}
 
+	// If we are only replaying the corpus, we do so with a single worker, so call sequences are replayed
+	// deterministically and fuzzing can stop as soon as all of them have been replayed.
+	if config.Fuzzing.ReplayOnly {
+		config.Fuzzing.Workers = 1
+	}
+
+	// If a worker count was not specified, use the number of available CPUs. Otherwise, warn if it significantly exceeds
+	// them, as this typically hurts throughput.
+	cpuCount := runtime.NumCPU()
+	if config.Fuzzing.Workers <= 0 {
+		config.Fuzzing.Workers = cpuCount
+	} else if config.Fuzzing.Workers > 2*cpuCount {
+		fmt.Printf("Warning: the worker count (%d) significantly exceeds the number of available CPUs (%d), which may reduce throughput\n", config.Fuzzing.Workers, cpuCount)
+	}
+
+	// Collect value generation statistics if they should be reported. Each worker collects its own, which are merged
+	// into these when it is destroyed.
+	var generationStats *valuegeneration.AbiValueGenerationStats
+	if config.Fuzzing.ValueGeneration.ReportGenerationStats {
+		generationStats = valuegeneration.NewAbiValueGenerationStats()
+	}
+
 	// Parse the senders addresses from our account config.
 	senders, err := utils.HexStringsToAddresses(config.Fuzzing.SenderAddresses)
 	if err != nil {
 		return nil, err
 	}
 
+	// Parse the sender address weights from our account config. Senders without a specified weight default to 1.
+	senderWeights := make(map[common.Address]uint64)
+	for _, sender := range senders {
+		senderWeights[sender] = 1
+	}
+	for senderHexString, weight := range config.Fuzzing.SenderAddressWeights {
+		sender, err := utils.HexStringToAddress(senderHexString)
+		if err != nil {
+			return nil, err
+		}
+		senderWeights[sender] = weight
+	}
+
+	// Parse the addresses of contracts which should never be called.
+	excludedContractAddresses, err := utils.HexStringsToAddresses(config.Fuzzing.Testing.ExcludeContractAddresses)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse the deployer address from our account config
 	deployer, err := utils.HexStringToAddress(config.Fuzzing.DeployerAddress)
 	if err != nil {
 		return nil, err
 	}
 
+	// Create the providers used to track the execution results of calls. Method executions are only tracked if they
+	// are reported, either when the fuzzer exits or when coverage stalls. The reasons calls failed are only recorded
+	// if coverage stall detection is enabled, as they are only reported when coverage stalls. If enabled, we also
+	// create a provider to learn which methods are restricted to a single sender, from the senders which can be selected.
+	feedbackProviders := []FeedbackProvider{&CoverageFeedbackProvider{}}
+	stallDetectionEnabled := config.Fuzzing.CoverageEnabled && (config.Fuzzing.CoverageStallTimeout > 0 || config.Fuzzing.CoverageStallCallLimit > 0)
+	var methodExecutions *MethodExecutionFeedbackProvider
+	if config.Fuzzing.ReportUnreachableMethods || stallDetectionEnabled {
+		methodExecutions = NewMethodExecutionFeedbackProvider(config.Fuzzing.Workers, stallDetectionEnabled)
+		feedbackProviders = append(feedbackProviders, methodExecutions)
+	}
+	var senderRestrictions *SenderRestrictionFeedbackProvider
+	if config.Fuzzing.LearnSenderRestrictions {
+		selectableSenders := make([]common.Address, 0, len(senders))
+		for _, sender := range senders {
+			if senderWeights[sender] > 0 {
+				selectableSenders = append(selectableSenders, sender)
+			}
+		}
+		senderRestrictions = NewSenderRestrictionFeedbackProvider(selectableSenders)
+		feedbackProviders = append(feedbackProviders, senderRestrictions)
+	}
+
 	// Create and return our fuzzing instance.
 	fuzzer := &Fuzzer{
-		config:              config,
-		senders:             senders,
-		deployer:            deployer,
-		baseValueSet:        valuegeneration.NewValueSet(),
-		contractDefinitions: make(fuzzerTypes.Contracts, 0),
-		testCases:           make([]TestCase, 0),
-		testCasesFinished:   make(map[string]TestCase),
+		config:                    config,
+		senders:                   senders,
+		senderWeights:             senderWeights,
+		senderRestrictions:        senderRestrictions,
+		methodExecutions:          methodExecutions,
+		excludedContractAddresses: excludedContractAddresses,
+		deployer:                  deployer,
+		baseValueSet:              valuegeneration.NewValueSet(),
+		contractDefinitions:       make(fuzzerTypes.Contracts, 0),
+		testCases:                 make([]TestCase, 0),
+		testCasesFinished:         make(map[string]TestCase),
+		generationStats:           generationStats,
 		Hooks: FuzzerHooks{
 			NewCallSequenceGeneratorConfigFunc: defaultNewCallSequenceGeneratorConfigFunc,
 			ChainSetupFunc:                     chainSetupFromCompilations,
 			CallSequenceTestFuncs:              make([]CallSequenceTestFunc, 0),
+			FeedbackProviders:                  feedbackProviders,
+			MethodArgumentsValidators:          make(map[string]MethodArgumentsValidatorFunc),
+			MethodAddressCorrelations:          make(map[string][]AddressCorrelation),
+			MethodArgumentValueProviders:       make(map[string][]MethodArgumentValueProvider),
+			TupleFixups:                        make(map[string]valuegeneration.TupleFixupFunc),
+			JSONArgumentCodecs:                 make(valuegeneration.JSONArgumentCodecs),
 		},
 	}
 
@@ -149,6 +270,23 @@ func (f *Fuzzer) ContractDefinitions() fuzzerTypes.Contracts {
 	return slices.Clone(f.contractDefinitions)
 }
 
+// UnreachableMethods returns the execution stats for every state changing method which was never successfully
+// executed during the fuzzing campaign, either because it was never selected or because every call to it reverted.
+// Returns nil if method executions are not tracked.
+func (f *Fuzzer) UnreachableMethods() []MethodExecutionStats {
+	if f.methodExecutions == nil {
+		return nil
+	}
+	return f.methodExecutions.UnreachableMethods()
+}
+
+// ZeroValueSmokeTestFailures returns a description of each state changing method which failed when called with
+// zero-valued arguments by the zero value smoke test, sorted by contract and method.
+// Returns nil if the smoke test was not performed.
+func (f *Fuzzer) ZeroValueSmokeTestFailures() []string {
+	return f.zeroValueSmokeTestFailures
+}
+
 // Config exposes the underlying project configuration provided to the Fuzzer.
 func (f *Fuzzer) Config() config.ProjectConfig {
 	return f.config
@@ -199,29 +337,117 @@ func (f *Fuzzer) RegisterTestCase(testCase TestCase) {
 }
 
 // ReportTestCaseFinished is used to report a TestCase status as finalized to the Fuzzer.
-func (f *Fuzzer) ReportTestCaseFinished(testCase TestCase) {
+// Returns an error if the argument values of a failed test case could not be persisted.
+func (f *Fuzzer) ReportTestCaseFinished(testCase TestCase) error {
 	// Acquire a thread lock to avoid race conditions
 	f.testCasesLock.Lock()
-	defer f.testCasesLock.Unlock()
 
 	// If we already reported this test case as finished, stop
 	if _, alreadyExists := f.testCasesFinished[testCase.ID()]; alreadyExists {
-		return
+		f.testCasesLock.Unlock()
+		return nil
 	}
 
 	// Otherwise now mark the test case as finished.
 	f.testCasesFinished[testCase.ID()] = testCase
 
+	// If the test case failed, record the argument values of its call sequence so future campaigns can reuse them.
+	testCaseFailed := testCase.Status() == TestCaseStatusFailed
+	if testCaseFailed {
+		f.recordFailingValues(testCase.CallSequence())
+	}
+
 	// We only log here if we're not configured to stop on the first test failure. This is because the fuzzer prints
 	// results on exit, so we avoid duplicate messages.
 	if !f.config.Fuzzing.Testing.StopOnFailedTest {
 		fmt.Printf("\n[%s] %s\n%s\n\n", testCase.Status(), testCase.Name(), testCase.Message())
 	}
+	f.testCasesLock.Unlock()
 
 	// If the config specifies, we stop after the first failed test reported.
-	if testCase.Status() == TestCaseStatusFailed && f.config.Fuzzing.Testing.StopOnFailedTest {
+	if testCaseFailed && f.config.Fuzzing.Testing.StopOnFailedTest {
 		f.Stop()
 	}
+
+	// Persist the failing values outside of the test case lock, as this performs file I/O.
+	if testCaseFailed {
+		return f.saveFailingValues()
+	}
+	return nil
+}
+
+// failingValuesPath obtains the file path which argument values of failing test sequences are persisted to.
+// Returns the file path, or an empty string if no corpus directory is configured.
+func (f *Fuzzer) failingValuesPath() string {
+	if f.config.Fuzzing.CorpusDirectory == "" {
+		return ""
+	}
+	return filepath.Join(f.config.Fuzzing.CorpusDirectory, "failing_values.json")
+}
+
+// loadFailingValues reads argument values of failing test sequences persisted by a prior campaign, and adds them to
+// the base value set used to generate inputs. If no values were persisted, this does nothing.
+// Returns an error if one occurs.
+func (f *Fuzzer) loadFailingValues() error {
+	f.failingValueSet = valuegeneration.NewValueSet()
+	path := f.failingValuesPath()
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	valueSet, err := valuegeneration.ReadValueSetFromFile(path)
+	if err != nil {
+		return err
+	}
+	f.failingValueSet.AddValueSet(valueSet)
+	f.baseValueSet.AddValueSet(valueSet)
+	return nil
+}
+
+// recordFailingValues adds the argument values of every call in the provided failing call sequence to the failing
+// value set. The caller must hold testCasesLock.
+func (f *Fuzzer) recordFailingValues(callSequence *calls.CallSequence) {
+	if callSequence == nil || f.failingValueSet == nil {
+		return
+	}
+	for _, element := range *callSequence {
+		if element == nil || element.Call == nil || element.Call.MsgDataAbiValues == nil || element.Call.MsgDataAbiValues.Method == nil {
+			continue
+		}
+		inputs := element.Call.MsgDataAbiValues.Method.Inputs
+		inputValues := element.Call.MsgDataAbiValues.InputValues
+		for i := 0; i < len(inputs) && i < len(inputValues); i++ {
+			f.failingValueSet.AddFromAbiValue(&inputs[i].Type, inputValues[i])
+		}
+	}
+}
+
+// saveFailingValues persists a copy of the failing value set to the corpus directory, if one is configured.
+// Returns an error if one occurs.
+func (f *Fuzzer) saveFailingValues() error {
+	path := f.failingValuesPath()
+	if path == "" || f.failingValueSet == nil {
+		return nil
+	}
+
+	// Copy the values while holding the test case lock, serializing writes so the latest copy is written last.
+	f.failingValuesFileLock.Lock()
+	defer f.failingValuesFileLock.Unlock()
+	f.testCasesLock.Lock()
+	failingValueSet := f.failingValueSet.Clone()
+	f.testCasesLock.Unlock()
+
+	err := utils.MakeDirectory(f.config.Fuzzing.CorpusDirectory)
+	if err != nil {
+		return err
+	}
+	err = failingValueSet.WriteToFile(path)
+	if err != nil {
+		return fmt.Errorf("could not write failing sequence values to %v: %v", path, err)
+	}
+	return nil
 }
 
 // AddCompilationTargets takes a compilation and updates the Fuzzer state with additional Fuzzer.ContractDefinitions
@@ -243,6 +469,15 @@ func (f *Fuzzer) AddCompilationTargets(compilations []compilationTypes.Compilati
 				contract := source.Contracts[contractName]
 				contractDefinition := fuzzerTypes.NewContract(contractName, sourcePath, &contract, compilation)
 				f.contractDefinitions = append(f.contractDefinitions, contractDefinition)
+
+				// Seed our base value set with the contract's method selectors and event IDs, so fixed-sized byte
+				// arguments which dispatch on them (e.g. bytes4 selectors) can be generated.
+				for _, method := range contract.Abi.Methods {
+					f.baseValueSet.AddFixedBytes(method.ID)
+				}
+				for _, event := range contract.Abi.Events {
+					f.baseValueSet.AddFixedBytes(event.ID.Bytes())
+				}
 			}
 		}
 
@@ -273,6 +508,17 @@ func (f *Fuzzer) createTestChain() (*chain.TestChain, error) {
 		Balance: initBalance,
 	}
 
+	// Place the code of any contracts which already exist at an address in the genesis block, if it was provided.
+	for _, contract := range f.contractDefinitions {
+		compiledContract := contract.CompiledContract()
+		if compiledContract.ExistingAddress != nil && len(compiledContract.RuntimeBytecode) > 0 {
+			genesisAlloc[*compiledContract.ExistingAddress] = core.GenesisAccount{
+				Balance: big.NewInt(0),
+				Code:    compiledContract.RuntimeBytecode,
+			}
+		}
+	}
+
 	// Create our test chain with our basic allocations and passed medusa's chain configuration
 	testChain, err := chain.NewTestChain(genesisAlloc, &f.config.Fuzzing.TestChainConfig)
 
@@ -281,6 +527,135 @@ func (f *Fuzzer) createTestChain() (*chain.TestChain, error) {
 	return testChain, err
 }
 
+// resolveLibraryPlaceholders obtains the addresses of libraries which may be linked into contracts before they are
+// deployed, keyed by the placeholders which may reference them in unlinked bytecode. Library addresses are sourced
+// from the project configuration, or otherwise from the provided lookup of contracts deployed so far.
+// Returns the library addresses keyed by placeholder, or an error if a configured address could not be resolved.
+func (f *Fuzzer) resolveLibraryPlaceholders(deployedContractAddr map[string]common.Address) (map[string]common.Address, error) {
+	addressType, err := abi.NewType("address", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	placeholderAddresses := make(map[string]common.Address)
+	for _, compilation := range f.compilations {
+		for sourcePath, source := range compilation.Sources {
+			for contractName := range source.Contracts {
+				// Resolve the library's address from our config, keyed by name or fully qualified name. If it was not
+				// configured, fall back to its deployed address, if it was deployed.
+				address, ok := deployedContractAddr[contractName]
+				configuredAddress, configured := f.config.Fuzzing.LibraryAddresses[contractName]
+				if !configured {
+					configuredAddress, configured = f.config.Fuzzing.LibraryAddresses[sourcePath+":"+contractName]
+				}
+				if configured {
+					decoded, err := valuegeneration.DecodeJSONArgumentsFromSlice(abi.Arguments{{Name: contractName, Type: addressType}}, []any{configuredAddress}, deployedContractAddr, nil, f.config.Fuzzing.MaxDecodedLength, f.Hooks.JSONArgumentCodecs)
+					if err != nil {
+						return nil, fmt.Errorf("could not resolve the configured address of library '%v': %v", contractName, err)
+					}
+					address, ok = decoded[0].(common.Address), true
+				}
+				if !ok {
+					continue
+				}
+
+				// Map each placeholder which may reference this library to its address, under any name the compiler
+				// may have referred to its source file by.
+				for _, sourceUnitName := range compilationTypes.GetSourceUnitNames(sourcePath, source.Ast) {
+					for _, placeholder := range compilationTypes.GetLibraryPlaceholders(sourceUnitName, contractName) {
+						placeholderAddresses[placeholder] = address
+					}
+				}
+			}
+		}
+	}
+	return placeholderAddresses, nil
+}
+
+// validateConstructorArgs performs a dry run of decoding the configured constructor arguments against the constructor
+// ABI of each compiled contract in the deployment order, so malformed arguments are reported before fuzzing starts
+// rather than during deployment. Deployed contract references (e.g. "DeployedContract:Name") are checked to refer to a
+// contract deployed earlier in the deployment order. Arguments are resolved as chain setup resolves them, using
+// resolveContractDeployment.
+// Returns an error describing every mismatch found, or nil if none were.
+func (f *Fuzzer) validateConstructorArgs() error {
+	// Determine our deployment order, inferring it as chain setup does if only a single contract was compiled.
+	deploymentOrder := f.config.Fuzzing.DeploymentOrder
+	if len(deploymentOrder) == 0 && len(f.contractDefinitions) == 1 {
+		deploymentOrder = []string{f.contractDefinitions[0].Name()}
+	}
+
+	// Decode the arguments for each contract, using placeholder addresses for contracts deployed before it, or the
+	// address of contracts which already exist at one.
+	mismatches := make([]string, 0)
+	deployedContractAddr := make(map[string]common.Address)
+	for i, contractName := range deploymentOrder {
+		contract, _, err := f.resolveContractDeployment(contractName, deployedContractAddr)
+		if err != nil {
+			mismatches = append(mismatches, err.Error())
+		}
+		deployedContractAddr[contractName] = common.BigToAddress(big.NewInt(int64(i + 1)))
+		if contract != nil && contract.CompiledContract().ExistingAddress != nil {
+			deployedContractAddr[contractName] = *contract.CompiledContract().ExistingAddress
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("invalid constructor arguments were provided:\n%v", strings.Join(mismatches, "\n"))
+	}
+	return nil
+}
+
+// resolveContractDeployment looks up the contract definition with the provided name from the deployment order, and
+// decodes the constructor arguments configured for it, resolving references to deployed contracts (e.g.
+// "DeployedContract:Name") using the provided addresses. Contracts which already exist at an address are not
+// deployed, so they must not be provided constructor arguments.
+// Returns the contract definition and its decoded constructor arguments, or an error if the contract could not be
+// found or the configured arguments do not match its constructor. The contract definition is returned if it was found,
+// even if an error occurred.
+func (f *Fuzzer) resolveContractDeployment(contractName string, deployedContractAddr map[string]common.Address) (*fuzzerTypes.Contract, []any, error) {
+	// Look for a contract in our compiled contract definitions that matches this one
+	var contract *fuzzerTypes.Contract
+	for _, contractDefinition := range f.contractDefinitions {
+		if contractDefinition.Name() == contractName {
+			contract = contractDefinition
+			break
+		}
+	}
+	if contract == nil {
+		return nil, nil, fmt.Errorf("DeploymentOrder specified a contract name which was not found in the compilation: %v\n", contractName)
+	}
+
+	// If the contract already exists at an address, it takes no constructor arguments.
+	jsonArgs, ok := f.config.Fuzzing.ConstructorArgs[contractName]
+	if existingAddress := contract.CompiledContract().ExistingAddress; existingAddress != nil {
+		if ok {
+			return contract, nil, fmt.Errorf("constructor arguments were specified for contract '%v', but it already exists at address %v", contractName, existingAddress.Hex())
+		}
+		return contract, nil, nil
+	}
+
+	// Otherwise, decode the arguments for its constructor.
+	inputs := contract.CompiledContract().Abi.Constructor.Inputs
+	if len(inputs) == 0 {
+		if ok && len(jsonArgs) > 0 {
+			return contract, nil, fmt.Errorf("contract '%v' has no constructor arguments, but %d were provided", contractName, len(jsonArgs))
+		}
+		return contract, make([]any, 0), nil
+	}
+	if !ok {
+		return contract, nil, fmt.Errorf("constructor arguments for contract '%v' not provided", contractName)
+	}
+	if unknownKeys := valuegeneration.UnknownJSONArgumentKeys(inputs, jsonArgs); len(unknownKeys) > 0 {
+		return contract, nil, fmt.Errorf("constructor arguments for contract '%v' include unknown argument(s): %v", contractName, strings.Join(unknownKeys, ", "))
+	}
+	args, err := valuegeneration.DecodeJSONArgumentsFromMap(inputs, jsonArgs, deployedContractAddr, nil, f.config.Fuzzing.MaxDecodedLength, f.Hooks.JSONArgumentCodecs)
+	if err != nil {
+		return contract, nil, fmt.Errorf("constructor arguments for contract '%v' could not be decoded: %v", contractName, err)
+	}
+	return contract, args, nil
+}
+
 // chainSetupFromCompilations is a TestChainSetupFunc which sets up the base test chain state by deploying
 // all compiled contract definitions. This includes any successful compilations as a result of the Fuzzer.config
 // definitions, as well as those added by Fuzzer.AddCompilationTargets. The contract deployment order is defined by
@@ -296,77 +671,84 @@ func chainSetupFromCompilations(fuzzer *Fuzzer, testChain *chain.TestChain) erro
 		}
 	}
 
+	// Verify every contract we were provided constructor arguments for will be deployed, so they are not silently
+	// ignored. We check in sorted order so the reported contract is deterministic.
+	constructorArgsContractNames := maps.Keys(fuzzer.config.Fuzzing.ConstructorArgs)
+	sort.Strings(constructorArgsContractNames)
+	for _, contractName := range constructorArgsContractNames {
+		if !slices.Contains(fuzzer.config.Fuzzing.DeploymentOrder, contractName) {
+			return fmt.Errorf("constructor arguments were specified for contract '%v', but it is not in the deployment order", contractName)
+		}
+	}
+
 	// Loop for all contracts to deploy
 	deployedContractAddr := make(map[string]common.Address)
 	for _, contractName := range fuzzer.config.Fuzzing.DeploymentOrder {
-		// Look for a contract in our compiled contract definitions that matches this one
-		found := false
-		for _, contract := range fuzzer.contractDefinitions {
-			// If we found a contract definition that matches this definition by name, try to deploy it
-			if contract.Name() == contractName {
-				args := make([]any, 0)
-				if len(contract.CompiledContract().Abi.Constructor.Inputs) > 0 {
-					jsonArgs, ok := fuzzer.config.Fuzzing.ConstructorArgs[contractName]
-					if !ok {
-						return fmt.Errorf("constructor arguments for contract %s not provided", contractName)
-					}
-					decoded, err := valuegeneration.DecodeJSONArgumentsFromMap(contract.CompiledContract().Abi.Constructor.Inputs,
-						jsonArgs, deployedContractAddr)
-					if err != nil {
-						return err
-					}
-					args = decoded
-				}
-
-				// Constructor our deployment message/tx data field
-				msgData, err := contract.CompiledContract().GetDeploymentMessageData(args)
-				if err != nil {
-					return fmt.Errorf("initial contract deployment failed for contract \"%v\", error: %v", contractName, err)
-				}
+		// Look up the contract definition for this contract and decode our constructor arguments.
+		contract, args, err := fuzzer.resolveContractDeployment(contractName, deployedContractAddr)
+		if err != nil {
+			return err
+		}
 
-				// Create a message to represent our contract deployment (we let deployments consume the whole block
-				// gas limit rather than use tx gas limit)
-				msg := calls.NewCallMessage(fuzzer.deployer, nil, 0, big.NewInt(0), fuzzer.config.Fuzzing.BlockGasLimit, nil, nil, nil, msgData)
-				msg.FillFromTestChainProperties(testChain)
+		// If the contract already exists at an address, it is not deployed. We only verify code exists there.
+		if existingAddress := contract.CompiledContract().ExistingAddress; existingAddress != nil {
+			if testChain.State().GetCodeSize(*existingAddress) == 0 {
+				return fmt.Errorf("contract '%v' was expected to exist at address %v, but no code exists there", contractName, existingAddress.Hex())
+			}
+			deployedContractAddr[contractName] = *existingAddress
+			continue
+		}
 
-				// Create a new pending block we'll commit to chain
-				block, err := testChain.PendingBlockCreate()
-				if err != nil {
-					return err
-				}
+		// If the contract references external libraries, link their addresses into its bytecode.
+		compiledContract := contract.CompiledContract()
+		if len(compiledContract.LibraryPlaceholders) > 0 || len(compiledContract.RuntimeLibraryPlaceholders) > 0 {
+			placeholderAddresses, err := fuzzer.resolveLibraryPlaceholders(deployedContractAddr)
+			if err != nil {
+				return err
+			}
+			compiledContract, err = compiledContract.LinkLibraries(placeholderAddresses)
+			if err != nil {
+				return fmt.Errorf("initial contract deployment failed for contract \"%v\", ensure its libraries are deployed first or specified in the library addresses: %v", contractName, err)
+			}
+		}
 
-				// Add our transaction to the block
-				err = testChain.PendingBlockAddTx(msg)
-				if err != nil {
-					return err
-				}
+		// Constructor our deployment message/tx data field
+		msgData, err := compiledContract.GetDeploymentMessageData(args)
+		if err != nil {
+			return fmt.Errorf("initial contract deployment failed for contract \"%v\", error: %v", contractName, err)
+		}
 
-				// Commit the pending block to the chain, so it becomes the new head.
-				err = testChain.PendingBlockCommit()
-				if err != nil {
-					return err
-				}
+		// Create a message to represent our contract deployment (we let deployments consume the whole block
+		// gas limit rather than use tx gas limit)
+		msg := calls.NewCallMessage(fuzzer.deployer, nil, 0, big.NewInt(0), fuzzer.config.Fuzzing.BlockGasLimit, nil, nil, nil, msgData)
+		msg.FillFromTestChainProperties(testChain)
 
-				// Ensure our transaction succeeded
-				if block.MessageResults[0].Receipt.Status != types.ReceiptStatusSuccessful {
-					return fmt.Errorf("contract deployment tx returned a failed status: %v", block.MessageResults[0].ExecutionResult.Err)
-				}
+		// Create a new pending block we'll commit to chain
+		block, err := testChain.PendingBlockCreate()
+		if err != nil {
+			return err
+		}
 
-				// Record our deployed contract so the next config-specified constructor args can reference this
-				// contract by name.
-				deployedContractAddr[contractName] = block.MessageResults[0].Receipt.ContractAddress
+		// Add our transaction to the block
+		err = testChain.PendingBlockAddTx(msg)
+		if err != nil {
+			return err
+		}
 
-				// Flag that we found a matching compiled contract definition and deployed it, then exit out of this
-				// inner loop to process the next contract to deploy in the outer loop.
-				found = true
-				break
-			}
+		// Commit the pending block to the chain, so it becomes the new head.
+		err = testChain.PendingBlockCommit()
+		if err != nil {
+			return err
 		}
 
-		// If we did not find a contract corresponding to this item in the deployment order, we throw an error.
-		if !found {
-			return fmt.Errorf("DeploymentOrder specified a contract name which was not found in the compilation: %v\n", contractName)
+		// Ensure our transaction succeeded
+		if block.MessageResults[0].Receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("contract deployment tx returned a failed status: %v", block.MessageResults[0].ExecutionResult.Err)
 		}
+
+		// Record our deployed contract so the next config-specified constructor args can reference this
+		// contract by name.
+		deployedContractAddr[contractName] = block.MessageResults[0].Receipt.ContractAddress
 	}
 	return nil
 }
@@ -374,14 +756,22 @@ func chainSetupFromCompilations(fuzzer *Fuzzer, testChain *chain.TestChain) erro
 // defaultNewCallSequenceGeneratorConfigFunc is a NewCallSequenceGeneratorConfigFunc which creates a
 // CallSequenceGeneratorConfig with a default configuration. Returns the config or an error, if one occurs.
 func defaultNewCallSequenceGeneratorConfigFunc(fuzzer *Fuzzer, valueSet *valuegeneration.ValueSet, randomProvider *rand.Rand) (*CallSequenceGeneratorConfig, error) {
+	// Create the scale constants used to generate scaled integers.
+	scaleConstants := make([]*big.Int, len(fuzzer.config.Fuzzing.ValueGeneration.ScaleConstantExponents))
+	for i, exponent := range fuzzer.config.Fuzzing.ValueGeneration.ScaleConstantExponents {
+		scaleConstants[i] = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+	}
+
 	// Create the underlying value generator for the worker and its sequence generator.
 	valueGenConfig := &valuegeneration.MutatingValueGeneratorConfig{
 		MinMutationRounds:               0,
 		MaxMutationRounds:               1,
-		GenerateRandomAddressBias:       0.5,
-		GenerateRandomIntegerBias:       0.5,
-		GenerateRandomStringBias:        0.5,
-		GenerateRandomBytesBias:         0.5,
+		GenerateRandomAddressBias:       fuzzer.config.Fuzzing.ValueGeneration.GenerateRandomAddressBias,
+		GenerateRandomIntegerBias:       fuzzer.config.Fuzzing.ValueGeneration.GenerateRandomIntegerBias,
+		GenerateRandomStringBias:        fuzzer.config.Fuzzing.ValueGeneration.GenerateRandomStringBias,
+		GenerateRandomBytesBias:         fuzzer.config.Fuzzing.ValueGeneration.GenerateRandomBytesBias,
+		GenerateRandomFixedBytesBias:    fuzzer.config.Fuzzing.ValueGeneration.GenerateRandomFixedBytesBias,
+		GenerateRandomArrayLengthBias:   fuzzer.config.Fuzzing.ValueGeneration.GenerateRandomArrayLengthBias,
 		MutateAddressProbability:        0.1,
 		MutateArrayStructureProbability: 0.1,
 		MutateBoolProbability:           0.1,
@@ -393,32 +783,90 @@ func defaultNewCallSequenceGeneratorConfigFunc(fuzzer *Fuzzer, valueSet *valuege
 		MutateIntegerProbability:        0.1,
 		MutateIntegerGenerateNewBias:    0.5,
 		RandomValueGeneratorConfig: &valuegeneration.RandomValueGeneratorConfig{
-			GenerateRandomArrayMinSize:  0,
-			GenerateRandomArrayMaxSize:  100,
-			GenerateRandomBytesMinSize:  0,
-			GenerateRandomBytesMaxSize:  100,
-			GenerateRandomStringMinSize: 0,
-			GenerateRandomStringMaxSize: 100,
+			GenerateRandomArrayMinSize:        0,
+			GenerateRandomArrayMaxSize:        100,
+			GenerateRandomBytesMinSize:        0,
+			GenerateRandomBytesMaxSize:        100,
+			GenerateRandomStringMinSize:       0,
+			GenerateRandomStringMaxSize:       100,
+			GenerateBoundaryLengthProbability: fuzzer.config.Fuzzing.ValueGeneration.GenerateBoundaryLengthBias,
+			GenerateScaledIntegerProbability:  fuzzer.config.Fuzzing.ValueGeneration.GenerateScaledIntegerBias,
+			ScaleConstants:                    scaleConstants,
+			TupleFixups:                       fuzzer.Hooks.TupleFixups,
 		},
 	}
+	if fuzzer.generationStats != nil {
+		valueGenConfig.RandomValueGeneratorConfig.GenerationStats = valuegeneration.NewAbiValueGenerationStats()
+	}
+	var err error
+	valueGenConfig.RandomValueGeneratorConfig.StrategyChooser, err = newValueGenerationStrategyChooser(fuzzer.config.Fuzzing.ValueGeneration, valueGenConfig.RandomValueGeneratorConfig, valueSet, randomProvider)
+	if err != nil {
+		return nil, err
+	}
 	valueGenerator := valuegeneration.NewMutatingValueGenerator(valueGenConfig, valueSet, randomProvider)
 
 	// Create a sequence generator config which uses the created value generator.
 	sequenceGenConfig := &CallSequenceGeneratorConfig{
-		NewSequenceProbability:                   0.3,
-		RandomUnmodifiedCorpusHeadWeight:         800,
-		RandomUnmodifiedCorpusTailWeight:         100,
-		RandomUnmodifiedSpliceAtRandomWeight:     200,
-		RandomUnmodifiedInterleaveAtRandomWeight: 100,
-		RandomMutatedCorpusHeadWeight:            80,
-		RandomMutatedCorpusTailWeight:            10,
-		RandomMutatedSpliceAtRandomWeight:        20,
-		RandomMutatedInterleaveAtRandomWeight:    10,
-		ValueGenerator:                           valueGenerator,
+		NewSequenceProbability:                      0.3,
+		RandomUnmodifiedCorpusHeadWeight:            800,
+		RandomUnmodifiedCorpusTailWeight:            100,
+		RandomUnmodifiedSpliceAtRandomWeight:        200,
+		RandomUnmodifiedInterleaveAtRandomWeight:    100,
+		RandomMutatedCorpusHeadWeight:               80,
+		RandomMutatedCorpusTailWeight:               10,
+		RandomMutatedSpliceAtRandomWeight:           20,
+		RandomMutatedInterleaveAtRandomWeight:       10,
+		RandomMutatedSingleArgumentCorpusHeadWeight: 20,
+		RandomMutatedSequenceStructureWeight:        40,
+		ValueGenerator:                              valueGenerator,
 	}
 	return sequenceGenConfig, nil
 }
 
+// newValueGenerationStrategyChooser creates a chooser which selects a strategy to generate each leaf value with, using
+// the strategy weights of the provided value generation config. Strategies are selected with the provided random
+// provider, so selection is reproducible for a given worker seed.
+// Returns the chooser, nil if no strategy is weighted, or an error if one occurs.
+func newValueGenerationStrategyChooser(valueGenerationConfig config.ValueGenerationConfig, randomConfig *valuegeneration.RandomValueGeneratorConfig, valueSet *valuegeneration.ValueSet, randomProvider *rand.Rand) (*randomutils.WeightedRandomChooser[valuegeneration.ValueGenerationStrategy], error) {
+	// Decode our dictionary entries.
+	dictionary := make([][]byte, len(valueGenerationConfig.Dictionary))
+	for i, entry := range valueGenerationConfig.Dictionary {
+		if strings.HasPrefix(entry, "0x") {
+			b, err := hex.DecodeString(entry[2:])
+			if err != nil {
+				return nil, fmt.Errorf("could not decode dictionary entry '%s': %v", entry, err)
+			}
+			dictionary[i] = b
+		} else {
+			dictionary[i] = []byte(entry)
+		}
+	}
+
+	// Add each strategy with a non-zero weight.
+	weights := valueGenerationConfig.StrategyWeights
+	strategies := []struct {
+		strategy valuegeneration.ValueGenerationStrategy
+		weight   uint64
+	}{
+		{valuegeneration.NewRandomValueGenerationStrategy(randomConfig), weights.Random},
+		{&valuegeneration.ValueSetValueGenerationStrategy{ValueSet: valueSet}, weights.ValueSet},
+		{&valuegeneration.BoundaryValueGenerationStrategy{}, weights.Boundary},
+		{&valuegeneration.DictionaryValueGenerationStrategy{Entries: dictionary}, weights.Dictionary},
+	}
+	chooser := randomutils.NewWeightedRandomChooserWithRand[valuegeneration.ValueGenerationStrategy](randomProvider, &sync.Mutex{})
+	for _, s := range strategies {
+		if s.weight > 0 {
+			chooser.AddChoices(randomutils.NewWeightedRandomChoice(s.strategy, new(big.Int).SetUint64(s.weight)))
+		}
+	}
+
+	// If no strategy is weighted, leaf values are generated by the value generator itself.
+	if chooser.ChoiceCount() == 0 {
+		return nil, nil
+	}
+	return chooser, nil
+}
+
 // spawnWorkersLoop is a method which spawns a config-defined amount of FuzzerWorker to carry out the fuzzing campaign.
 // This function exits when Fuzzer.ctx is cancelled.
 func (f *Fuzzer) spawnWorkersLoop(baseTestChain *chain.TestChain) error {
@@ -429,6 +877,8 @@ func (f *Fuzzer) spawnWorkersLoop(baseTestChain *chain.TestChain) error {
 
 	// Workers are "reset" when they hit some config-defined limit. They are destroyed and recreated at the same index.
 	// For now, we create our available index queue before initializing some providers and entering our main loop.
+	// Each worker slot's random provider is seeded from the master seed and its index, so a campaign is reproducible
+	// for a given seed and worker count, regardless of worker scheduling.
 	type availableWorkerSlot struct {
 		index          int
 		randomProvider *rand.Rand
@@ -438,7 +888,7 @@ func (f *Fuzzer) spawnWorkersLoop(baseTestChain *chain.TestChain) error {
 	for i := 0; i < len(availableWorkerSlotQueue); i++ {
 		availableWorkerSlotQueue[i] = availableWorkerSlot{
 			index:          i,
-			randomProvider: randomutils.ForkRandomProvider(f.randomProvider),
+			randomProvider: rand.New(rand.NewSource(randomutils.DeriveSeed(f.randomSeed, i))),
 		}
 	}
 
@@ -490,6 +940,13 @@ func (f *Fuzzer) spawnWorkersLoop(baseTestChain *chain.TestChain) error {
 				}
 			}
 
+			// Merge the value generation statistics collected by the worker into our own, if they are collected.
+			if f.generationStats != nil && worker != nil {
+				if statsProvider, ok := worker.ValueGenerator().(valuegeneration.AbiValueGenerationStatsProvider); ok && statsProvider.GenerationStats() != nil {
+					f.generationStats.Merge(statsProvider.GenerationStats())
+				}
+			}
+
 			// Free our worker id before unblocking our channel, as a free one will be expected.
 			availableWorkerIndexedLock.Lock()
 			availableWorkerSlotQueue = append(availableWorkerSlotQueue, workerSlotInfo)
@@ -529,6 +986,51 @@ func (f *Fuzzer) spawnWorkersLoop(baseTestChain *chain.TestChain) error {
 	return err
 }
 
+// canonicalizeMethodReferences resolves the methods referenced by the method keys of the fuzzer's hooks, and by the
+// view methods to include in assertion testing, against the fuzzer's contract definitions. This allows methods to be
+// referenced with inconsistent formatting (e.g. "transfer(address, uint)" or "transfer").
+// Returns an error if a reference could not be resolved to a method.
+func (f *Fuzzer) canonicalizeMethodReferences() error {
+	err := f.Hooks.canonicalizeMethodKeys(f.contractDefinitions)
+	if err != nil {
+		return err
+	}
+	assertionTesting := &f.config.Fuzzing.Testing.AssertionTesting
+	assertionTesting.IncludeViewMethods, err = canonicalizeIncludeViewMethods(f.contractDefinitions, assertionTesting.IncludeViewMethods)
+	return err
+}
+
+// setupBaseTestChain validates the configuration used to set up a test chain, resolves the methods referenced by the
+// fuzzer's hooks and configuration, then creates a test chain and sets it up with the deployment/setup strategy defined
+// by the fuzzer. This is shared by every operation which replays call sequences against the base test chain used when
+// fuzzing.
+// Returns the base test chain, or an error if one occurs.
+func (f *Fuzzer) setupBaseTestChain() (*chain.TestChain, error) {
+	// Verify our constructor arguments can be decoded for each contract to deploy, before any setup is performed.
+	err := f.validateConstructorArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the methods referenced by our hooks and configuration, so they match regardless of how they were
+	// formatted.
+	err = f.canonicalizeMethodReferences()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create our test chain and set it up with our deployment/setup strategy defined by the fuzzer.
+	baseTestChain, err := f.createTestChain()
+	if err != nil {
+		return nil, err
+	}
+	err = f.Hooks.ChainSetupFunc(f, baseTestChain)
+	if err != nil {
+		return nil, err
+	}
+	return baseTestChain, nil
+}
+
 // Start begins a fuzzing operation on the provided project configuration. This operation will not return until an error
 // is encountered or the fuzzing operation has completed. Its execution can be cancelled using the Stop method.
 // Returns an error if one is encountered.
@@ -536,8 +1038,15 @@ func (f *Fuzzer) Start() error {
 	// Define our variable to catch errors
 	var err error
 
-	// While we're fuzzing, we'll want to have an initialized random provider.
-	f.randomProvider = rand.New(rand.NewSource(time.Now().UnixNano()))
+	// While we're fuzzing, we'll want to have an initialized random provider. If no seed was configured, we choose
+	// one at random. Either way, we print it so the campaign can be reproduced.
+	randomSeed := f.config.Fuzzing.RandomSeed
+	if randomSeed == 0 {
+		randomSeed = time.Now().UnixNano()
+	}
+	fmt.Printf("Using random seed %d\n", randomSeed)
+	f.randomSeed = randomSeed
+	f.randomProvider = rand.New(rand.NewSource(randomSeed))
 
 	// Create our running context (allows us to cancel across threads)
 	f.ctx, f.ctxCancelFunc = context.WithCancel(context.Background())
@@ -548,12 +1057,21 @@ func (f *Fuzzer) Start() error {
 		f.ctx, f.ctxCancelFunc = context.WithTimeout(f.ctx, time.Duration(f.config.Fuzzing.Timeout)*time.Second)
 	}
 
-	// Set up the corpus
-	f.corpus, err = corpus.NewCorpus(f.config.Fuzzing.CorpusDirectory)
+	// Set up the corpus. If we are ignoring the corpus, we do not load its call sequences, so they are not replayed.
+	f.corpus, err = corpus.NewCorpus(f.config.Fuzzing.CorpusDirectory, f.config.Fuzzing.Workers, !f.config.Fuzzing.CleanRoom, f.config.Fuzzing.MaxDecodedLength, f.Hooks.JSONArgumentCodecs)
 	if err != nil {
 		return err
 	}
 
+	// Seed our base value set with argument values from failing sequences of prior campaigns.
+	err = f.loadFailingValues()
+	if err != nil {
+		return err
+	}
+
+	// Set up our call throttle, if we are limiting the rate of calls.
+	f.callThrottle = newCallThrottle(f.config.Fuzzing.MaxCallsPerSecond)
+
 	// Initialize our metrics and valueGenerator.
 	f.metrics = newFuzzerMetrics(f.config.Fuzzing.Workers)
 
@@ -563,20 +1081,14 @@ func (f *Fuzzer) Start() error {
 	f.testCasesFinished = make(map[string]TestCase)
 	f.testCasesLock.Unlock()
 
-	// Create our test chain
-	baseTestChain, err := f.createTestChain()
-	if err != nil {
-		return err
-	}
-
-	// Set it up with our deployment/setup strategy defined by the fuzzer.
-	err = f.Hooks.ChainSetupFunc(f, baseTestChain)
+	// Create our test chain and set it up with our deployment/setup strategy defined by the fuzzer.
+	baseTestChain, err := f.setupBaseTestChain()
 	if err != nil {
 		return err
 	}
 
 	// Initialize our coverage maps by measuring the coverage we get from the corpus.
-	err = f.corpus.Initialize(baseTestChain, f.contractDefinitions)
+	err = f.corpus.Initialize(baseTestChain, f.contractDefinitions, rand.New(rand.NewSource(randomutils.DeriveSeed(f.randomSeed, corpusRandomSeedIndex))))
 	if err != nil {
 		return err
 	}
@@ -584,6 +1096,16 @@ func (f *Fuzzer) Start() error {
 	// Start our printing loop now that we're about to begin fuzzing.
 	go f.printMetricsLoop()
 
+	// Start sampling our memory usage, if workers have a memory budget.
+	if f.config.Fuzzing.WorkerMemoryLimit > 0 {
+		go f.memoryUsageSampleLoop()
+	}
+
+	// Start our periodic corpus flushing loop, if enabled.
+	if f.config.Fuzzing.CoverageEnabled && f.config.Fuzzing.CorpusDirectory != "" && f.config.Fuzzing.CorpusFlushInterval > 0 {
+		go f.corpusFlushLoop()
+	}
+
 	// Publish a fuzzer starting event.
 	err = f.Events.FuzzerStarting.Publish(FuzzerStartingEvent{Fuzzer: f})
 	if err != nil {
@@ -613,17 +1135,93 @@ func (f *Fuzzer) Start() error {
 	// Print our results on exit.
 	f.printExitingResults()
 
-	// Finally, generate our coverage report if we have set a valid corpus directory.
-	if err == nil && f.config.Fuzzing.CorpusDirectory != "" {
-		coverageReportPath := filepath.Join(f.config.Fuzzing.CorpusDirectory, "coverage_report.html")
-		err = coverage.GenerateReport(f.compilations, f.corpus.CoverageMaps(), coverageReportPath)
-		fmt.Printf("coverage report saved to file: %v\n", coverageReportPath)
+	// Finally, generate our coverage report and check our coverage baseline, if configured to.
+	if err == nil {
+		err = f.checkCoverage()
 	}
 
 	// Return any encountered error.
 	return err
 }
 
+// checkCoverage performs source analysis for the coverage achieved by the campaign once, then uses it to generate a
+// coverage report if a corpus directory is set, and to save or compare against a coverage baseline if configured to.
+// Returns an error if one occurred.
+func (f *Fuzzer) checkCoverage() error {
+	// If there is no report to generate and no baseline to save or compare against, there is nothing to do.
+	saveOrCompareBaseline := f.config.Fuzzing.CoverageEnabled && (f.config.Fuzzing.SaveCoverageBaseline || f.config.Fuzzing.CoverageBaselineFile != "")
+	if f.config.Fuzzing.CorpusDirectory == "" && !saveOrCompareBaseline {
+		return nil
+	}
+
+	// Perform source analysis.
+	sourceAnalysis, err := coverage.AnalyzeSourceCoverage(f.compilations, f.corpus.CoverageMaps())
+	if err != nil {
+		return err
+	}
+
+	// Generate our coverage report if we have set a valid corpus directory.
+	if f.config.Fuzzing.CorpusDirectory != "" {
+		coverageReportPath := filepath.Join(f.config.Fuzzing.CorpusDirectory, "coverage_report.html")
+		err = coverage.GenerateReport(sourceAnalysis, coverageReportPath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("coverage report saved to file: %v\n", coverageReportPath)
+	}
+
+	// Save our coverage baseline and compare it against any previous baseline.
+	if saveOrCompareBaseline {
+		return f.checkCoverageBaseline(sourceAnalysis)
+	}
+	return nil
+}
+
+// checkCoverageBaseline creates a coverage baseline from the provided source analysis of the coverage achieved by the
+// campaign. If a previous coverage baseline was configured, coverage is compared against it, reporting any source
+// files whose coverage regressed beyond the configured threshold. If configured to, the baseline is then saved to the
+// corpus directory.
+// Returns an error if coverage regressed and the campaign is configured to fail on regressions, or if another error
+// occurred.
+func (f *Fuzzer) checkCoverageBaseline(sourceAnalysis *coverage.SourceAnalysis) error {
+	// Create a baseline from our coverage.
+	currentBaseline := coverage.NewCoverageBaseline(sourceAnalysis)
+
+	// Compare coverage against the previous baseline, prior to overwriting it.
+	var regressions []coverage.CoverageRegression
+	if f.config.Fuzzing.CoverageBaselineFile != "" {
+		previousBaseline, err := coverage.ReadCoverageBaselineFromFile(f.config.Fuzzing.CoverageBaselineFile)
+		if err != nil {
+			return fmt.Errorf("could not read coverage baseline: %v", err)
+		}
+		regressions = currentBaseline.Compare(previousBaseline, f.config.Fuzzing.CoverageRegressionThreshold)
+		fmt.Printf("coverage: %.2f%% of lines covered (baseline: %.2f%%)\n", currentBaseline.CoveragePercent(), previousBaseline.CoveragePercent())
+		for _, regression := range regressions {
+			fmt.Printf("Warning: coverage of %v regressed from %.2f%% to %.2f%% (lines no longer covered: %v)\n", regression.Path, regression.BaselinePercent, regression.CurrentPercent, regression.LostLines)
+		}
+	}
+
+	// Save our baseline to the corpus directory, if configured to.
+	if f.config.Fuzzing.SaveCoverageBaseline && f.config.Fuzzing.CorpusDirectory != "" {
+		err := utils.MakeDirectory(f.config.Fuzzing.CorpusDirectory)
+		if err != nil {
+			return err
+		}
+		coverageBaselinePath := filepath.Join(f.config.Fuzzing.CorpusDirectory, "coverage_baseline.json")
+		err = currentBaseline.WriteToFile(coverageBaselinePath)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("coverage baseline saved to file: %v\n", coverageBaselinePath)
+	}
+
+	// Fail if coverage regressed, if configured to.
+	if len(regressions) > 0 && f.config.Fuzzing.FailOnCoverageRegression {
+		return fmt.Errorf("coverage regressed below the baseline for %d source file(s)", len(regressions))
+	}
+	return nil
+}
+
 // Stop stops a running operation invoked by the Start method. This method may return before complete operation teardown
 // occurs.
 func (f *Fuzzer) Stop() {
@@ -633,6 +1231,97 @@ func (f *Fuzzer) Stop() {
 	}
 }
 
+// MinimizeCorpus reduces the corpus in the configured corpus directory to a minimal subset of call sequences which
+// preserves the coverage achieved by the whole corpus. Call sequences which do not contribute coverage are deleted
+// from the corpus directory.
+// Returns the count of call sequences removed, or an error if one is encountered.
+func (f *Fuzzer) MinimizeCorpus() (int, error) {
+	// Minimizing the corpus requires a corpus directory to read from and write to.
+	if f.config.Fuzzing.CorpusDirectory == "" {
+		return 0, fmt.Errorf("a corpus directory must be specified to minimize the corpus")
+	}
+
+	// Load the corpus from disk
+	var err error
+	f.corpus, err = corpus.NewCorpus(f.config.Fuzzing.CorpusDirectory, f.config.Fuzzing.Workers, true, f.config.Fuzzing.MaxDecodedLength, f.Hooks.JSONArgumentCodecs)
+	if err != nil {
+		return 0, err
+	}
+
+	// Create our test chain and set it up as the fuzzer would when starting.
+	baseTestChain, err := f.setupBaseTestChain()
+	if err != nil {
+		return 0, err
+	}
+
+	// Replay the corpus, removing any call sequences which do not contribute coverage.
+	return f.corpus.Minimize(baseTestChain, f.contractDefinitions)
+}
+
+// ExportCallSequence replays a previously deserialized call sequence on a newly set up test chain to resolve the
+// contracts and methods it targets, then renders it as a standalone Solidity test contract with the provided name.
+// Returns the Solidity source code of the test, or an error if one is encountered.
+func (f *Fuzzer) ExportCallSequence(sequence calls.CallSequence, testContractName string) (string, error) {
+	// Create our test chain, tracking any contract deployments so we can resolve the contracts targeted by calls.
+	baseTestChain, err := f.createTestChain()
+	if err != nil {
+		return "", err
+	}
+	deployedContracts := make(map[common.Address]*fuzzerTypes.Contract, 0)
+	deployments := make([]calls.ExportedContractDeployment, 0)
+	setupComplete := false
+	baseTestChain.Events.ContractDeploymentAddedEventEmitter.Subscribe(func(event chain.ContractDeploymentsAddedEvent) error {
+		matchedContract := f.contractDefinitions.MatchBytecode(event.Contract.InitBytecode, event.Contract.RuntimeBytecode)
+		if matchedContract == nil {
+			return nil
+		}
+		deployedContracts[event.Contract.Address] = matchedContract
+
+		// Contracts deployed during setup must be deployed by the exported test's setUp function, along with the
+		// constructor arguments appended to their init bytecode.
+		if !setupComplete {
+			deployment := calls.ExportedContractDeployment{Address: event.Contract.Address, Contract: matchedContract}
+			if compiledInitBytecode := matchedContract.CompiledContract().InitBytecode; len(event.Contract.InitBytecode) > len(compiledInitBytecode) {
+				deployment.ConstructorArgs = event.Contract.InitBytecode[len(compiledInitBytecode):]
+			}
+			deployments = append(deployments, deployment)
+		}
+		return nil
+	})
+
+	// Set it up with our deployment/setup strategy defined by the fuzzer.
+	err = f.Hooks.ChainSetupFunc(f, baseTestChain)
+	if err != nil {
+		return "", err
+	}
+	setupComplete = true
+
+	// Record the balance of each sender after setup, so the exported test can fund them identically.
+	senderBalances := make(map[common.Address]*big.Int, len(f.senders))
+	for _, sender := range f.senders {
+		senderBalances[sender] = baseTestChain.State().GetBalance(sender)
+	}
+
+	// Replay the call sequence, resolving each call's contract and ABI values prior to executing it.
+	fetchElementFunc := func(currentIndex int) (*calls.CallSequenceElement, error) {
+		if currentIndex >= len(sequence) {
+			return nil, nil
+		}
+		err := sequence[currentIndex].ResolveRuntimeReferences(deployedContracts, f.config.Fuzzing.MaxDecodedLength, f.Hooks.JSONArgumentCodecs)
+		if err != nil {
+			return nil, err
+		}
+		return sequence[currentIndex], nil
+	}
+	_, err = calls.ExecuteCallSequenceIteratively(baseTestChain, fetchElementFunc, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// Render the resolved and executed call sequence as a test.
+	return sequence.ExportSolidityTest(testContractName, deployments, senderBalances)
+}
+
 // printMetricsLoop prints metrics to the console in a loop until ctx signals a stopped operation.
 func (f *Fuzzer) printMetricsLoop() {
 	// Define our start time
@@ -643,6 +1332,12 @@ func (f *Fuzzer) printMetricsLoop() {
 	lastSequencesTested := big.NewInt(0)
 	lastWorkerStartupCount := big.NewInt(0)
 
+	// Define variables to track when coverage last grew, so we can detect stalled campaigns.
+	lastCoverageEntryCount := f.corpus.CallSequenceEntryCount(true, true, false)
+	lastCoverageGrowthTime := time.Now()
+	lastCoverageGrowthCallsTested := big.NewInt(0)
+	coverageStallReported := false
+
 	lastPrintedTime := time.Time{}
 	for !utils.CheckContextDone(f.ctx) {
 		// Obtain our metrics
@@ -678,11 +1373,101 @@ func (f *Fuzzer) printMetricsLoop() {
 			break
 		}
 
+		// If coverage grew, reset our stall tracking. Otherwise, check if it has stalled for too long.
+		coverageEntryCount := f.corpus.CallSequenceEntryCount(true, true, false)
+		if coverageEntryCount != lastCoverageEntryCount {
+			lastCoverageEntryCount = coverageEntryCount
+			lastCoverageGrowthTime = time.Now()
+			lastCoverageGrowthCallsTested = callsTested
+			coverageStallReported = false
+		} else if !coverageStallReported && f.isCoverageStalled(time.Since(lastCoverageGrowthTime), new(big.Int).Sub(callsTested, lastCoverageGrowthCallsTested)) {
+			coverageStallReported = true
+			f.printCoverageStallSummary(time.Since(lastCoverageGrowthTime))
+			if f.config.Fuzzing.StopOnCoverageStall {
+				fmt.Printf("coverage stall detected, halting now ...\n")
+				f.Stop()
+				break
+			}
+		}
+
 		// Sleep some time between print iterations
 		time.Sleep(time.Second * 3)
 	}
 }
 
+// memoryUsageSampleInterval describes the interval at which memoryUsageSampleLoop samples the process' heap usage.
+// Reading memory statistics stops the world, so it is sampled periodically rather than by each worker.
+const memoryUsageSampleInterval = time.Second
+
+// memoryUsageSampleLoop samples the heap memory allocated by the process every memoryUsageSampleInterval, so workers can
+// compare it against their memory budget, until ctx signals a stopped operation.
+func (f *Fuzzer) memoryUsageSampleLoop() {
+	ticker := time.NewTicker(memoryUsageSampleInterval)
+	defer ticker.Stop()
+	for {
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		atomic.StoreUint64(&f.heapAlloc, memStats.HeapAlloc)
+
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// corpusFlushLoop flushes the corpus to disk every CorpusFlushInterval seconds until ctx signals a stopped operation.
+// The corpus synchronizes flushes with call sequences being added by workers, so this is safe to run while fuzzing.
+func (f *Fuzzer) corpusFlushLoop() {
+	ticker := time.NewTicker(time.Duration(f.config.Fuzzing.CorpusFlushInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+			err := f.corpus.Flush()
+			if err != nil {
+				fmt.Printf("Warning: failed to flush the corpus to disk: %v\n", err)
+			}
+		}
+	}
+}
+
+// isCoverageStalled checks whether the campaign is considered stalled, given the time elapsed and calls tested since
+// coverage last grew.
+func (f *Fuzzer) isCoverageStalled(timeSinceGrowth time.Duration, callsSinceGrowth *big.Int) bool {
+	// Stall detection requires coverage to be tracked.
+	if !f.config.Fuzzing.CoverageEnabled {
+		return false
+	}
+	stallTimeout := f.config.Fuzzing.CoverageStallTimeout
+	if stallTimeout > 0 && timeSinceGrowth >= time.Duration(stallTimeout)*time.Second {
+		return true
+	}
+	stallCallLimit := f.config.Fuzzing.CoverageStallCallLimit
+	return stallCallLimit > 0 && (!callsSinceGrowth.IsUint64() || callsSinceGrowth.Uint64() >= stallCallLimit)
+}
+
+// printCoverageStallSummary prints a warning that the campaign has stalled, alongside a summary of the reasons calls to
+// each method failed, to help diagnose misconfiguration.
+func (f *Fuzzer) printCoverageStallSummary(timeSinceGrowth time.Duration) {
+	fmt.Printf("Warning: no new coverage was achieved in %s, the campaign may be misconfigured. Call results follow below ...\n", timeSinceGrowth.Round(time.Second))
+	for _, stats := range f.methodExecutions.MethodExecutionStats() {
+		fmt.Printf("%s.%s (executed: %d, succeeded: %d)\n", stats.ContractName, stats.MethodSig, stats.ExecutedCount, stats.SucceededCount)
+
+		// Print the failure reasons, most frequent first.
+		reasons := maps.Keys(stats.FailureReasons)
+		sort.Slice(reasons, func(i, j int) bool {
+			return stats.FailureReasons[reasons[i]] > stats.FailureReasons[reasons[j]]
+		})
+		for _, reason := range reasons {
+			fmt.Printf("\t%d failure(s): %s\n", stats.FailureReasons[reason], reason)
+		}
+	}
+}
+
 // printExitingResults prints the TestCase results prior to the fuzzer exiting.
 func (f *Fuzzer) printExitingResults() {
 	// Define the order our test cases should be sorted by when considering status.
@@ -733,7 +1518,40 @@ func (f *Fuzzer) printExitingResults() {
 		}
 	}
 
-	// Print our final tally of test statuses.
+	// Print our final tally of test statuses, along with a summary of the campaign.
 	fmt.Printf("\n")
 	fmt.Printf("%d test(s) passed, %d test(s) failed\n", testCountPassed, testCountFailed)
+	if f.metrics != nil {
+		fmt.Printf(
+			"%d call(s) in %d sequence(s) tested, cov: %d\n",
+			f.metrics.CallsTested(),
+			f.metrics.SequencesTested(),
+			f.corpus.ActiveMutableSequenceCount(),
+		)
+	}
+
+	// Print any methods which failed the zero value smoke test, as they may indicate misconfiguration.
+	if f.config.Fuzzing.ZeroValueSmokeTest && f.zeroValueSmokeTestFailures != nil {
+		fmt.Printf("\n")
+		fmt.Printf("%d method(s) failed when called with zero-valued arguments:\n", len(f.zeroValueSmokeTestFailures))
+		for _, failedMethod := range f.zeroValueSmokeTestFailures {
+			fmt.Printf("%s\n", failedMethod)
+		}
+	}
+
+	// Print any methods which were never successfully executed, as they may indicate misconfiguration.
+	unreachableMethods := f.UnreachableMethods()
+	if f.config.Fuzzing.ReportUnreachableMethods && len(unreachableMethods) > 0 {
+		fmt.Printf("\n")
+		fmt.Printf("%d method(s) were never successfully executed:\n", len(unreachableMethods))
+		for _, stats := range unreachableMethods {
+			fmt.Printf("%s.%s (selected: %d, executed: %d)\n", stats.ContractName, stats.MethodSig, stats.SelectedCount, stats.ExecutedCount)
+		}
+	}
+
+	// Print our value generation statistics, if they were collected.
+	if f.generationStats != nil {
+		fmt.Printf("\n")
+		fmt.Printf("%s", f.generationStats)
+	}
 }
@@ -0,0 +1,58 @@
+package fuzzing
+
+import (
+	"testing"
+
+	"github.com/crytic/medusa/fuzzing/config"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConstructorArgsExistingAddress runs tests to ensure contracts which already exist at an address (e.g. ABI-only
+// targets) are not expected to be provided constructor arguments, even if their ABI declares a constructor, and that
+// contracts deployed after them may reference them, as resolved by both validation and chain setup.
+func TestConstructorArgsExistingAddress(t *testing.T) {
+	// Create an ABI-only contract with a constructor which exists at an address, and a contract referencing it.
+	existingAddress := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	existingContract := newTestTargetMethodsContract(t, "ExistingContract", `[
+		{"type":"constructor","inputs":[{"name":"owner","type":"address"}],"stateMutability":"nonpayable"}
+	]`)
+	existingContract.CompiledContract().ExistingAddress = &existingAddress
+	existingContract.CompiledContract().RuntimeBytecode = []byte{0x00}
+	dependentContract := newTestTargetMethodsContract(t, "DependentContract", `[
+		{"type":"constructor","inputs":[{"name":"target","type":"address"}],"stateMutability":"nonpayable"}
+	]`)
+
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	projectConfig.Fuzzing.DeploymentOrder = []string{"ExistingContract"}
+	fuzzer, err := NewFuzzer(*projectConfig)
+	assert.NoError(t, err)
+	fuzzer.contractDefinitions = fuzzerTypes.Contracts{existingContract, dependentContract}
+
+	// The existing contract is validated and set up without constructor arguments.
+	assert.NoError(t, fuzzer.validateConstructorArgs())
+	_, err = fuzzer.setupBaseTestChain()
+	assert.NoError(t, err)
+
+	// Contracts deployed after it may reference it.
+	fuzzer.config.Fuzzing.DeploymentOrder = []string{"ExistingContract", "DependentContract"}
+	fuzzer.config.Fuzzing.ConstructorArgs = map[string]map[string]any{"DependentContract": {"target": "DeployedContract:ExistingContract"}}
+	assert.NoError(t, fuzzer.validateConstructorArgs())
+	_, args, err := fuzzer.resolveContractDeployment("DependentContract", map[string]common.Address{"ExistingContract": existingAddress})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []any{existingAddress}, args)
+
+	// Contracts which are not compiled cannot be deployed.
+	fuzzer.config.Fuzzing.DeploymentOrder = append(fuzzer.config.Fuzzing.DeploymentOrder, "MissingContract")
+	assert.ErrorContains(t, fuzzer.validateConstructorArgs(), "MissingContract")
+	fuzzer.config.Fuzzing.DeploymentOrder = []string{"ExistingContract", "DependentContract"}
+
+	// Constructor arguments provided for the existing contract are rejected, as it is never deployed.
+	fuzzer.config.Fuzzing.ConstructorArgs["ExistingContract"] = map[string]any{"owner": "0x0000000000000000000000000000000000010000"}
+	assert.ErrorContains(t, fuzzer.validateConstructorArgs(), "already exists at address")
+	testChain, err := fuzzer.createTestChain()
+	assert.NoError(t, err)
+	assert.ErrorContains(t, chainSetupFromCompilations(fuzzer, testChain), "already exists at address")
+}
@@ -0,0 +1,74 @@
+package fuzzing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crytic/medusa/fuzzing/config"
+	"github.com/crytic/medusa/fuzzing/coverage"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestSourceAnalysis creates a SourceAnalysis for a single source file with the provided amount of active lines,
+// of which the provided amount are covered.
+func newTestSourceAnalysis(activeLineCount int, coveredLineCount int) *coverage.SourceAnalysis {
+	file := &coverage.SourceFileAnalysis{
+		Path:  "contracts/TestContract.sol",
+		Lines: make([]*coverage.SourceLineAnalysis, activeLineCount),
+	}
+	for i := range file.Lines {
+		file.Lines[i] = &coverage.SourceLineAnalysis{IsActive: true, IsCovered: i < coveredLineCount}
+	}
+	return &coverage.SourceAnalysis{Files: map[string]*coverage.SourceFileAnalysis{file.Path: file}}
+}
+
+// TestCheckCoverageBaseline runs tests to ensure a coverage baseline is only saved when configured to, and that the
+// campaign only fails when coverage regressed beyond the threshold and it is configured to fail on regressions.
+func TestCheckCoverageBaseline(t *testing.T) {
+	// Save a baseline with 8 of 10 lines covered.
+	corpusDirectory := t.TempDir()
+	baselinePath := filepath.Join(corpusDirectory, "coverage_baseline.json")
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	projectConfig.Fuzzing.CorpusDirectory = corpusDirectory
+	fuzzer, err := NewFuzzer(*projectConfig)
+	assert.NoError(t, err)
+	assert.NoError(t, fuzzer.checkCoverageBaseline(newTestSourceAnalysis(10, 8)))
+	assert.NoFileExists(t, baselinePath)
+	fuzzer.config.Fuzzing.SaveCoverageBaseline = true
+	assert.NoError(t, fuzzer.checkCoverageBaseline(newTestSourceAnalysis(10, 8)))
+	assert.FileExists(t, baselinePath)
+	b, err := os.ReadFile(baselinePath)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		coveredLineCount         int
+		threshold                float64
+		failOnCoverageRegression bool
+		expectError              bool
+	}{
+		{coveredLineCount: 8, threshold: 0, failOnCoverageRegression: true, expectError: false},
+		{coveredLineCount: 6, threshold: 0, failOnCoverageRegression: false, expectError: false},
+		{coveredLineCount: 6, threshold: 0, failOnCoverageRegression: true, expectError: true},
+		{coveredLineCount: 6, threshold: 20, failOnCoverageRegression: true, expectError: false},
+		{coveredLineCount: 5, threshold: 20, failOnCoverageRegression: true, expectError: true},
+	}
+	for _, test := range tests {
+		// Compare coverage against the saved baseline without overwriting it.
+		projectConfig.Fuzzing.CoverageBaselineFile = baselinePath
+		projectConfig.Fuzzing.CoverageRegressionThreshold = test.threshold
+		projectConfig.Fuzzing.FailOnCoverageRegression = test.failOnCoverageRegression
+		fuzzer, err = NewFuzzer(*projectConfig)
+		assert.NoError(t, err)
+		err = fuzzer.checkCoverageBaseline(newTestSourceAnalysis(10, test.coveredLineCount))
+		if test.expectError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		currentBytes, err := os.ReadFile(baselinePath)
+		assert.NoError(t, err)
+		assert.EqualValues(t, b, currentBytes)
+	}
+}
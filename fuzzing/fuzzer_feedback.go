@@ -0,0 +1,99 @@
+package fuzzing
+
+import (
+	"github.com/crytic/medusa/fuzzing/calls"
+)
+
+// FeedbackResult describes whether a FeedbackProvider deemed an executed call sequence interesting, and how it should
+// be stored in the corpus if so.
+type FeedbackResult int
+
+const (
+	// FeedbackResultNone indicates the call sequence was not interesting and should not be added to the corpus.
+	FeedbackResultNone FeedbackResult = iota
+	// FeedbackResultInterestingImmutable indicates the call sequence was interesting and should be added to the
+	// corpus to be replayed, but should not be used as a base for mutations.
+	FeedbackResultInterestingImmutable
+	// FeedbackResultInterestingMutable indicates the call sequence was interesting and should be added to the corpus
+	// to be replayed and used as a base for mutations.
+	FeedbackResultInterestingMutable
+)
+
+// FeedbackProvider describes a source of feedback which a FuzzerWorker consults after every call it executes, to
+// determine whether the call sequence executed so far is interesting and should be added to the corpus. Providers
+// are consulted in order, and the most interesting result returned by any of them determines how the call sequence
+// is stored.
+// Note: Providers are called by all FuzzerWorker instances concurrently, and must be thread safe.
+type FeedbackProvider interface {
+	// CheckCallSequence checks whether the most recent call executed in the provided call sequence produced new
+	// feedback, updating any internal state tracked by the provider.
+	// Returns a FeedbackResult describing whether the call sequence is interesting, or an error if one occurs.
+	CheckCallSequence(worker *FuzzerWorker, callSequence calls.CallSequence) (FeedbackResult, error)
+}
+
+// CoverageFeedbackProvider is the default FeedbackProvider, which deems call sequences interesting if they achieve
+// EVM coverage not previously achieved by the corpus.
+type CoverageFeedbackProvider struct{}
+
+// CheckCallSequence checks whether the most recent call executed in the provided call sequence increased the coverage
+// tracked by the corpus, merging its coverage into it. Sequences which increased non-reverted coverage are used for
+// mutations, while those which only increased reverted coverage are not.
+// Returns a FeedbackResult describing whether the call sequence is interesting, or an error if one occurs.
+func (p *CoverageFeedbackProvider) CheckCallSequence(worker *FuzzerWorker, callSequence calls.CallSequence) (FeedbackResult, error) {
+	coverageUpdated, revertedCoverageUpdated, err := worker.fuzzer.corpus.UpdateSequenceCoverage(callSequence)
+	if err != nil {
+		return FeedbackResultNone, err
+	}
+	if coverageUpdated {
+		return FeedbackResultInterestingMutable, nil
+	} else if revertedCoverageUpdated {
+		return FeedbackResultInterestingImmutable, nil
+	}
+	return FeedbackResultNone, nil
+}
+
+// checkFeedbackAndUpdateCorpus consults every FeedbackProvider registered with the Fuzzer to check whether the most
+// recent call executed in the provided call sequence was interesting. If it was, the call sequence is added to the
+// corpus accordingly.
+// Returns an error if one occurs.
+func (fw *FuzzerWorker) checkFeedbackAndUpdateCorpus(callSequence calls.CallSequence) error {
+	// If we have no calls in our sequence, there is nothing to do.
+	if len(callSequence) == 0 {
+		return nil
+	}
+
+	// Consult every provider, tracking the most interesting result. Every provider is consulted, so each may update
+	// its internal state.
+	result := FeedbackResultNone
+	for _, feedbackProvider := range fw.fuzzer.Hooks.FeedbackProviders {
+		providerResult, err := feedbackProvider.CheckCallSequence(fw, callSequence)
+		if err != nil {
+			return err
+		}
+		if providerResult > result {
+			result = providerResult
+		}
+	}
+
+	// If the sequence was not interesting, there is nothing left to do.
+	if result == FeedbackResultNone {
+		return nil
+	}
+
+	// Record the dynamic array lengths of the interesting call's arguments, so generation can be biased towards them.
+	lastCall := callSequence[len(callSequence)-1]
+	if abiValues := lastCall.Call.MsgDataAbiValues; abiValues != nil && abiValues.Method != nil && len(abiValues.Method.Inputs) == len(abiValues.InputValues) {
+		for i, input := range abiValues.Method.Inputs {
+			fw.valueSet.AddArrayLengthsFromAbiValue(&input.Type, abiValues.InputValues[i])
+		}
+	}
+
+	// Record the sequence so it may be extended by the next sequence generated.
+	err := fw.sequenceGenerator.RecordInterestingPrefix(callSequence)
+	if err != nil {
+		return err
+	}
+
+	// Add the sequence to the corpus with weight as 1 + sequences tested (to avoid zero weights).
+	return fw.fuzzer.corpus.AddCallSequence(callSequence, result == FeedbackResultInterestingMutable, fw.getNewCorpusCallSequenceWeight(), true)
+}
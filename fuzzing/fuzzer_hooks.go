@@ -1,10 +1,17 @@
 package fuzzing
 
 import (
+	"fmt"
+	"math/rand"
+	"strings"
+
 	"github.com/crytic/medusa/chain"
+	"github.com/crytic/medusa/compilation/abiutils"
 	"github.com/crytic/medusa/fuzzing/calls"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
-	"math/rand"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"golang.org/x/exp/slices"
 )
 
 // FuzzerHooks defines the hooks that can be used for the Fuzzer on an API level.
@@ -21,6 +28,81 @@ type FuzzerHooks struct {
 	// CallSequenceTestFuncs describes a list of functions to be called upon by a FuzzerWorker after every call
 	// in a call sequence.
 	CallSequenceTestFuncs []CallSequenceTestFunc
+
+	// SignatureDigestFunc describes an optional function used to compute the digest which signature arguments of a
+	// generated call should sign, so valid signatures are generated for them (e.g. for permit or meta-transaction
+	// flows). If nil, signature arguments are generated like any other argument.
+	SignatureDigestFunc SignatureDigestFunc
+
+	// FeedbackProviders describes a list of providers consulted by a FuzzerWorker after every call in a call sequence,
+	// to determine whether the call sequence is interesting and should be added to the corpus. By default, this
+	// contains a CoverageFeedbackProvider and a MethodExecutionFeedbackProvider, as well as a
+	// SenderRestrictionFeedbackProvider used to bias the senders of generated calls if enabled by the project
+	// configuration.
+	FeedbackProviders []FeedbackProvider
+
+	// MethodArgumentsValidators describes functions used to validate the arguments generated for calls to specific
+	// methods, keyed by MethodHookKey. They may adjust the arguments, or reject them so new arguments
+	// are generated. This allows simple domain constraints (e.g. one argument must be less than another) to be
+	// encoded, raising the rate of calls which do not revert.
+	MethodArgumentsValidators map[string]MethodArgumentsValidatorFunc
+
+	// MethodAddressCorrelations describes hints for generating address arguments of calls to specific methods with
+	// a relationship to the call (e.g. equal to its sender, or a deployed contract), keyed by
+	// MethodHookKey. Correlations are applied before arguments are validated.
+	MethodAddressCorrelations map[string][]AddressCorrelation
+
+	// MethodArgumentValueProviders describes callbacks which constrain the values generated for specific arguments of
+	// calls to specific methods using the current chain state (e.g. bounding a transfer amount by the token's total
+	// supply), keyed by MethodHookKey. Providers are applied after address correlations, and before
+	// arguments are validated.
+	MethodArgumentValueProviders map[string][]MethodArgumentValueProvider
+
+	// TupleFixups describes functions which adjust the fields of generated or mutated tuples (structs) so they satisfy
+	// internal consistency requirements (e.g. a start time which does not exceed an end time), keyed by the tuple's
+	// abi.Type.TupleRawName. They are provided to the value generator created by the default
+	// NewCallSequenceGeneratorConfigFunc.
+	TupleFixups map[string]valuegeneration.TupleFixupFunc
+
+	// JSONArgumentCodecs describes custom JSON representations of ABI values by type category (e.g. integers as hex
+	// strings), used to decode configured constructor arguments and corpus call sequences, and to encode the call
+	// sequences generated by the Fuzzer. This allows the corpus to be shared with external tools.
+	JSONArgumentCodecs valuegeneration.JSONArgumentCodecs
+}
+
+// MethodArgumentValueProviderFunc describes a function which provides the value to use for an argument of a call to
+// a method, given the value which was generated for it. The FuzzerWorker's chain may be used to read the current chain
+// state to constrain the value. The returned value must be a valid value of the argument's input type, as verified by
+// valuegeneration.ValidateAbiValue, otherwise generation of the call fails.
+// Returns the value to use for the argument, or an error if one occurs.
+type MethodArgumentValueProviderFunc func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, generatedValue any) (any, error)
+
+// MethodArgumentValueProvider describes a MethodArgumentValueProviderFunc registered for a single argument of a method.
+type MethodArgumentValueProvider struct {
+	// ArgumentIndex describes the index of the method argument whose value is provided.
+	ArgumentIndex int
+
+	// Provide describes the function which provides the value for the argument.
+	Provide MethodArgumentValueProviderFunc
+}
+
+// maxMethodArgumentsGenerationAttempts describes the maximum amount of times arguments are generated for a call,
+// while they are rejected by its MethodArgumentsValidatorFunc.
+const maxMethodArgumentsGenerationAttempts = 10
+
+// MethodArgumentsValidatorFunc describes a function which validates the arguments generated for a call to a method.
+// The function may modify the provided arguments in place, as long as they remain of the method's input types.
+// Returns a boolean indicating whether the arguments were accepted, or an error if one occurs. If the arguments were
+// rejected, new arguments are generated, up to a maximum amount of attempts.
+type MethodArgumentsValidatorFunc func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) (bool, error)
+
+// MethodHookKey obtains the key used to register hooks for a method of a contract in FuzzerHooks (e.g.
+// FuzzerHooks.MethodArgumentsValidators or FuzzerHooks.MethodAddressCorrelations). The key is in the format
+// "ContractName.methodSignature" (e.g. "Token.transfer(address,uint256)"). Keys registered in FuzzerHooks are
+// canonicalized when the Fuzzer starts, so they may also use a non-canonical signature (e.g.
+// "Token.transfer(address, uint)") or an unambiguous method name (e.g. "Token.transfer").
+func MethodHookKey(contractName string, method *abi.Method) string {
+	return contractName + "." + method.Sig
 }
 
 // NewCallSequenceGeneratorConfigFunc defines a method is called to create a new CallSequenceGeneratorConfig, defining
@@ -45,6 +127,12 @@ type ShrinkCallSequenceRequest struct {
 	// VerifierFunction is a method is called upon by a FuzzerWorker to check if a shrunken call sequence satisfies
 	// the needs of an original method.
 	VerifierFunction func(worker *FuzzerWorker, callSequence calls.CallSequence) (bool, error)
+	// PostShrinkVerifierFunction is an optional method called upon by a FuzzerWorker after shrinking has concluded,
+	// once the finalized shrunken call sequence has been re-executed. It should confirm the sequence still triggers
+	// the same failure that prompted the shrink request. To account for non-deterministic failures, a failed
+	// verification is retried up to the configured amount of times. If the shrunken sequence could not be verified,
+	// the original call sequence is reported instead.
+	PostShrinkVerifierFunction func(worker *FuzzerWorker, callSequence calls.CallSequence) (bool, error)
 	// FinishedCallback is a method called upon when the shrink request has concluded. It provides the finalized
 	// shrunken call sequence.
 	FinishedCallback func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence) error
@@ -52,3 +140,56 @@ type ShrinkCallSequenceRequest struct {
 	// the shrinking operation is completed, the sequence will be added to the corpus if it doesn't already exist.
 	RecordResultInCorpus bool
 }
+
+// canonicalizeMethodKeys resolves each key of the provided mapping, in the format described by
+// MethodHookKey, against the provided contract definitions. This allows keys to reference methods with
+// inconsistent formatting (e.g. "Token.transfer(address, uint)" or "Token.transfer"), which would otherwise silently
+// fail to match.
+// Returns a mapping with canonical keys, or an error if a key could not be resolved to a method.
+func canonicalizeMethodKeys[V any](contractDefinitions fuzzerTypes.Contracts, methodMap map[string]V) (map[string]V, error) {
+	canonicalMap := make(map[string]V, len(methodMap))
+	for key, value := range methodMap {
+		// Split the contract name from the method reference.
+		contractName, methodReference, ok := strings.Cut(key, ".")
+		if !ok {
+			return nil, fmt.Errorf("method key '%v' must be in the format 'ContractName.methodSignature'", key)
+		}
+
+		// Resolve the method against the first contract definition with the given name.
+		contractIndex := slices.IndexFunc(contractDefinitions, func(contract *fuzzerTypes.Contract) bool {
+			return contract.Name() == contractName
+		})
+		if contractIndex < 0 {
+			return nil, fmt.Errorf("method key '%v' references unknown contract '%v'", key, contractName)
+		}
+		method, err := abiutils.ResolveMethod(&contractDefinitions[contractIndex].CompiledContract().Abi, methodReference)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve method key '%v': %v", key, err)
+		}
+
+		// Store the value under the canonical key, ensuring two keys do not resolve to the same method.
+		canonicalKey := MethodHookKey(contractName, method)
+		if _, exists := canonicalMap[canonicalKey]; exists {
+			return nil, fmt.Errorf("method key '%v' resolves to '%v', which is provided more than once", key, canonicalKey)
+		}
+		canonicalMap[canonicalKey] = value
+	}
+	return canonicalMap, nil
+}
+
+// canonicalizeMethodKeys resolves the keys of each method hook mapping against the provided contract definitions.
+// See canonicalizeMethodKeys for more information.
+// Returns an error if a key could not be resolved to a method.
+func (h *FuzzerHooks) canonicalizeMethodKeys(contractDefinitions fuzzerTypes.Contracts) error {
+	var err error
+	h.MethodArgumentsValidators, err = canonicalizeMethodKeys(contractDefinitions, h.MethodArgumentsValidators)
+	if err != nil {
+		return err
+	}
+	h.MethodAddressCorrelations, err = canonicalizeMethodKeys(contractDefinitions, h.MethodAddressCorrelations)
+	if err != nil {
+		return err
+	}
+	h.MethodArgumentValueProviders, err = canonicalizeMethodKeys(contractDefinitions, h.MethodArgumentValueProviders)
+	return err
+}
@@ -0,0 +1,207 @@
+package fuzzing
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/crytic/medusa/compilation/abiutils"
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core"
+)
+
+// MethodExecutionStats describes how often a state changing method of a contract was selected to be called, and how
+// often calls to it were executed and succeeded.
+type MethodExecutionStats struct {
+	// ContractName describes the name of the contract containing the method.
+	ContractName string
+	// MethodSig describes the signature of the method.
+	MethodSig string
+
+	// SelectedCount describes the amount of times the method was selected when generating a new call.
+	SelectedCount uint64
+	// ExecutedCount describes the amount of times a call to the method was executed, including calls replayed from
+	// the corpus, but excluding calls replayed while shrinking a call sequence.
+	ExecutedCount uint64
+	// SucceededCount describes the amount of times a call to the method was executed without reverting.
+	SucceededCount uint64
+	// FailureReasons describes the amount of times calls to the method failed for each reason (e.g. a revert message,
+	// custom error or panic code). This is only populated if the provider records failure reasons.
+	FailureReasons map[string]uint64
+}
+
+// MethodExecutionFeedbackProvider is a FeedbackProvider which tracks how often each state changing method was
+// selected, executed and succeeded during a fuzzing campaign, so methods which were never successfully executed can
+// be reported. Methods are tracked per contract definition rather than per deployment. Stats are tracked separately by
+// each FuzzerWorker, so workers do not contend over them, and are merged when they are requested. Calls executed while
+// shrinking a call sequence are not counted. It never deems a call sequence interesting itself.
+type MethodExecutionFeedbackProvider struct {
+	// workerStats describes the execution stats tracked by each FuzzerWorker, indexed by worker index.
+	workerStats []*methodExecutionWorkerStats
+
+	// recordFailureReasons indicates whether the reasons calls failed should be decoded and recorded. This is only
+	// needed to summarize failures when coverage stalls, so it is skipped otherwise.
+	recordFailureReasons bool
+}
+
+// methodExecutionWorkerStats describes the execution stats tracked by a single FuzzerWorker.
+type methodExecutionWorkerStats struct {
+	// methods describes the execution stats for each method, keyed by MethodHookKey.
+	methods map[string]*MethodExecutionStats
+
+	// methodsLock provides thread-synchronization to avoid race conditions when methods are merged while the worker
+	// updates them. It is otherwise uncontended.
+	methodsLock sync.Mutex
+}
+
+// NewMethodExecutionFeedbackProvider creates a MethodExecutionFeedbackProvider with no tracked methods, for the
+// provided amount of workers. If recordFailureReasons is true, the reason each failed call failed for is decoded and
+// recorded.
+func NewMethodExecutionFeedbackProvider(workerCount int, recordFailureReasons bool) *MethodExecutionFeedbackProvider {
+	workerStats := make([]*methodExecutionWorkerStats, workerCount)
+	for i := 0; i < workerCount; i++ {
+		workerStats[i] = &methodExecutionWorkerStats{methods: make(map[string]*MethodExecutionStats)}
+	}
+	return &MethodExecutionFeedbackProvider{
+		workerStats:          workerStats,
+		recordFailureReasons: recordFailureReasons,
+	}
+}
+
+// getOrCreateStats obtains the stats for the provided method, creating them if needed. The methodsLock must be
+// acquired by the caller.
+func (s *methodExecutionWorkerStats) getOrCreateStats(contractName string, method *abi.Method) *MethodExecutionStats {
+	key := MethodHookKey(contractName, method)
+	stats, ok := s.methods[key]
+	if !ok {
+		stats = &MethodExecutionStats{
+			ContractName:   contractName,
+			MethodSig:      method.Sig,
+			FailureReasons: make(map[string]uint64),
+		}
+		s.methods[key] = stats
+	}
+	return stats
+}
+
+// registerMethod begins tracking the provided method for the provided worker, so it is reported even if it is never
+// selected.
+func (p *MethodExecutionFeedbackProvider) registerMethod(workerIndex int, contractName string, method *abi.Method) {
+	workerStats := p.workerStats[workerIndex]
+	workerStats.methodsLock.Lock()
+	defer workerStats.methodsLock.Unlock()
+	workerStats.getOrCreateStats(contractName, method)
+}
+
+// recordSelection records that the provided method was selected by the provided worker when generating a new call.
+func (p *MethodExecutionFeedbackProvider) recordSelection(workerIndex int, contractName string, method *abi.Method) {
+	workerStats := p.workerStats[workerIndex]
+	workerStats.methodsLock.Lock()
+	defer workerStats.methodsLock.Unlock()
+	workerStats.getOrCreateStats(contractName, method).SelectedCount++
+}
+
+// CheckCallSequence records whether the most recent call executed in the provided call sequence succeeded, unless
+// the worker is shrinking a call sequence.
+// Returns FeedbackResultNone, as this provider does not deem call sequences interesting, or an error if one occurs.
+func (p *MethodExecutionFeedbackProvider) CheckCallSequence(worker *FuzzerWorker, callSequence calls.CallSequence) (FeedbackResult, error) {
+	// Calls replayed while shrinking are not counted, as they would inflate the stats of the shrunken calls.
+	if worker.IsShrinking() {
+		return FeedbackResultNone, nil
+	}
+
+	// Obtain the last call executed. We only track calls to methods of known contracts which were executed.
+	lastCall := callSequence[len(callSequence)-1]
+	if lastCall.ChainReference == nil || lastCall.Contract == nil || lastCall.Call.MsgDataAbiValues == nil || lastCall.Call.MsgDataAbiValues.Method == nil {
+		return FeedbackResultNone, nil
+	}
+
+	// Determine the reason the call failed, if we are recording them, before acquiring our lock.
+	executionResult := lastCall.ChainReference.MessageResults().ExecutionResult
+	failureReason := ""
+	if executionResult.Err != nil && p.recordFailureReasons {
+		failureReason = getFailureReason(lastCall.Contract.CompiledContract().Abi, executionResult)
+	}
+
+	// Record the result of the call.
+	workerStats := p.workerStats[worker.WorkerIndex()]
+	workerStats.methodsLock.Lock()
+	defer workerStats.methodsLock.Unlock()
+	stats := workerStats.getOrCreateStats(lastCall.Contract.Name(), lastCall.Call.MsgDataAbiValues.Method)
+	stats.ExecutedCount++
+	if executionResult.Err == nil {
+		stats.SucceededCount++
+	} else if p.recordFailureReasons {
+		stats.FailureReasons[failureReason]++
+	}
+	return FeedbackResultNone, nil
+}
+
+// getFailureReason obtains a displayable reason for a failed execution result, resolving revert messages, custom
+// errors and panic codes where possible.
+func getFailureReason(contractAbi abi.ABI, executionResult *core.ExecutionResult) string {
+	if revertMessage := abiutils.GetSolidityRevertErrorString(executionResult.Err, executionResult.ReturnData); revertMessage != nil {
+		return fmt.Sprintf("%v: %v", executionResult.Err, *revertMessage)
+	}
+	if customError, _ := abiutils.GetSolidityCustomRevertError(&contractAbi, executionResult.Err, executionResult.ReturnData); customError != nil {
+		return fmt.Sprintf("%v: %v", executionResult.Err, customError.Sig)
+	}
+	if panicCode := abiutils.GetSolidityPanicCode(executionResult.Err, executionResult.ReturnData, false); panicCode != nil {
+		return fmt.Sprintf("panic: 0x%x", panicCode)
+	}
+	return executionResult.Err.Error()
+}
+
+// MethodExecutionStats returns the stats for every tracked method, merged across all workers and sorted by contract
+// name and method signature.
+func (p *MethodExecutionFeedbackProvider) MethodExecutionStats() []MethodExecutionStats {
+	// Merge the stats of each worker.
+	mergedStats := make(map[string]*MethodExecutionStats)
+	for _, workerStats := range p.workerStats {
+		workerStats.methodsLock.Lock()
+		for key, stats := range workerStats.methods {
+			merged, ok := mergedStats[key]
+			if !ok {
+				merged = &MethodExecutionStats{
+					ContractName:   stats.ContractName,
+					MethodSig:      stats.MethodSig,
+					FailureReasons: make(map[string]uint64, len(stats.FailureReasons)),
+				}
+				mergedStats[key] = merged
+			}
+			merged.SelectedCount += stats.SelectedCount
+			merged.ExecutedCount += stats.ExecutedCount
+			merged.SucceededCount += stats.SucceededCount
+			for reason, count := range stats.FailureReasons {
+				merged.FailureReasons[reason] += count
+			}
+		}
+		workerStats.methodsLock.Unlock()
+	}
+
+	methodStats := make([]MethodExecutionStats, 0, len(mergedStats))
+	for _, stats := range mergedStats {
+		methodStats = append(methodStats, *stats)
+	}
+	sort.Slice(methodStats, func(i, j int) bool {
+		if methodStats[i].ContractName != methodStats[j].ContractName {
+			return methodStats[i].ContractName < methodStats[j].ContractName
+		}
+		return methodStats[i].MethodSig < methodStats[j].MethodSig
+	})
+	return methodStats
+}
+
+// UnreachableMethods returns the stats for every tracked method which was never successfully executed, either because
+// it was never selected or because every call to it reverted. These often indicate misconfiguration, such as senders
+// lacking permissions or preconditions which are never met.
+func (p *MethodExecutionFeedbackProvider) UnreachableMethods() []MethodExecutionStats {
+	unreachableMethods := make([]MethodExecutionStats, 0)
+	for _, stats := range p.MethodExecutionStats() {
+		if stats.SucceededCount == 0 {
+			unreachableMethods = append(unreachableMethods, stats)
+		}
+	}
+	return unreachableMethods
+}
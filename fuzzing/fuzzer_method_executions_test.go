@@ -0,0 +1,107 @@
+package fuzzing
+
+import (
+	"math/big"
+	"testing"
+
+	chainTypes "github.com/crytic/medusa/chain/types"
+	"github.com/crytic/medusa/compilation/types"
+	"github.com/crytic/medusa/fuzzing/calls"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordTestMethodExecution records the result of a call to the provided method with the
+// MethodExecutionFeedbackProvider, as if it was executed by the provided worker with the provided error.
+func recordTestMethodExecution(t *testing.T, provider *MethodExecutionFeedbackProvider, worker *FuzzerWorker, contract *fuzzerTypes.Contract, method *abi.Method, executionErr error) {
+	contractAddress := common.HexToAddress("0x1000")
+	msg := calls.NewCallMessageWithAbiValueData(common.HexToAddress("0x10000"), &contractAddress, 0, big.NewInt(0), 100000, nil, nil, nil, &calls.CallMessageDataAbiValues{
+		Method:      method,
+		InputValues: []any{},
+	})
+	element := calls.NewCallSequenceElement(contract, msg, 1, 1)
+	element.ChainReference = &calls.CallSequenceElementChainReference{
+		Block: &chainTypes.Block{
+			MessageResults: []*chainTypes.MessageResults{{ExecutionResult: &core.ExecutionResult{Err: executionErr}}},
+		},
+		TransactionIndex: 0,
+	}
+	result, err := provider.CheckCallSequence(worker, calls.CallSequence{element})
+	assert.NoError(t, err)
+	assert.EqualValues(t, FeedbackResultNone, result)
+}
+
+// TestMethodExecutionFeedbackProviderFailureReasons runs tests to ensure the executions of each method are counted,
+// while the reasons calls failed are only recorded if requested.
+func TestMethodExecutionFeedbackProviderFailureReasons(t *testing.T) {
+	contract := fuzzerTypes.NewContract("TestContract", "contracts/TestContract.sol", &types.CompiledContract{}, nil)
+	method := abi.NewMethod("withdraw", "withdraw", abi.Function, "nonpayable", false, false, abi.Arguments{}, nil)
+
+	for _, recordFailureReasons := range []bool{false, true} {
+		// Record a successful call and two failed calls.
+		provider := NewMethodExecutionFeedbackProvider(1, recordFailureReasons)
+		worker := &FuzzerWorker{workerIndex: 0}
+		recordTestMethodExecution(t, provider, worker, contract, &method, nil)
+		recordTestMethodExecution(t, provider, worker, contract, &method, vm.ErrExecutionReverted)
+		recordTestMethodExecution(t, provider, worker, contract, &method, vm.ErrExecutionReverted)
+
+		// Verify the calls were counted, and the failure reasons were only recorded if requested.
+		methodStats := provider.MethodExecutionStats()
+		assert.Len(t, methodStats, 1)
+		assert.EqualValues(t, 3, methodStats[0].ExecutedCount)
+		assert.EqualValues(t, 1, methodStats[0].SucceededCount)
+		if recordFailureReasons {
+			assert.EqualValues(t, map[string]uint64{vm.ErrExecutionReverted.Error(): 2}, methodStats[0].FailureReasons)
+		} else {
+			assert.Empty(t, methodStats[0].FailureReasons)
+		}
+	}
+}
+
+// TestMethodExecutionFeedbackProviderWorkers runs tests to ensure the stats tracked by each worker are merged, calls
+// executed while shrinking are not counted, and methods which never succeeded are reported as unreachable.
+func TestMethodExecutionFeedbackProviderWorkers(t *testing.T) {
+	contract := fuzzerTypes.NewContract("TestContract", "contracts/TestContract.sol", &types.CompiledContract{}, nil)
+	deposit := abi.NewMethod("deposit", "deposit", abi.Function, "nonpayable", false, false, abi.Arguments{}, nil)
+	withdraw := abi.NewMethod("withdraw", "withdraw", abi.Function, "nonpayable", false, false, abi.Arguments{}, nil)
+	provider := NewMethodExecutionFeedbackProvider(2, true)
+	workers := []*FuzzerWorker{{workerIndex: 0}, {workerIndex: 1}}
+
+	// Each worker registers both methods, selects and executes them, with only deposit succeeding.
+	for _, worker := range workers {
+		provider.registerMethod(worker.WorkerIndex(), contract.Name(), &deposit)
+		provider.registerMethod(worker.WorkerIndex(), contract.Name(), &withdraw)
+		provider.recordSelection(worker.WorkerIndex(), contract.Name(), &deposit)
+		provider.recordSelection(worker.WorkerIndex(), contract.Name(), &withdraw)
+		recordTestMethodExecution(t, provider, worker, contract, &deposit, nil)
+		recordTestMethodExecution(t, provider, worker, contract, &withdraw, vm.ErrExecutionReverted)
+	}
+
+	// Calls executed while shrinking are ignored, even if they succeed.
+	workers[1].shrinking = true
+	recordTestMethodExecution(t, provider, workers[1], contract, &withdraw, nil)
+	recordTestMethodExecution(t, provider, workers[1], contract, &deposit, nil)
+	workers[1].shrinking = false
+
+	// Verify the stats of both workers were merged.
+	methodStats := provider.MethodExecutionStats()
+	assert.Len(t, methodStats, 2)
+	assert.EqualValues(t, "deposit()", methodStats[0].MethodSig)
+	assert.EqualValues(t, 2, methodStats[0].SelectedCount)
+	assert.EqualValues(t, 2, methodStats[0].ExecutedCount)
+	assert.EqualValues(t, 2, methodStats[0].SucceededCount)
+	assert.EqualValues(t, "withdraw()", methodStats[1].MethodSig)
+	assert.EqualValues(t, 2, methodStats[1].SelectedCount)
+	assert.EqualValues(t, 2, methodStats[1].ExecutedCount)
+	assert.EqualValues(t, 0, methodStats[1].SucceededCount)
+	assert.EqualValues(t, map[string]uint64{vm.ErrExecutionReverted.Error(): 2}, methodStats[1].FailureReasons)
+
+	// Only the method which never succeeded is reported as unreachable.
+	unreachableMethods := provider.UnreachableMethods()
+	assert.Len(t, unreachableMethods, 1)
+	assert.EqualValues(t, "withdraw()", unreachableMethods[0].MethodSig)
+}
@@ -0,0 +1,84 @@
+package fuzzing
+
+import (
+	"testing"
+
+	"github.com/crytic/medusa/chain"
+	"github.com/crytic/medusa/fuzzing/config"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMinimizeCorpusSetup runs tests to ensure corpus minimization requires a corpus directory, and validates the
+// configuration used to set up its test chain before setting it up, as the fuzzer does when starting.
+func TestMinimizeCorpusSetup(t *testing.T) {
+	contract := newTestTargetMethodsContract(t, "TestContract", `[
+		{"type":"constructor","inputs":[{"name":"owner","type":"address"}],"stateMutability":"nonpayable"}
+	]`)
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	fuzzer, err := NewFuzzer(*projectConfig)
+	assert.NoError(t, err)
+	fuzzer.contractDefinitions = fuzzerTypes.Contracts{contract}
+	chainSetupCalled := false
+	fuzzer.Hooks.ChainSetupFunc = func(fuzzer *Fuzzer, testChain *chain.TestChain) error {
+		chainSetupCalled = true
+		return nil
+	}
+
+	// A corpus directory is required.
+	_, err = fuzzer.MinimizeCorpus()
+	assert.Error(t, err)
+
+	// Missing constructor arguments are reported before the chain is set up.
+	fuzzer.config.Fuzzing.CorpusDirectory = t.TempDir()
+	_, err = fuzzer.MinimizeCorpus()
+	assert.ErrorContains(t, err, "constructor arguments for contract 'TestContract' not provided")
+	assert.False(t, chainSetupCalled)
+
+	// Once the constructor arguments are provided, the chain is set up and the empty corpus is minimized.
+	fuzzer.config.Fuzzing.ConstructorArgs = map[string]map[string]any{"TestContract": {"owner": "0x0000000000000000000000000000000000010000"}}
+	removedCount, err := fuzzer.MinimizeCorpus()
+	assert.NoError(t, err)
+	assert.Zero(t, removedCount)
+	assert.True(t, chainSetupCalled)
+}
+
+// TestMinimizeCorpusCanonicalizesMethodReferences runs tests to ensure corpus minimization resolves the methods
+// referenced by hooks and configuration prior to setting up its test chain, as the fuzzer does when starting.
+func TestMinimizeCorpusCanonicalizesMethodReferences(t *testing.T) {
+	contract := newTestTargetMethodsContract(t, "TestContract", `[
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"function","name":"balance","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}
+	]`)
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	projectConfig.Fuzzing.CorpusDirectory = t.TempDir()
+	projectConfig.Fuzzing.Testing.AssertionTesting.IncludeViewMethods = []string{"TestContract.balance"}
+	fuzzer, err := NewFuzzer(*projectConfig)
+	assert.NoError(t, err)
+	fuzzer.contractDefinitions = fuzzerTypes.Contracts{contract}
+
+	// Reference methods with non-canonical formatting, and verify they were resolved by the time the chain is set up.
+	fuzzer.Hooks.MethodAddressCorrelations = map[string][]AddressCorrelation{
+		"TestContract.transfer(address, uint)": {{Kind: AddressCorrelationSender, ArgumentIndex: 0, Probability: 1}},
+	}
+	chainSetupCalled := false
+	fuzzer.Hooks.ChainSetupFunc = func(fuzzer *Fuzzer, testChain *chain.TestChain) error {
+		chainSetupCalled = true
+		assert.Contains(t, fuzzer.Hooks.MethodAddressCorrelations, "TestContract.transfer(address,uint256)")
+		assert.EqualValues(t, []string{"TestContract.balance()"}, fuzzer.config.Fuzzing.Testing.AssertionTesting.IncludeViewMethods)
+		return nil
+	}
+	removedCount, err := fuzzer.MinimizeCorpus()
+	assert.NoError(t, err)
+	assert.Zero(t, removedCount)
+	assert.True(t, chainSetupCalled)
+
+	// References which do not resolve to a method are rejected.
+	fuzzer.Hooks.MethodAddressCorrelations = map[string][]AddressCorrelation{
+		"TestContract.transfer(address)": {{Kind: AddressCorrelationSender, ArgumentIndex: 0, Probability: 1}},
+	}
+	_, err = fuzzer.MinimizeCorpus()
+	assert.Error(t, err)
+}
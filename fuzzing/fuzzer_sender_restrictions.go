@@ -0,0 +1,217 @@
+package fuzzing
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// senderRestrictionMinReverts describes the minimum amount of times every other sender must have failed to call a
+// method, with arguments another sender succeeded with, before its calls are considered restricted to a single sender.
+const senderRestrictionMinReverts = 10
+
+// senderRestrictionBias describes the probability that a call to a method restricted to a single sender will be
+// sent from that sender. The remaining calls select a sender as usual, so restrictions which are lifted later in a
+// call sequence (e.g. by an ownership transfer) can still be discovered.
+const senderRestrictionBias = 0.9
+
+// senderRestrictionMaxMethods describes the maximum amount of methods for which sender restrictions are tracked.
+// Methods of contracts deployed once this limit is reached (e.g. by factory contracts) are not tracked.
+const senderRestrictionMaxMethods = 4096
+
+// senderRestrictionMaxArguments describes the maximum amount of distinct arguments for which call results are tracked
+// per method. Calls with new arguments once this limit is reached are not tracked.
+const senderRestrictionMaxArguments = 256
+
+// senderRestrictionKey describes a method of a deployed contract, for which sender restrictions are tracked.
+type senderRestrictionKey struct {
+	// contractAddress describes the address of the deployed contract containing the method.
+	contractAddress common.Address
+	// methodID describes the selector of the method.
+	methodID string
+}
+
+// senderRestrictionArguments describes the call results observed for a method called with given arguments.
+type senderRestrictionArguments struct {
+	// succeededSender describes the first sender which successfully called the method with the arguments, or nil if
+	// no sender has yet.
+	succeededSender *common.Address
+	// pendingFailures describes the failure reason of each sender which failed to call the method with the arguments
+	// before any sender succeeded with them.
+	pendingFailures map[common.Address]string
+}
+
+// senderRestrictionStats describes the execution results observed for each sender calling a given method. Failures
+// are only attributed to a sender if another sender succeeded with the same arguments, so failures which depend on
+// the arguments rather than the sender are not considered.
+type senderRestrictionStats struct {
+	// succeededSenders describes the senders which successfully called the method at least once.
+	succeededSenders map[common.Address]struct{}
+	// arguments describes the call results observed for each distinct call data the method was called with.
+	arguments map[string]*senderRestrictionArguments
+	// failedSenders describes the amount of times each sender failed to call the method with arguments another
+	// sender succeeded with.
+	failedSenders map[common.Address]uint64
+	// failureReason describes the error and return data of the first failure attributed to any sender, so failures
+	// can be checked for consistency.
+	failureReason string
+	// consistentFailures indicates whether every failure attributed to a sender so far had the same failureReason.
+	consistentFailures bool
+}
+
+// recordFailure attributes a failure to call the method with arguments another sender succeeded with to the
+// provided sender.
+func (s *senderRestrictionStats) recordFailure(sender common.Address, failureReason string) {
+	if s.failureReason == "" {
+		s.failureReason = failureReason
+	} else if s.failureReason != failureReason {
+		s.consistentFailures = false
+	}
+	s.failedSenders[sender]++
+}
+
+// recordResult records the result of a call to the method by the provided sender with the provided call data. If
+// the call failed, failureReason describes why, otherwise it is empty.
+func (s *senderRestrictionStats) recordResult(sender common.Address, callData string, succeeded bool, failureReason string) {
+	if succeeded {
+		s.succeededSenders[sender] = struct{}{}
+	}
+
+	// Obtain the results for these arguments, creating them if we have not reached our limit.
+	arguments, ok := s.arguments[callData]
+	if !ok {
+		if len(s.arguments) >= senderRestrictionMaxArguments {
+			return
+		}
+		arguments = &senderRestrictionArguments{pendingFailures: make(map[common.Address]string)}
+		s.arguments[callData] = arguments
+	}
+
+	// If another sender already succeeded with these arguments, a failure is attributed to this sender. Failures by
+	// the sender which succeeded depend on state rather than the sender, so they are ignored.
+	if arguments.succeededSender != nil {
+		if !succeeded && *arguments.succeededSender != sender {
+			s.recordFailure(sender, failureReason)
+		}
+		return
+	}
+
+	// Otherwise, if the call failed, it may be attributed to this sender once another sender succeeds.
+	if !succeeded {
+		if _, ok := arguments.pendingFailures[sender]; !ok {
+			arguments.pendingFailures[sender] = failureReason
+		}
+		return
+	}
+
+	// If it succeeded, attribute the pending failures of every other sender.
+	arguments.succeededSender = &sender
+	for failedSender, pendingFailureReason := range arguments.pendingFailures {
+		if failedSender != sender {
+			s.recordFailure(failedSender, pendingFailureReason)
+		}
+	}
+	arguments.pendingFailures = nil
+}
+
+// SenderRestrictionFeedbackProvider is a FeedbackProvider which learns which methods can only be successfully called
+// by a single sender (e.g. due to access control modifiers such as onlyOwner). A method is considered restricted if
+// it succeeded for exactly one sender, while every other sender failed for the same reason a minimum amount of times
+// when calling it with arguments the succeeding sender called it with. Calls generated for restricted methods are
+// biased towards the sender which succeeded, reducing the amount of calls wasted on access control failures. It never
+// deems a call sequence interesting itself, and is only registered if enabled by the project configuration.
+type SenderRestrictionFeedbackProvider struct {
+	// senders describes the senders which may be selected to send calls.
+	senders []common.Address
+
+	// methods describes the execution results observed for each method called.
+	methods map[senderRestrictionKey]*senderRestrictionStats
+
+	// methodsLock provides thread-synchronization to avoid race conditions when accessing methods.
+	methodsLock sync.Mutex
+}
+
+// NewSenderRestrictionFeedbackProvider creates a SenderRestrictionFeedbackProvider which tracks calls sent by the
+// provided senders.
+func NewSenderRestrictionFeedbackProvider(senders []common.Address) *SenderRestrictionFeedbackProvider {
+	return &SenderRestrictionFeedbackProvider{
+		senders: senders,
+		methods: make(map[senderRestrictionKey]*senderRestrictionStats),
+	}
+}
+
+// CheckCallSequence records whether the most recent call executed in the provided call sequence succeeded for its
+// sender and arguments, and the reason it failed otherwise.
+// Returns FeedbackResultNone, as this provider does not deem call sequences interesting, or an error if one occurs.
+func (p *SenderRestrictionFeedbackProvider) CheckCallSequence(worker *FuzzerWorker, callSequence calls.CallSequence) (FeedbackResult, error) {
+	// Obtain the last call executed. We only track calls to methods with known ABI values which were executed.
+	lastCall := callSequence[len(callSequence)-1]
+	if lastCall.ChainReference == nil || lastCall.Call.MsgTo == nil || lastCall.Call.MsgDataAbiValues == nil || lastCall.Call.MsgDataAbiValues.Method == nil {
+		return FeedbackResultNone, nil
+	}
+	key := senderRestrictionKey{
+		contractAddress: *lastCall.Call.MsgTo,
+		methodID:        string(lastCall.Call.MsgDataAbiValues.Method.ID),
+	}
+	callData, err := lastCall.Call.MsgDataAbiValues.Pack()
+	if err != nil {
+		return FeedbackResultNone, err
+	}
+
+	// Determine the result of the call, before acquiring our lock.
+	executionResult := lastCall.ChainReference.MessageResults().ExecutionResult
+	succeeded := executionResult.Err == nil
+	failureReason := ""
+	if !succeeded {
+		failureReason = executionResult.Err.Error() + hex.EncodeToString(executionResult.ReturnData)
+	}
+
+	// Acquire our lock and obtain the stats for this method, creating them if we have not reached our limit.
+	p.methodsLock.Lock()
+	defer p.methodsLock.Unlock()
+	stats, ok := p.methods[key]
+	if !ok {
+		if len(p.methods) >= senderRestrictionMaxMethods {
+			return FeedbackResultNone, nil
+		}
+		stats = &senderRestrictionStats{
+			succeededSenders:   make(map[common.Address]struct{}),
+			arguments:          make(map[string]*senderRestrictionArguments),
+			failedSenders:      make(map[common.Address]uint64),
+			consistentFailures: true,
+		}
+		p.methods[key] = stats
+	}
+
+	// Record the result of the call.
+	stats.recordResult(lastCall.Call.MsgFrom, string(callData), succeeded, failureReason)
+	return FeedbackResultNone, nil
+}
+
+// restrictedSender checks whether calls to the provided method of a deployed contract are believed to be restricted
+// to a single sender.
+// Returns the sender the method is restricted to, and a boolean indicating whether the method is restricted.
+func (p *SenderRestrictionFeedbackProvider) restrictedSender(contractAddress common.Address, methodID []byte) (common.Address, bool) {
+	p.methodsLock.Lock()
+	defer p.methodsLock.Unlock()
+
+	// If we have no stats for this method, or it failed for different reasons, it is not restricted.
+	stats, ok := p.methods[senderRestrictionKey{contractAddress: contractAddress, methodID: string(methodID)}]
+	if !ok || !stats.consistentFailures || len(stats.succeededSenders) != 1 {
+		return common.Address{}, false
+	}
+
+	// Obtain the only sender which succeeded, and verify every other sender failed enough times.
+	var allowedSender common.Address
+	for sender := range stats.succeededSenders {
+		allowedSender = sender
+	}
+	for _, sender := range p.senders {
+		if sender != allowedSender && stats.failedSenders[sender] < senderRestrictionMinReverts {
+			return common.Address{}, false
+		}
+	}
+	return allowedSender, true
+}
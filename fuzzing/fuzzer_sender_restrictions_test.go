@@ -0,0 +1,104 @@
+package fuzzing
+
+import (
+	"math/big"
+	"testing"
+
+	chainTypes "github.com/crytic/medusa/chain/types"
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordTestSenderRestrictionCall records the result of a call to the provided method with the provided argument
+// with the SenderRestrictionFeedbackProvider, as if it was executed with the provided error.
+func recordTestSenderRestrictionCall(t *testing.T, provider *SenderRestrictionFeedbackProvider, contractAddress common.Address, method *abi.Method, sender common.Address, argument int64, executionErr error) {
+	msg := calls.NewCallMessageWithAbiValueData(sender, &contractAddress, 0, big.NewInt(0), 100000, nil, nil, nil, &calls.CallMessageDataAbiValues{
+		Method:      method,
+		InputValues: []any{big.NewInt(argument)},
+	})
+	element := calls.NewCallSequenceElement(nil, msg, 1, 1)
+	element.ChainReference = &calls.CallSequenceElementChainReference{
+		Block: &chainTypes.Block{
+			MessageResults: []*chainTypes.MessageResults{{ExecutionResult: &core.ExecutionResult{Err: executionErr}}},
+		},
+		TransactionIndex: 0,
+	}
+	result, err := provider.CheckCallSequence(nil, calls.CallSequence{element})
+	assert.NoError(t, err)
+	assert.EqualValues(t, FeedbackResultNone, result)
+}
+
+// TestSenderRestrictionFeedbackProvider runs tests to ensure a method is only considered restricted to a sender once
+// every other sender consistently failed with arguments that sender succeeded with, and that failures which depend on
+// the arguments rather than the sender are not attributed to the sender.
+func TestSenderRestrictionFeedbackProvider(t *testing.T) {
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("setFee", "setFee", abi.Function, "nonpayable", false, false, abi.Arguments{{Name: "fee", Type: uint256Type}}, nil)
+	contractAddress := common.HexToAddress("0x1000")
+	owner := common.HexToAddress("0x10000")
+	other := common.HexToAddress("0x20000")
+	provider := NewSenderRestrictionFeedbackProvider([]common.Address{owner, other})
+
+	// The owner succeeds with small arguments and fails with large ones, while the other sender always fails.
+	for i := int64(0); i < senderRestrictionMinReverts; i++ {
+		recordTestSenderRestrictionCall(t, provider, contractAddress, &method, owner, 1000+i, vm.ErrExecutionReverted)
+		recordTestSenderRestrictionCall(t, provider, contractAddress, &method, other, 1000+i, vm.ErrExecutionReverted)
+	}
+
+	// Failures with arguments no sender succeeded with are not attributed to any sender.
+	_, restricted := provider.restrictedSender(contractAddress, method.ID)
+	assert.False(t, restricted)
+
+	// Once the owner succeeds with arguments the other sender failed with, the other sender's failures are
+	// attributed to it, regardless of the order in which they occurred.
+	for i := int64(0); i < senderRestrictionMinReverts; i++ {
+		if i%2 == 0 {
+			recordTestSenderRestrictionCall(t, provider, contractAddress, &method, other, i, vm.ErrExecutionReverted)
+			_, restricted = provider.restrictedSender(contractAddress, method.ID)
+			assert.False(t, restricted)
+			recordTestSenderRestrictionCall(t, provider, contractAddress, &method, owner, i, nil)
+		} else {
+			recordTestSenderRestrictionCall(t, provider, contractAddress, &method, owner, i, nil)
+			recordTestSenderRestrictionCall(t, provider, contractAddress, &method, other, i, vm.ErrExecutionReverted)
+		}
+
+		// The owner failing with arguments it previously succeeded with is not attributed to it.
+		recordTestSenderRestrictionCall(t, provider, contractAddress, &method, owner, i, vm.ErrExecutionReverted)
+	}
+	restrictedSender, restricted := provider.restrictedSender(contractAddress, method.ID)
+	assert.True(t, restricted)
+	assert.EqualValues(t, owner, restrictedSender)
+
+	// Once the other sender succeeds, the method is no longer considered restricted.
+	recordTestSenderRestrictionCall(t, provider, contractAddress, &method, other, 0, nil)
+	_, restricted = provider.restrictedSender(contractAddress, method.ID)
+	assert.False(t, restricted)
+}
+
+// TestSenderRestrictionFeedbackProviderLimits runs tests to ensure the amount of methods and arguments tracked by a
+// SenderRestrictionFeedbackProvider is bounded.
+func TestSenderRestrictionFeedbackProviderLimits(t *testing.T) {
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("setFee", "setFee", abi.Function, "nonpayable", false, false, abi.Arguments{{Name: "fee", Type: uint256Type}}, nil)
+	sender := common.HexToAddress("0x10000")
+	provider := NewSenderRestrictionFeedbackProvider([]common.Address{sender})
+
+	// Call the same method with more distinct arguments than are tracked.
+	contractAddress := common.HexToAddress("0x1000")
+	for i := int64(0); i < senderRestrictionMaxArguments*2; i++ {
+		recordTestSenderRestrictionCall(t, provider, contractAddress, &method, sender, i, nil)
+	}
+	assert.Len(t, provider.methods[senderRestrictionKey{contractAddress: contractAddress, methodID: string(method.ID)}].arguments, senderRestrictionMaxArguments)
+
+	// Call the method on more distinct contracts than are tracked.
+	for i := int64(0); i < senderRestrictionMaxMethods*2; i++ {
+		recordTestSenderRestrictionCall(t, provider, common.BigToAddress(big.NewInt(i)), &method, sender, 0, nil)
+	}
+	assert.Len(t, provider.methods, senderRestrictionMaxMethods)
+}
@@ -0,0 +1,178 @@
+package fuzzing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crytic/medusa/compilation/abiutils"
+	"github.com/crytic/medusa/fuzzing/config"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"golang.org/x/exp/slices"
+)
+
+// TargetMethod describes a contract method which a Fuzzer targets, either by calling it in call sequences or by
+// testing it.
+type TargetMethod struct {
+	// Contract describes the contract definition which contains the method.
+	Contract *fuzzerTypes.Contract
+
+	// Method describes the targeted method.
+	Method abi.Method
+
+	// Fuzzed indicates whether the method is called in generated call sequences.
+	Fuzzed bool
+
+	// PropertyTest indicates whether the method is tested as a property test.
+	PropertyTest bool
+
+	// AssertionTest indicates whether the method is tested for assertion failures.
+	AssertionTest bool
+}
+
+// GetTargetMethods resolves the list of methods a Fuzzer with the provided fuzzing configuration will target, from the
+// provided contract definitions. Methods are reported as fuzzed if they would be called in generated call sequences
+// once their contract is deployed, which applies to any contract definition as contracts may be deployed
+// dynamically. Methods are only reported as tested if their contract is in the configured deployment order, or all
+// contracts are configured to be tested. Methods are returned sorted by contract name and method signature.
+func GetTargetMethods(contractDefinitions fuzzerTypes.Contracts, fuzzingConfig config.FuzzingConfig) []TargetMethod {
+	targetMethods := make([]TargetMethod, 0)
+	for _, contract := range contractDefinitions {
+		// Methods are only tested if we're testing all contracts, or the contract is in our deployment order.
+		testContract := isTestContract(contract, fuzzingConfig)
+		for _, method := range contract.CompiledContract().Abi.Methods {
+			targetMethod := TargetMethod{
+				Contract:      contract,
+				Method:        method,
+				Fuzzed:        isFuzzedMethod(contract, method, fuzzingConfig),
+				PropertyTest:  testContract && fuzzingConfig.Testing.PropertyTesting.Enabled && isPropertyTestMethod(method, fuzzingConfig.Testing),
+				AssertionTest: testContract && fuzzingConfig.Testing.AssertionTesting.Enabled && isAssertionTestMethod(contract, method, fuzzingConfig.Testing),
+			}
+			if targetMethod.Fuzzed || targetMethod.PropertyTest || targetMethod.AssertionTest {
+				targetMethods = append(targetMethods, targetMethod)
+			}
+		}
+	}
+
+	// Sort our methods so the result is deterministic.
+	sort.SliceStable(targetMethods, func(i, j int) bool {
+		if targetMethods[i].Contract.Name() != targetMethods[j].Contract.Name() {
+			return targetMethods[i].Contract.Name() < targetMethods[j].Contract.Name()
+		}
+		return targetMethods[i].Method.Sig < targetMethods[j].Method.Sig
+	})
+	return targetMethods
+}
+
+// TargetMethods resolves the list of methods the Fuzzer will target from its compiled contract definitions and
+// configuration. See GetTargetMethods for more information.
+func (f *Fuzzer) TargetMethods() []TargetMethod {
+	return GetTargetMethods(f.contractDefinitions, f.config.Fuzzing)
+}
+
+// isTestContract checks whether methods of the provided contract should be tested given the fuzzing configuration.
+func isTestContract(contract *fuzzerTypes.Contract, fuzzingConfig config.FuzzingConfig) bool {
+	if isExcludedContract(contract, fuzzingConfig) {
+		return false
+	}
+	return fuzzingConfig.Testing.TestAllContracts || slices.Contains(fuzzingConfig.DeploymentOrder, contract.Name())
+}
+
+// isFuzzedMethod checks whether the method of the provided contract is called in generated call sequences once the
+// contract is deployed, given the fuzzing configuration.
+func isFuzzedMethod(contract *fuzzerTypes.Contract, method abi.Method, fuzzingConfig config.FuzzingConfig) bool {
+	// If the contract is configured to never be called, its methods are not fuzzed.
+	if isExcludedContract(contract, fuzzingConfig) {
+		return false
+	}
+
+	// View methods are only called if they are assertion tested, as calling them cannot otherwise be fruitful.
+	if method.IsConstant() && !isFuzzedAssertionTestMethod(contract, method, fuzzingConfig) {
+		return false
+	}
+
+	// Methods with recursive input types cannot have values generated for them, so we skip them.
+	return checkMethodInputsNotRecursive(&method) == nil
+}
+
+// isExcludedContract checks whether the provided contract is configured to never be called or tested.
+func isExcludedContract(contract *fuzzerTypes.Contract, fuzzingConfig config.FuzzingConfig) bool {
+	return slices.Contains(fuzzingConfig.Testing.ExcludeContracts, contract.Name())
+}
+
+// isPropertyTestMethod checks whether the method is a property test given potential naming prefixes it must conform
+// to and its underlying input/output arguments.
+func isPropertyTestMethod(method abi.Method, testingConfig config.TestingConfig) bool {
+	// Loop through all enabled prefixes to find a match
+	for _, prefix := range testingConfig.PropertyTesting.TestPrefixes {
+		if strings.HasPrefix(method.Name, prefix) {
+			if len(method.Inputs) == 0 && len(method.Outputs) == 1 && method.Outputs[0].Type.T == abi.BoolTy {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isAssertionTestMethod checks whether the method of the provided contract is configured to be a target of assertion
+// testing.
+func isAssertionTestMethod(contract *fuzzerTypes.Contract, method abi.Method, testingConfig config.TestingConfig) bool {
+	// Only test state-changing methods if we are not configured to test view methods exclusively.
+	assertionTesting := testingConfig.AssertionTesting
+	if !method.IsConstant() {
+		return !assertionTesting.TestOnlyViewMethods
+	}
+
+	// Only test constant methods (pure/view) if we are configured to, either for all of them or for this method.
+	return assertionTesting.TestViewMethods ||
+		slices.Contains(assertionTesting.IncludeViewMethods, method.Sig) ||
+		slices.Contains(assertionTesting.IncludeViewMethods, contract.Name()+"."+method.Sig)
+}
+
+// canonicalizeIncludeViewMethods resolves each entry of the provided list of view methods to include in assertion
+// testing against the provided contract definitions. Entries are in the format "methodSignature" or
+// "ContractName.methodSignature", where the method signature may be non-canonical (e.g. "getX(uint)") or an
+// unambiguous method name (e.g. "getX"). Entries which do not name a contract are resolved against every contract.
+// Returns the canonicalized entries, or an error if an entry does not resolve to any method.
+func canonicalizeIncludeViewMethods(contractDefinitions fuzzerTypes.Contracts, includeViewMethods []string) ([]string, error) {
+	canonicalEntries := make([]string, 0, len(includeViewMethods))
+	for _, entry := range includeViewMethods {
+		// Split the contract name from the method reference, if one was provided. Method signatures cannot contain
+		// a period before their argument list.
+		contractName, methodReference := "", entry
+		if periodIndex := strings.Index(entry, "."); periodIndex >= 0 && !strings.Contains(entry[:periodIndex], "(") {
+			contractName, methodReference = entry[:periodIndex], entry[periodIndex+1:]
+		}
+
+		// Resolve the method against each matching contract, recording the canonical entry for every match.
+		resolved := false
+		for _, contract := range contractDefinitions {
+			if contractName != "" && contract.Name() != contractName {
+				continue
+			}
+			method, err := abiutils.ResolveMethod(&contract.CompiledContract().Abi, methodReference)
+			if err != nil {
+				continue
+			}
+			resolved = true
+			canonicalEntry := method.Sig
+			if contractName != "" {
+				canonicalEntry = contractName + "." + method.Sig
+			}
+			if !slices.Contains(canonicalEntries, canonicalEntry) {
+				canonicalEntries = append(canonicalEntries, canonicalEntry)
+			}
+		}
+		if !resolved {
+			return nil, fmt.Errorf("view method to include in assertion testing '%v' does not resolve to any method", entry)
+		}
+	}
+	return canonicalEntries, nil
+}
+
+// isFuzzedAssertionTestMethod checks whether the method of the provided contract is a constant (pure/view) method which
+// must be called in generated call sequences, as it is a target of assertion testing.
+func isFuzzedAssertionTestMethod(contract *fuzzerTypes.Contract, method abi.Method, fuzzingConfig config.FuzzingConfig) bool {
+	return method.IsConstant() && fuzzingConfig.Testing.AssertionTesting.Enabled && isAssertionTestMethod(contract, method, fuzzingConfig.Testing)
+}
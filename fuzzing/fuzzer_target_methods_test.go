@@ -0,0 +1,95 @@
+package fuzzing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crytic/medusa/compilation/types"
+	"github.com/crytic/medusa/fuzzing/config"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTargetMethodsContract creates a contract definition with the provided ABI for use in target method tests.
+func newTestTargetMethodsContract(t *testing.T, name string, abiJson string) *fuzzerTypes.Contract {
+	contractAbi, err := abi.JSON(strings.NewReader(abiJson))
+	assert.NoError(t, err)
+	return fuzzerTypes.NewContract(name, name+".sol", &types.CompiledContract{Abi: contractAbi}, nil)
+}
+
+// TestGetTargetMethods runs tests to ensure methods are reported as fuzzed for any contract which is not excluded, as
+// contracts may be deployed dynamically, while tests are only reported for contracts which are tested.
+func TestGetTargetMethods(t *testing.T) {
+	const testAbi = `[
+		{"type":"function","name":"setX","inputs":[{"name":"x","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"function","name":"getX","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+		{"type":"function","name":"fuzz_x","inputs":[],"outputs":[{"name":"","type":"bool"}],"stateMutability":"view"}
+	]`
+	deployedContract := newTestTargetMethodsContract(t, "DeployedContract", testAbi)
+	dynamicContract := newTestTargetMethodsContract(t, "DynamicContract", testAbi)
+	excludedContract := newTestTargetMethodsContract(t, "ExcludedContract", testAbi)
+
+	projectConfig, err := config.GetDefaultProjectConfig("crytic-compile")
+	assert.NoError(t, err)
+	fuzzingConfig := projectConfig.Fuzzing
+	fuzzingConfig.DeploymentOrder = []string{"DeployedContract"}
+	fuzzingConfig.Testing.ExcludeContracts = []string{"ExcludedContract"}
+	fuzzingConfig.Testing.PropertyTesting.Enabled = true
+	fuzzingConfig.Testing.AssertionTesting.Enabled = false
+
+	// Index our target methods by contract name and method signature.
+	targetMethods := make(map[string]TargetMethod)
+	for _, targetMethod := range GetTargetMethods(fuzzerTypes.Contracts{deployedContract, dynamicContract, excludedContract}, fuzzingConfig) {
+		targetMethods[targetMethod.Contract.Name()+"."+targetMethod.Method.Sig] = targetMethod
+	}
+
+	// State changing methods are fuzzed for every contract which is not excluded, while view methods are not fuzzed.
+	assert.True(t, targetMethods["DeployedContract.setX(uint256)"].Fuzzed)
+	assert.True(t, targetMethods["DynamicContract.setX(uint256)"].Fuzzed)
+	assert.NotContains(t, targetMethods, "DeployedContract.getX()")
+	assert.NotContains(t, targetMethods, "ExcludedContract.setX(uint256)")
+
+	// Property tests are only reported for contracts which are tested.
+	assert.True(t, targetMethods["DeployedContract.fuzz_x()"].PropertyTest)
+	assert.False(t, targetMethods["DynamicContract.setX(uint256)"].PropertyTest)
+	assert.NotContains(t, targetMethods, "DynamicContract.fuzz_x()")
+}
+
+// TestCanonicalizeIncludeViewMethods runs tests to ensure view methods to include in assertion testing are resolved to
+// canonical method signatures, with or without a contract name, and that entries which do not resolve to any method
+// are rejected.
+func TestCanonicalizeIncludeViewMethods(t *testing.T) {
+	firstContract := newTestTargetMethodsContract(t, "FirstContract", `[
+		{"type":"function","name":"getX","inputs":[{"name":"x","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+		{"type":"function","name":"getY","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}
+	]`)
+	secondContract := newTestTargetMethodsContract(t, "SecondContract", `[
+		{"type":"function","name":"getY","inputs":[{"name":"y","type":"bytes1"}],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}
+	]`)
+	contractDefinitions := fuzzerTypes.Contracts{firstContract, secondContract}
+
+	tests := []struct {
+		entries         []string
+		expectedEntries []string
+	}{
+		{entries: []string{"getX(uint256)"}, expectedEntries: []string{"getX(uint256)"}},
+		{entries: []string{" getX( uint )"}, expectedEntries: []string{"getX(uint256)"}},
+		{entries: []string{"FirstContract.getX(uint)"}, expectedEntries: []string{"FirstContract.getX(uint256)"}},
+		{entries: []string{"FirstContract.getX"}, expectedEntries: []string{"FirstContract.getX(uint256)"}},
+		{entries: []string{"getY"}, expectedEntries: []string{"getY()", "getY(bytes1)"}},
+		{entries: []string{"SecondContract.getY(byte)", "getY(bytes1)"}, expectedEntries: []string{"SecondContract.getY(bytes1)", "getY(bytes1)"}},
+		{entries: []string{}, expectedEntries: []string{}},
+	}
+	for _, test := range tests {
+		canonicalEntries, err := canonicalizeIncludeViewMethods(contractDefinitions, test.entries)
+		assert.NoError(t, err)
+		assert.EqualValues(t, test.expectedEntries, canonicalEntries)
+	}
+
+	// Entries which do not resolve to any method are rejected.
+	for _, entry := range []string{"getZ()", "getX()", "SecondContract.getX(uint256)", "UnknownContract.getX(uint256)"} {
+		_, err := canonicalizeIncludeViewMethods(contractDefinitions, []string{entry})
+		assert.Error(t, err, "entry '%v' should not resolve", entry)
+	}
+}
@@ -2,11 +2,19 @@ package fuzzing
 
 import (
 	"github.com/crytic/medusa/chain"
+	"github.com/crytic/medusa/compilation"
+	"github.com/crytic/medusa/compilation/abiutils"
+	"github.com/crytic/medusa/compilation/platforms"
 	"github.com/crytic/medusa/events"
 	"github.com/crytic/medusa/fuzzing/calls"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/crytic/medusa/utils"
+	"github.com/crytic/medusa/utils/testutils"
+	"github.com/ethereum/go-ethereum/common"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/crytic/medusa/fuzzing/config"
@@ -55,6 +63,45 @@ func TestFuzzerHooks(t *testing.T) {
 	})
 }
 
+// TestFuzzerHooksCoverageDeltas runs a test to ensure the coverage recorded for each call in a sequence executed by a
+// FuzzerWorker remains available to its call sequence test functions, so they may compute coverage deltas.
+func TestFuzzerHooksCoverageDeltas(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_even_number.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.DeploymentOrder = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 1_000
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = false
+		},
+		method: func(f *fuzzerTestContext) {
+			// Attach a test function which computes the coverage deltas of every sequence tested. The first call of a
+			// sequence always introduces new coverage relative to empty base coverage.
+			var deltasChecked, firstCallCoverageIncreased bool
+			f.fuzzer.Hooks.CallSequenceTestFuncs = append(f.fuzzer.Hooks.CallSequenceTestFuncs, func(worker *FuzzerWorker, callSequence calls.CallSequence) ([]ShrinkCallSequenceRequest, error) {
+				deltas, err := callSequence.CoverageDeltas(nil)
+				if err != nil {
+					return nil, err
+				}
+				deltasChecked = true
+				firstCallCoverageIncreased = deltas[0].CoverageIncreased || deltas[0].RevertedCoverageIncreased
+				if !firstCallCoverageIncreased {
+					worker.Fuzzer().Stop()
+				}
+				return nil, nil
+			})
+
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Assert that coverage deltas were observed for the first call of the last sequence tested.
+			assert.True(t, deltasChecked, "call sequence test func was not called")
+			assert.True(t, firstCallCoverageIncreased, "coverage deltas did not reflect the coverage of the first call")
+		},
+	})
+}
+
 // TestAssertionsBasicSolving runs tests to ensure that assertion testing behaves as expected.
 func TestAssertionsBasicSolving(t *testing.T) {
 	filePaths := []string{
@@ -104,6 +151,99 @@ func TestAssertionsNotRequire(t *testing.T) {
 	})
 }
 
+// TestAssertionsPanicCodeFailures runs tests to ensure only panics with codes configured to be treated as assertion
+// failures are reported.
+func TestAssertionsPanicCodeFailures(t *testing.T) {
+	panicCodeFailures := map[uint64]bool{
+		abiutils.PanicCodeAssertFailed:           false,
+		abiutils.PanicCodeDivideByZero:           true,
+		abiutils.PanicCodeOutOfBoundsArrayAccess: true,
+	}
+	for panicCode, expectFailure := range panicCodeFailures {
+		panicCode, expectFailure := panicCode, expectFailure
+		runFuzzerTest(t, &fuzzerSolcFileTest{
+			filePath: "testdata/contracts/assertions/assert_panic_codes.sol",
+			configUpdates: func(config *config.ProjectConfig) {
+				config.Fuzzing.DeploymentOrder = []string{"TestContract"}
+				config.Fuzzing.TestLimit = 500
+				config.Fuzzing.Testing.PropertyTesting.Enabled = false
+				config.Fuzzing.Testing.AssertionTesting.Enabled = true
+				config.Fuzzing.Testing.AssertionTesting.PanicCodeFailures = []uint64{panicCode}
+			},
+			method: func(f *fuzzerTestContext) {
+				// Start the fuzzer
+				err := f.fuzzer.Start()
+				assert.NoError(t, err)
+
+				// Check for failed assertion tests.
+				assertFailedTestsExpected(f, expectFailure)
+			},
+		})
+	}
+}
+
+// TestAssertionsOnlyIncludedViewMethods runs tests to ensure only the view methods explicitly included are tested when
+// assertion testing is configured to test view methods only.
+func TestAssertionsOnlyIncludedViewMethods(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_view_methods.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.DeploymentOrder = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+			config.Fuzzing.Testing.AssertionTesting.TestOnlyViewMethods = true
+			config.Fuzzing.Testing.AssertionTesting.IncludeViewMethods = []string{"TestContract.failingIncludedViewMethod()"}
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check that only our included view method was tested, and that it failed.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			assert.Len(t, f.fuzzer.TestCases(), 1)
+			if assert.Len(t, failedTestCases, 1) {
+				assert.Contains(t, failedTestCases[0].Name(), "failingIncludedViewMethod()")
+			}
+		},
+	})
+}
+
+// TestAssertionsReceiveAndFallback runs tests to ensure assertion failures in receive and fallback functions are
+// detected when calls targeting them are generated.
+func TestAssertionsReceiveAndFallback(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/assertions/assert_receive_fallback.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.DeploymentOrder = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 500
+			config.Fuzzing.ReceiveCallProbability = 0.2
+			config.Fuzzing.FallbackCallProbability = 0.2
+			config.Fuzzing.Testing.StopOnFailedTest = false
+			config.Fuzzing.Testing.PropertyTesting.Enabled = false
+			config.Fuzzing.Testing.AssertionTesting.Enabled = true
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check that both the receive and fallback functions failed, while the method did not.
+			failedTestCases := f.fuzzer.TestCasesWithStatus(TestCaseStatusFailed)
+			failedTestNames := make([]string, 0)
+			for _, failedTestCase := range failedTestCases {
+				failedTestNames = append(failedTestNames, failedTestCase.Name())
+			}
+			assert.ElementsMatch(t, []string{
+				"Assertion Test: TestContract.receive()",
+				"Assertion Test: TestContract.fallback()",
+			}, failedTestNames)
+		},
+	})
+}
+
 // TestAssertionsAndProperties runs a test to property testing and assertion testing can both run in parallel.
 // This test does not stop on first failure and expects a failure from each after timeout.
 func TestAssertionsAndProperties(t *testing.T) {
@@ -457,6 +597,30 @@ func TestValueGenerationGenerateAllTypes(t *testing.T) {
 	})
 }
 
+// TestValueGenerationZeroValueSmokeTest runs a test to ensure the zero value smoke test reports methods which fail
+// when called with zero-valued arguments, such as access-controlled methods.
+func TestValueGenerationZeroValueSmokeTest(t *testing.T) {
+	runFuzzerTest(t, &fuzzerSolcFileTest{
+		filePath: "testdata/contracts/value_generation/zero_value_smoke_test.sol",
+		configUpdates: func(config *config.ProjectConfig) {
+			config.Fuzzing.DeploymentOrder = []string{"TestContract"}
+			config.Fuzzing.TestLimit = 100
+			config.Fuzzing.ZeroValueSmokeTest = true
+		},
+		method: func(f *fuzzerTestContext) {
+			// Start the fuzzer
+			err := f.fuzzer.Start()
+			assert.NoError(t, err)
+
+			// Check that only the access-controlled method was reported.
+			failedMethods := f.fuzzer.ZeroValueSmokeTestFailures()
+			if assert.Len(t, failedMethods, 1) {
+				assert.Contains(t, failedMethods[0], "TestContract.setXAsOwner(uint256)")
+			}
+		},
+	})
+}
+
 // TestValueGenerationSolving runs a series of tests to test the value generator can solve expected problems.
 func TestValueGenerationSolving(t *testing.T) {
 	// TODO: match_ints_xy is slower than match_uints_xy in the value generator because AST doesn't retain negative
@@ -643,3 +807,82 @@ func TestDeploymentOrderWithCoverage(t *testing.T) {
 		},
 	})
 }
+
+// TestValueGenerationStrategyChooser runs tests to ensure value generation strategies are only selected when weighted
+// in the configuration, and that dictionary entries are decoded from hex when prefixed.
+func TestValueGenerationStrategyChooser(t *testing.T) {
+	projectConfig, err := config.GetDefaultProjectConfig("crytic-compile")
+	assert.NoError(t, err)
+	valueGenerationConfig := projectConfig.Fuzzing.ValueGeneration
+	randomConfig := &valuegeneration.RandomValueGeneratorConfig{}
+
+	// By default, no strategies are weighted, so no chooser is created.
+	chooser, err := newValueGenerationStrategyChooser(valueGenerationConfig, randomConfig, valuegeneration.NewValueSet(), rand.New(rand.NewSource(1)))
+	assert.NoError(t, err)
+	assert.Nil(t, chooser)
+
+	// Weight only the dictionary strategy and verify it produces our decoded entry.
+	valueGenerationConfig.StrategyWeights.Dictionary = 1
+	valueGenerationConfig.Dictionary = []string{"0xdeadbeef"}
+	chooser, err = newValueGenerationStrategyChooser(valueGenerationConfig, randomConfig, valuegeneration.NewValueSet(), rand.New(rand.NewSource(1)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, chooser.ChoiceCount())
+	strategy, err := chooser.Choose()
+	assert.NoError(t, err)
+	b, ok := (*strategy).GenerateBytes(valuegeneration.NewRandomValueGenerator(randomConfig, rand.New(rand.NewSource(1))))
+	assert.True(t, ok)
+	assert.EqualValues(t, []byte{0xde, 0xad, 0xbe, 0xef}, b)
+}
+
+// TestAbiCompilationExistingAddress runs tests to ensure contracts loaded from an ABI definition with an address they
+// already exist at are targeted at that address rather than deployed, and that setup fails if no code exists there.
+func TestAbiCompilationExistingAddress(t *testing.T) {
+	contractDirectory := testutils.CopyToTestDirectory(t, "testdata/contracts/deployments/abi_existing_address/")
+	testutils.ExecuteInDirectory(t, contractDirectory, func() {
+		existingAddress := common.HexToAddress("0x1234567890123456789012345678901234567890")
+		for _, hasRuntimeBytecode := range []bool{true, false} {
+			// Create an ABI platform config targeting our contract at its existing address.
+			abiConfig := platforms.NewAbiCompilationConfig(contractDirectory)
+			abiConfig.Addresses["Counter"] = existingAddress.Hex()
+			if !hasRuntimeBytecode {
+				abiConfig.Target = t.TempDir()
+				abiData, err := os.ReadFile(filepath.Join(contractDirectory, "Counter.abi"))
+				assert.NoError(t, err)
+				err = os.WriteFile(filepath.Join(abiConfig.Target, "Counter.abi"), abiData, 0644)
+				assert.NoError(t, err)
+			}
+			compilationConfig, err := compilation.NewCompilationConfigFromPlatformConfig(abiConfig)
+			assert.NoError(t, err)
+			projectConfig := getFuzzerTestingProjectConfig(t, compilationConfig)
+			projectConfig.Fuzzing.Workers = 1
+			projectConfig.Fuzzing.TestLimit = 100
+
+			executeFuzzerTestMethodInternal(t, projectConfig, func(f *fuzzerTestContext) {
+				// Record the addresses of the contracts registered by workers.
+				var addedAddressesLock sync.Mutex
+				addedAddresses := make([]common.Address, 0)
+				f.fuzzer.Events.WorkerCreated.Subscribe(func(event FuzzerWorkerCreatedEvent) error {
+					event.Worker.Events.ContractAdded.Subscribe(func(event FuzzerWorkerContractAddedEvent) error {
+						addedAddressesLock.Lock()
+						defer addedAddressesLock.Unlock()
+						addedAddresses = append(addedAddresses, event.ContractAddress)
+						return nil
+					})
+					return nil
+				})
+
+				// Start the fuzzer. Without runtime bytecode, no code exists at the address, so setup should fail.
+				err := f.fuzzer.Start()
+				if hasRuntimeBytecode {
+					assert.NoError(t, err)
+					assert.NotEmpty(t, addedAddresses)
+					for _, addedAddress := range addedAddresses {
+						assert.EqualValues(t, existingAddress, addedAddress)
+					}
+				} else {
+					assert.ErrorContains(t, err, "no code exists there")
+				}
+			})
+		}
+	})
+}
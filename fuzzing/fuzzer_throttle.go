@@ -0,0 +1,65 @@
+package fuzzing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// callThrottle limits the rate at which calls are executed across all FuzzerWorker instances.
+type callThrottle struct {
+	// interval describes the minimum duration between two calls.
+	interval time.Duration
+
+	// next describes the earliest time at which the next call may be executed.
+	next time.Time
+
+	// lock is used for thread-synchronization when reserving call slots.
+	lock sync.Mutex
+}
+
+// newCallThrottle creates a callThrottle which allows at most the provided amount of calls per second.
+// Returns the callThrottle, or nil if callsPerSecond is not positive, indicating no throttling should occur.
+func newCallThrottle(callsPerSecond float64) *callThrottle {
+	if callsPerSecond <= 0 {
+		return nil
+	}
+	return &callThrottle{
+		interval: time.Duration(float64(time.Second) / callsPerSecond),
+	}
+}
+
+// wait blocks until the caller may execute its next call, or until the provided context is cancelled. Each caller
+// reserves its own slot, and the lock is not held while waiting, so callers which stop waiting (e.g. due to worker
+// resets or cancellation) never block others.
+// Returns true if the call may be executed, or false if the context was cancelled while waiting.
+func (t *callThrottle) wait(ctx context.Context) bool {
+	// If we are not throttling, the call may be executed immediately.
+	if t == nil {
+		return true
+	}
+
+	// Reserve the next available slot.
+	t.lock.Lock()
+	now := time.Now()
+	slot := t.next
+	if slot.Before(now) {
+		slot = now
+	}
+	t.next = slot.Add(t.interval)
+	t.lock.Unlock()
+
+	// Wait until our slot, or until we are cancelled.
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
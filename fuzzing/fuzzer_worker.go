@@ -1,6 +1,7 @@
 package fuzzing
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/crytic/medusa/chain"
 	"github.com/crytic/medusa/fuzzing/calls"
@@ -8,10 +9,14 @@ import (
 	"github.com/crytic/medusa/fuzzing/coverage"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/crytic/medusa/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"math/big"
 	"math/rand"
+	"sort"
+	"sync/atomic"
 )
 
 // FuzzerWorker describes a single thread worker utilizing its own go-ethereum test node to run property tests against
@@ -26,6 +31,10 @@ type FuzzerWorker struct {
 	chain *chain.TestChain
 	// coverageTracer describes the tracer used to collect coverage maps during fuzzing campaigns.
 	coverageTracer *coverage.CoverageTracer
+	// comparisonTracer describes the tracer used to collect comparison operands from reverted calls, if enabled.
+	comparisonTracer *comparisonTracer
+	// storageWriteTracer describes the tracer used to collect values written to storage by calls, if enabled.
+	storageWriteTracer *storageWriteTracer
 
 	// testingBaseBlockNumber refers to the block number at which all contracts for testing have been deployed, prior
 	// to any fuzzing activity. This block number is reverted to after testing each call sequence to reset state.
@@ -33,10 +42,30 @@ type FuzzerWorker struct {
 
 	// deployedContracts describes a mapping of deployed contractDefinitions and the addresses they were deployed to.
 	deployedContracts map[common.Address]*fuzzerTypes.Contract
+	// deployedContractAddresses describes the addresses of deployedContracts, sorted so random selections from it are
+	// deterministic.
+	deployedContractAddresses []common.Address
+	// dynamicContractTargets describes the addresses of contracts deployed after the worker's chain was set up which
+	// are targeted for fuzzing, in the order they were deployed.
+	dynamicContractTargets []common.Address
+	// untargetedContracts describes the addresses of deployed contracts which are tracked, but whose methods should not
+	// be targeted for fuzzing, as they exceeded the configured limit of dynamically deployed contract targets.
+	untargetedContracts map[common.Address]struct{}
+	// chainSetupComplete indicates whether the worker's chain has been set up, such that any further contract
+	// deployments are considered dynamic.
+	chainSetupComplete bool
+	// shrinking indicates whether the worker is currently executing call sequences to shrink a call sequence.
+	shrinking bool
 	// stateChangingMethods is a list of contract functions which are suspected of changing contract state
-	// (non-read-only). A sequence of calls is generated by the FuzzerWorker, targeting stateChangingMethods
-	// before executing tests.
+	// (non-read-only), along with any read-only functions targeted by assertion testing. A sequence of calls is
+	// generated by the FuzzerWorker, targeting stateChangingMethods before executing tests.
 	stateChangingMethods []fuzzerTypes.DeployedContractMethod
+	// receiveMethods is a list of the receive functions of deployed contracts which define one, targeted by value
+	// transfers without call data.
+	receiveMethods []fuzzerTypes.DeployedContractMethod
+	// fallbackMethods is a list of the fallback functions of deployed contracts which define one, targeted by calls
+	// with short call data or unknown method selectors.
+	fallbackMethods []fuzzerTypes.DeployedContractMethod
 
 	// randomProvider provides random data as inputs to decisions throughout the worker.
 	randomProvider *rand.Rand
@@ -69,6 +98,7 @@ func newFuzzerWorker(fuzzer *Fuzzer, workerIndex int, randomProvider *rand.Rand)
 		workerIndex:          workerIndex,
 		fuzzer:               fuzzer,
 		deployedContracts:    make(map[common.Address]*fuzzerTypes.Contract),
+		untargetedContracts:  make(map[common.Address]struct{}),
 		stateChangingMethods: make([]fuzzerTypes.DeployedContractMethod, 0),
 		coverageTracer:       nil,
 		randomProvider:       randomProvider,
@@ -84,6 +114,12 @@ func (fw *FuzzerWorker) WorkerIndex() int {
 	return fw.workerIndex
 }
 
+// IsShrinking indicates whether this FuzzerWorker is currently executing call sequences to shrink a call sequence,
+// rather than testing newly generated or mutated ones.
+func (fw *FuzzerWorker) IsShrinking() bool {
+	return fw.shrinking
+}
+
 // workerMetrics returns the fuzzerWorkerMetrics for this specific worker.
 func (fw *FuzzerWorker) workerMetrics() *fuzzerWorkerMetrics {
 	return &fw.fuzzer.metrics.workerMetrics[fw.workerIndex]
@@ -148,8 +184,31 @@ func (fw *FuzzerWorker) onChainContractDeploymentAddedEvent(event chain.Contract
 		}
 	}
 
+	return fw.registerDeployedContract(event.Contract.Address, matchedDefinition)
+}
+
+// registerDeployedContract updates the list of deployed contracts the worker should use for fuzz testing with a
+// contract matching the provided definition, which is deployed at the provided address.
+// Returns an error if one occurred.
+func (fw *FuzzerWorker) registerDeployedContract(contractAddress common.Address, contractDefinition *fuzzerTypes.Contract) error {
 	// Set our deployed contract address in our deployed contract lookup, so we can reference it later.
-	fw.deployedContracts[event.Contract.Address] = matchedDefinition
+	if _, previouslyRegistered := fw.deployedContracts[contractAddress]; !previouslyRegistered {
+		fw.deployedContractAddresses = append(fw.deployedContractAddresses, contractAddress)
+		sort.Slice(fw.deployedContractAddresses, func(i, j int) bool {
+			return bytes.Compare(fw.deployedContractAddresses[i].Bytes(), fw.deployedContractAddresses[j].Bytes()) < 0
+		})
+	}
+	fw.deployedContracts[contractAddress] = contractDefinition
+
+	// If this contract was deployed dynamically, only target it if we have not exceeded our limit of dynamic targets.
+	if fw.chainSetupComplete {
+		maxTargets := fw.fuzzer.config.Fuzzing.MaxDynamicContractTargets
+		if maxTargets > 0 && uint64(len(fw.dynamicContractTargets)) >= maxTargets {
+			fw.untargetedContracts[contractAddress] = struct{}{}
+		} else {
+			fw.dynamicContractTargets = append(fw.dynamicContractTargets, contractAddress)
+		}
+	}
 
 	// Update our state changing methods
 	fw.updateStateChangingMethods()
@@ -157,8 +216,8 @@ func (fw *FuzzerWorker) onChainContractDeploymentAddedEvent(event chain.Contract
 	// Emit an event indicating the worker detected a new contract deployment on its chain.
 	err := fw.Events.ContractAdded.Publish(FuzzerWorkerContractAddedEvent{
 		Worker:             fw,
-		ContractAddress:    event.Contract.Address,
-		ContractDefinition: matchedDefinition,
+		ContractAddress:    contractAddress,
+		ContractDefinition: contractDefinition,
 	})
 	if err != nil {
 		return fmt.Errorf("error returned by an event handler when a worker emitted a deployed contract added event: %v", err)
@@ -166,6 +225,27 @@ func (fw *FuzzerWorker) onChainContractDeploymentAddedEvent(event chain.Contract
 	return nil
 }
 
+// registerExistingContracts registers the contracts in the deployment order which already exist at an address on the
+// worker's chain, as they are not deployed, so no deployment events are emitted for them.
+// Returns an error if one occurred.
+func (fw *FuzzerWorker) registerExistingContracts() error {
+	for _, contractName := range fw.fuzzer.config.Fuzzing.DeploymentOrder {
+		for _, contract := range fw.fuzzer.contractDefinitions {
+			existingAddress := contract.CompiledContract().ExistingAddress
+			if contract.Name() != contractName || existingAddress == nil {
+				continue
+			}
+			fw.valueSet.AddAddress(*existingAddress)
+			err := fw.registerDeployedContract(*existingAddress, contract)
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
 // onChainContractDeploymentRemovedEvent is the event callback used when the chain detects removal of a previously
 // deployed contract. It updates the list of deployed contracts the worker should use for fuzz testing.
 func (fw *FuzzerWorker) onChainContractDeploymentRemovedEvent(event chain.ContractDeploymentsRemovedEvent) error {
@@ -181,6 +261,13 @@ func (fw *FuzzerWorker) onChainContractDeploymentRemovedEvent(event chain.Contra
 
 	// Remove the contract from our deployed contracts mapping the worker maintains.
 	delete(fw.deployedContracts, event.Contract.Address)
+	if i := slices.Index(fw.deployedContractAddresses, event.Contract.Address); i >= 0 {
+		fw.deployedContractAddresses = slices.Delete(fw.deployedContractAddresses, i, i+1)
+	}
+	delete(fw.untargetedContracts, event.Contract.Address)
+	if i := slices.Index(fw.dynamicContractTargets, event.Contract.Address); i >= 0 {
+		fw.dynamicContractTargets = slices.Delete(fw.dynamicContractTargets, i, i+1)
+	}
 
 	// Update our state changing methods
 	fw.updateStateChangingMethods()
@@ -202,19 +289,90 @@ func (fw *FuzzerWorker) onChainContractDeploymentRemovedEvent(event chain.Contra
 func (fw *FuzzerWorker) updateStateChangingMethods() {
 	// Clear our list of state changing methods
 	fw.stateChangingMethods = make([]fuzzerTypes.DeployedContractMethod, 0)
+	fw.receiveMethods = make([]fuzzerTypes.DeployedContractMethod, 0)
+	fw.fallbackMethods = make([]fuzzerTypes.DeployedContractMethod, 0)
 
 	// Loop through each deployed contract
 	for contractAddress, contractDefinition := range fw.deployedContracts {
+		// If the contract is configured to never be called, skip it.
+		if isExcludedContract(contractDefinition, fw.fuzzer.config.Fuzzing) || slices.Contains(fw.fuzzer.excludedContractAddresses, contractAddress) {
+			continue
+		}
+
+		// If the contract was deployed beyond our limit of dynamic contract targets, skip it.
+		if _, untargeted := fw.untargetedContracts[contractAddress]; untargeted {
+			continue
+		}
+
+		// Track any receive or fallback functions, which are not listed among the contract's methods.
+		contractAbi := contractDefinition.CompiledContract().Abi
+		if contractAbi.HasReceive() {
+			fw.receiveMethods = append(fw.receiveMethods, fuzzerTypes.DeployedContractMethod{Address: contractAddress, Contract: contractDefinition, Method: contractAbi.Receive})
+		}
+		if contractAbi.HasFallback() {
+			fw.fallbackMethods = append(fw.fallbackMethods, fuzzerTypes.DeployedContractMethod{Address: contractAddress, Contract: contractDefinition, Method: contractAbi.Fallback})
+		}
+
 		// If we deployed the contract, also enumerate property tests and state changing methods.
 		for _, method := range contractDefinition.CompiledContract().Abi.Methods {
-			if !method.IsConstant() {
-				// Any non-constant method should be tracked as a state changing method.
+			if isFuzzedMethod(contractDefinition, method, fw.fuzzer.config.Fuzzing) {
+				// Any non-constant or assertion tested method should be tracked as a state changing method.
 				fw.stateChangingMethods = append(fw.stateChangingMethods, fuzzerTypes.DeployedContractMethod{Address: contractAddress, Contract: contractDefinition, Method: method})
+				if fw.fuzzer.methodExecutions != nil {
+					fw.fuzzer.methodExecutions.registerMethod(fw.workerIndex, contractDefinition.Name(), &method)
+				}
 			}
 		}
 	}
 }
 
+// runZeroValueSmokeTest calls every state changing method once with the zero value for each of its arguments, without
+// committing any state changes, and records the methods whose calls failed so they are reported with the fuzzer's
+// results.
+// Returns an error if one occurred.
+func (fw *FuzzerWorker) runZeroValueSmokeTest() error {
+	failedMethods := make([]string, 0)
+	for _, method := range fw.stateChangingMethods {
+		// Generate zero values for every argument and encode our call data.
+		args := make([]any, len(method.Method.Inputs))
+		for i, input := range method.Method.Inputs {
+			args[i] = valuegeneration.GenerateZeroAbiValue(&input.Type)
+		}
+		data, err := method.Contract.CompiledContract().Abi.Pack(method.Method.Name, args...)
+		if err != nil {
+			return fmt.Errorf("failed to encode zero value smoke test call to '%s.%s': %v", method.Contract.Name(), method.Method.Sig, err)
+		}
+
+		// Execute the call without committing it.
+		msg := calls.NewCallMessage(fw.fuzzer.senders[0], &method.Address, 0, big.NewInt(0), fw.fuzzer.config.Fuzzing.TransactionGasLimit, nil, nil, nil, data)
+		msg.FillFromTestChainProperties(fw.chain)
+		executionResult, err := fw.chain.CallContract(msg, nil)
+		if err != nil {
+			return fmt.Errorf("failed to perform zero value smoke test call to '%s.%s': %v", method.Contract.Name(), method.Method.Sig, err)
+		}
+		if executionResult.Failed() {
+			failedMethods = append(failedMethods, fmt.Sprintf("%s.%s (%v)", method.Contract.Name(), method.Method.Sig, executionResult.Err))
+		}
+	}
+
+	// Record the methods which failed, sorted so the output is deterministic.
+	sort.Strings(failedMethods)
+	fw.fuzzer.zeroValueSmokeTestFailures = failedMethods
+	return nil
+}
+
+// checkMethodInputsNotRecursive verifies that none of the provided method's input types are recursive, as values
+// cannot be generated for them.
+// Returns an error if any input type is recursive.
+func checkMethodInputsNotRecursive(method *abi.Method) error {
+	for _, input := range method.Inputs {
+		if err := valuegeneration.CheckAbiTypeNotRecursive(&input.Type); err != nil {
+			return fmt.Errorf("input '%s' of method '%s' has an unsupported type: %v", input.Name, method.Sig, err)
+		}
+	}
+	return nil
+}
+
 // testCallSequence tests a call message sequence against the underlying FuzzerWorker's Chain and calls every
 // CallSequenceTestFunc registered with the parent Fuzzer to update any test results. If any call message in the
 // sequence is nil, a call message will be created in its place, targeting a state changing method of a contract
@@ -241,6 +399,10 @@ func (fw *FuzzerWorker) testCallSequence() (calls.CallSequence, []ShrinkCallSequ
 
 	// Our "fetch next call" method will generate new calls as needed, if we are generating a new sequence.
 	fetchElementFunc := func(currentIndex int) (*calls.CallSequenceElement, error) {
+		// If we are throttling calls, wait for our turn. If we were cancelled while waiting, stop executing.
+		if !fw.fuzzer.callThrottle.wait(fw.fuzzer.ctx) {
+			return nil, nil
+		}
 		return fw.sequenceGenerator.PopSequenceElement()
 	}
 
@@ -248,13 +410,30 @@ func (fw *FuzzerWorker) testCallSequence() (calls.CallSequence, []ShrinkCallSequ
 	// request for a shrunk call sequence, we exit our call sequence execution immediately to go fulfill the shrink
 	// request.
 	executionCheckFunc := func(currentlyExecutedSequence calls.CallSequence) (bool, error) {
-		// Check for updates to coverage and corpus.
-		// If we detect coverage changes, add this sequence with weight as 1 + sequences tested (to avoid zero weights)
-		err := fw.fuzzer.corpus.CheckSequenceCoverageAndUpdate(currentlyExecutedSequence, fw.getNewCorpusCallSequenceWeight(), true)
+		// Check for feedback (e.g. coverage) updates and update the corpus if this sequence was interesting.
+		err := fw.checkFeedbackAndUpdateCorpus(currentlyExecutedSequence)
 		if err != nil {
 			return true, err
 		}
 
+		// Add any values emitted in event logs by the last call to our value set, so they may be used in generation.
+		lastCall := currentlyExecutedSequence[len(currentlyExecutedSequence)-1]
+		if lastCall.ChainReference != nil {
+			for _, log := range lastCall.ChainReference.MessageResults().Receipt.Logs {
+				fw.valueSet.AddFromLog(log)
+			}
+
+			// Add any values compared against in reverted call frames, as they may describe unmet preconditions.
+			for _, operand := range getComparisonTracerResults(lastCall.ChainReference.MessageResults()) {
+				fw.valueSet.AddFromComparisonOperand(operand)
+			}
+
+			// Add any values written to storage, as they may describe addresses or amounts held in contract state.
+			for _, value := range getStorageWriteTracerResults(lastCall.ChainReference.MessageResults()) {
+				fw.valueSet.AddFromStorageWrite(value)
+			}
+		}
+
 		// Loop through each test function, signal our worker tested a call, and collect any requests to shrink
 		// this call sequence.
 		for _, callSequenceTestFunc := range fw.fuzzer.Hooks.CallSequenceTestFuncs {
@@ -280,6 +459,14 @@ func (fw *FuzzerWorker) testCallSequence() (calls.CallSequence, []ShrinkCallSequ
 	// Execute our call sequence.
 	testedCallSequence, err := calls.ExecuteCallSequenceIteratively(fw.chain, fetchElementFunc, executionCheckFunc)
 
+	// Memory optimization: Remove the coverage recorded for each call now that every hook has been able to observe it,
+	// to free memory later.
+	for _, element := range testedCallSequence {
+		if element != nil && element.ChainReference != nil {
+			coverage.RemoveCoverageTracerResults(element.ChainReference.MessageResults())
+		}
+	}
+
 	// If we encountered an error, report it.
 	if err != nil {
 		return nil, nil, err
@@ -302,10 +489,17 @@ func (fw *FuzzerWorker) testCallSequence() (calls.CallSequence, []ShrinkCallSequ
 }
 
 // shrinkCallSequence takes a provided call sequence and attempts to shrink it by looking for redundant
-// calls which can be removed that continue to satisfy the provided shrink verifier.
+// calls which can be removed that continue to satisfy the provided shrink verifier. Integer arguments of the remaining
+// calls are then shrunk where a smaller value continues to satisfy the verifier.
 // Returns a call sequence that was optimized to include as little calls as possible to trigger the
 // expected conditions, or an error if one occurred.
 func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shrinkRequest ShrinkCallSequenceRequest) (calls.CallSequence, error) {
+	// Indicate that we are shrinking for the duration of this method, so feedback providers can ignore shrink replays.
+	fw.shrinking = true
+	defer func() {
+		fw.shrinking = false
+	}()
+
 	// In case of any error, we defer an operation to revert our chain state. We purposefully ignore errors from it to
 	// prioritize any others which occurred.
 	var err error
@@ -318,14 +512,11 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 	// Define a variable to track our most optimized sequence across all optimization iterations.
 	optimizedSequence := callSequence
 
-	for i := 0; i < len(optimizedSequence); {
-		// Recreate our current optimized sequence without the item at this index
-		possibleShrunkSequence, err := optimizedSequence.Clone()
-		if err != nil {
-			return nil, err
-		}
-		possibleShrunkSequence = append(possibleShrunkSequence[:i], possibleShrunkSequence[i+1:]...)
-
+	// testShrunkSequence executes a possible shrunk call sequence and checks whether it satisfies our shrink verifier,
+	// reverting the chain state afterwards.
+	// Returns the tested call sequence, a boolean indicating whether it satisfied the verifier, or an error if one
+	// occurred.
+	testShrunkSequence := func(possibleShrunkSequence calls.CallSequence) (calls.CallSequence, bool, error) {
 		// Our "fetch next call method" method will simply fetch and fix the call message in case any fields are not correct due to shrinking.
 		fetchElementFunc := func(currentIndex int) (*calls.CallSequenceElement, error) {
 			// If we are at the end of our sequence, return nil indicating we should stop executing.
@@ -341,9 +532,9 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 		// request for a shrunk call sequence, we exit our call sequence execution immediately to go fulfill the shrink
 		// request.
 		executionCheckFunc := func(currentlyExecutedSequence calls.CallSequence) (bool, error) {
-			// Check for updates to coverage and corpus (using only the section of the sequence we tested so far).
-			// If we detect coverage changes, add this sequence.
-			err := fw.fuzzer.corpus.CheckSequenceCoverageAndUpdate(currentlyExecutedSequence, fw.getNewCorpusCallSequenceWeight(), true)
+			// Check for feedback (e.g. coverage) updates and corpus (using only the section of the sequence we tested
+			// so far). If this sequence was interesting, add it.
+			err := fw.checkFeedbackAndUpdateCorpus(currentlyExecutedSequence)
 			if err != nil {
 				return true, err
 			}
@@ -359,12 +550,12 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 		// Execute our call sequence.
 		testedPossibleShrunkSequence, err := calls.ExecuteCallSequenceIteratively(fw.chain, fetchElementFunc, executionCheckFunc)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// If our fuzzer context is done, exit out immediately without results.
 		if utils.CheckContextDone(fw.fuzzer.ctx) {
-			return nil, nil
+			return nil, false, nil
 		}
 
 		// Check if our verifier signalled that we met our conditions
@@ -372,14 +563,38 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 		if len(testedPossibleShrunkSequence) > 0 {
 			validShrunkSequence, err = shrinkRequest.VerifierFunction(fw, testedPossibleShrunkSequence)
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 		}
 
 		// After testing the sequence, we'll want to rollback changes to reset our testing state.
 		if err = fw.chain.RevertToBlockNumber(fw.testingBaseBlockNumber); err != nil {
+			return nil, false, err
+		}
+		return testedPossibleShrunkSequence, validShrunkSequence, nil
+	}
+
+	for i := 0; i < len(optimizedSequence); {
+		// If our fuzzer context is done, exit out immediately without results.
+		if utils.CheckContextDone(fw.fuzzer.ctx) {
+			return nil, nil
+		}
+
+		// Recreate our current optimized sequence without the item at this index
+		possibleShrunkSequence, err := optimizedSequence.Clone()
+		if err != nil {
 			return nil, err
 		}
+		possibleShrunkSequence = append(possibleShrunkSequence[:i], possibleShrunkSequence[i+1:]...)
+
+		// Test the sequence, exiting without results if our fuzzer context is done.
+		testedPossibleShrunkSequence, validShrunkSequence, err := testShrunkSequence(possibleShrunkSequence)
+		if err != nil {
+			return nil, err
+		}
+		if utils.CheckContextDone(fw.fuzzer.ctx) {
+			return nil, nil
+		}
 
 		// If this current sequence satisfied our conditions, set it as our optimized sequence.
 		if validShrunkSequence {
@@ -390,6 +605,67 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 		}
 	}
 
+	// Attempt to shrink the integer arguments of each remaining call by reinterpreting their bit pattern across the
+	// signed boundary, keeping any reinterpretation which yields a smaller value and still satisfies our conditions.
+	for i := 0; i < len(optimizedSequence); i++ {
+		abiValues := optimizedSequence[i].Call.MsgDataAbiValues
+		if abiValues == nil || abiValues.Method == nil || len(abiValues.Method.Inputs) != len(abiValues.InputValues) {
+			continue
+		}
+		for j, input := range abiValues.Method.Inputs {
+			// If our fuzzer context is done, exit out immediately without results.
+			if utils.CheckContextDone(fw.fuzzer.ctx) {
+				return nil, nil
+			}
+
+			// Determine if this argument is an integer which can be shrunk by reinterpretation.
+			if input.Type.T != abi.IntTy && input.Type.T != abi.UintTy {
+				continue
+			}
+			value, ok := optimizedSequence[i].Call.MsgDataAbiValues.InputValues[j].(*big.Int)
+			if !ok {
+				continue
+			}
+			shrunkValue, shrunk := valuegeneration.ShrinkIntegerBySignReinterpretation(value, input.Type.T == abi.IntTy, input.Type.Size)
+			if !shrunk {
+				continue
+			}
+
+			// Recreate our current optimized sequence with the reinterpreted argument and test it.
+			possibleShrunkSequence, err := optimizedSequence.Clone()
+			if err != nil {
+				return nil, err
+			}
+			possibleShrunkSequence[i].Call.MsgDataAbiValues.InputValues[j] = shrunkValue
+			testedPossibleShrunkSequence, validShrunkSequence, err := testShrunkSequence(possibleShrunkSequence)
+			if err != nil {
+				return nil, err
+			}
+			if utils.CheckContextDone(fw.fuzzer.ctx) {
+				return nil, nil
+			}
+			if validShrunkSequence && len(testedPossibleShrunkSequence) == len(optimizedSequence) {
+				optimizedSequence = testedPossibleShrunkSequence
+			}
+		}
+	}
+
+	// Verify our finalized shrunken sequence still reproduces the original failure. If it could not be verified,
+	// we fall back to the original call sequence, which is known to have triggered it.
+	verified, err := fw.verifyShrunkCallSequence(optimizedSequence, shrinkRequest)
+	if err != nil {
+		return nil, err
+	}
+	if utils.CheckContextDone(fw.fuzzer.ctx) {
+		return nil, nil
+	}
+	if !verified {
+		optimizedSequence = callSequence
+	}
+
+	// Pin the block context each call was executed in, so the result is replayed in the same block contexts.
+	optimizedSequence.PinBlockContexts()
+
 	// If the shrink request wanted the sequence recorded in the corpus, do so now.
 	if shrinkRequest.RecordResultInCorpus {
 		err = fw.fuzzer.corpus.AddTestResultCallSequence(optimizedSequence, fw.getNewCorpusCallSequenceWeight(), true)
@@ -423,6 +699,62 @@ func (fw *FuzzerWorker) shrinkCallSequence(callSequence calls.CallSequence, shri
 	return optimizedSequence, err
 }
 
+// verifyShrunkCallSequence re-executes a finalized shrunken call sequence and checks it against the provided
+// ShrinkCallSequenceRequest's PostShrinkVerifierFunction. If verification fails, it is retried up to the configured
+// amount of times to account for non-deterministic failures. Chain state is reverted after each attempt.
+// Returns a boolean indicating whether the call sequence was verified, or an error if one occurred.
+func (fw *FuzzerWorker) verifyShrunkCallSequence(callSequence calls.CallSequence, shrinkRequest ShrinkCallSequenceRequest) (bool, error) {
+	// If no post-shrink verifier was provided, there is nothing to verify.
+	if shrinkRequest.PostShrinkVerifierFunction == nil {
+		return true, nil
+	}
+
+	attempts := fw.fuzzer.config.Fuzzing.Testing.ShrinkVerificationRetries + 1
+	for i := 0; i < attempts; i++ {
+		// If our fuzzer context is done, exit out immediately without results.
+		if utils.CheckContextDone(fw.fuzzer.ctx) {
+			return false, nil
+		}
+
+		// Execute the call sequence and check if it still satisfies our verifier.
+		_, err := calls.ExecuteCallSequence(fw.chain, callSequence)
+		if err != nil {
+			return false, err
+		}
+		verified, err := shrinkRequest.PostShrinkVerifierFunction(fw, callSequence)
+		if err != nil {
+			return false, err
+		}
+
+		// Rollback our changes to reset our testing state.
+		if err = fw.chain.RevertToBlockNumber(fw.testingBaseBlockNumber); err != nil {
+			return false, err
+		}
+
+		if verified {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// exceedsMemoryLimit checks whether the memory used by the process exceeds the budget defined by the WorkerMemoryLimit
+// config. As heap memory can only be measured for the entire process, a single worker's usage is approximated as an
+// equal share of the heap across all workers. The heap usage last sampled by the Fuzzer is used, as reading it stops
+// the world.
+// Returns a boolean indicating whether the worker exceeded its memory budget. If no budget is set, this returns false.
+func (fw *FuzzerWorker) exceedsMemoryLimit() bool {
+	// If no memory limit was provided, the worker is only reset by WorkerResetLimit.
+	memoryLimit := fw.fuzzer.config.Fuzzing.WorkerMemoryLimit
+	if memoryLimit == 0 {
+		return false
+	}
+
+	// Obtain our heap usage and compare our share of it against our limit (in megabytes).
+	workerHeapAlloc := atomic.LoadUint64(&fw.fuzzer.heapAlloc) / uint64(fw.fuzzer.config.Fuzzing.Workers)
+	return workerHeapAlloc > memoryLimit*1024*1024
+}
+
 // run takes a base Chain in a setup state ready for testing, clones it, and begins executing fuzzed transaction calls
 // and asserting properties are upheld. This runs until Fuzzer.ctx cancels the operation.
 // Returns a boolean indicating whether Fuzzer.ctx has indicated we cancel the operation, and an error if one occurred.
@@ -450,6 +782,18 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 			fw.coverageTracer = coverage.NewCoverageTracer()
 			initializedChain.AddTracer(fw.coverageTracer, true, false)
 		}
+
+		// If we are collecting comparison operands from reverted calls, create a tracer to do so.
+		if fw.fuzzer.config.Fuzzing.ValueGeneration.CollectRevertComparisonValues {
+			fw.comparisonTracer = newComparisonTracer()
+			initializedChain.AddTracer(fw.comparisonTracer, true, false)
+		}
+
+		// If we are collecting values written to storage, create a tracer to do so.
+		if fw.fuzzer.config.Fuzzing.ValueGeneration.CollectStorageWriteValues {
+			fw.storageWriteTracer = newStorageWriteTracer()
+			initializedChain.AddTracer(fw.storageWriteTracer, true, false)
+		}
 		return nil
 	})
 
@@ -458,6 +802,12 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 		return false, err
 	}
 
+	// Register any contracts which already existed on our chain, rather than being deployed during setup.
+	err = fw.registerExistingContracts()
+	if err != nil {
+		return false, err
+	}
+
 	// Emit an event indicating the worker has setup its chain.
 	err = fw.Events.FuzzerWorkerChainSetup.Publish(FuzzerWorkerChainSetupEvent{
 		Worker: fw,
@@ -471,8 +821,19 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 	fw.workerMetrics().workerStartupCount.Add(fw.workerMetrics().workerStartupCount, big.NewInt(1))
 
 	// Save the current block number as all contracts have been deployed at this point, and we'll want to revert
-	// to this state between testing.
+	// to this state between testing. Any contracts deployed from this point on are considered dynamic deployments.
 	fw.testingBaseBlockNumber = fw.chain.HeadBlockNumber()
+	fw.chainSetupComplete = true
+
+	// If enabled, perform our zero value smoke test once per campaign, prior to fuzzing.
+	if fw.fuzzer.config.Fuzzing.ZeroValueSmokeTest {
+		fw.fuzzer.zeroValueSmokeTestOnce.Do(func() {
+			err = fw.runZeroValueSmokeTest()
+		})
+		if err != nil {
+			return false, err
+		}
+	}
 
 	// Enter the main fuzzing loop, restricting our memory database size based on our config variable.
 	// When the limit is reached, we exit this method gracefully, which will cause the fuzzing to recreate
@@ -517,6 +878,11 @@ func (fw *FuzzerWorker) run(baseTestChain *chain.TestChain) (bool, error) {
 		// Update our sequences tested metrics
 		fw.workerMetrics().sequencesTested.Add(fw.workerMetrics().sequencesTested, big.NewInt(1))
 		sequencesTested++
+
+		// If we exceeded our memory budget, exit early so this worker is recreated with a fresh memory database.
+		if fw.exceedsMemoryLimit() {
+			break
+		}
 	}
 
 	// We have not cancelled fuzzing operations, but this worker exited, signalling for it to be regenerated.
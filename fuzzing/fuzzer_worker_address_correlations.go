@@ -0,0 +1,88 @@
+package fuzzing
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddressCorrelationKind describes the relationship an address argument of a generated call should have.
+type AddressCorrelationKind int
+
+const (
+	// AddressCorrelationEqualToArgument indicates the address argument should be equal to another address argument of
+	// the same call, described by AddressCorrelation.RelatedArgumentIndex.
+	AddressCorrelationEqualToArgument AddressCorrelationKind = iota
+	// AddressCorrelationSender indicates the address argument should be the sender of the call.
+	AddressCorrelationSender
+	// AddressCorrelationSenderAccount indicates the address argument should be one of the fuzzer's sender accounts
+	// (e.g. an account which may hold a balance).
+	AddressCorrelationSenderAccount
+	// AddressCorrelationDeployedContract indicates the address argument should be the address of a deployed contract.
+	AddressCorrelationDeployedContract
+)
+
+// AddressCorrelation describes a hint that an address argument of calls to a method should be generated with a given
+// relationship to the call, rather than independently of other arguments. For example, the "to" argument of a token
+// transfer may be correlated with a deployed contract, or the "from" argument of transferFrom with the sender.
+type AddressCorrelation struct {
+	// Kind describes the relationship the address argument should have.
+	Kind AddressCorrelationKind
+	// ArgumentIndex describes the index of the address argument which the correlation applies to.
+	ArgumentIndex int
+	// RelatedArgumentIndex describes the index of the address argument the argument should be equal to, if Kind is
+	// AddressCorrelationEqualToArgument.
+	RelatedArgumentIndex int
+	// Probability describes the probability that the correlation is applied to a generated call, so that calls
+	// which do not satisfy it are still generated occasionally.
+	Probability float32
+}
+
+// correlateAddressArguments applies the AddressCorrelation hints registered for the provided method in
+// FuzzerHooks.MethodAddressCorrelations to its generated input values, replacing address arguments accordingly.
+// Returns an error if a correlation does not describe address arguments of the method.
+func (g *CallSequenceGenerator) correlateAddressArguments(contractName string, method *abi.Method, sender common.Address, args []any) error {
+	// Obtain the correlations for this method, if there are any.
+	correlations := g.worker.fuzzer.Hooks.MethodAddressCorrelations[MethodHookKey(contractName, method)]
+	for _, correlation := range correlations {
+		// Verify the correlation targets an address argument.
+		if !isAddressArgument(method, correlation.ArgumentIndex) {
+			return fmt.Errorf("address correlation for method '%v' targets argument %d, which is not an address", method.Sig, correlation.ArgumentIndex)
+		}
+
+		// Determine whether we should apply this correlation.
+		if g.worker.randomProvider.Float32() >= correlation.Probability {
+			continue
+		}
+
+		// Replace the argument according to the kind of correlation.
+		switch correlation.Kind {
+		case AddressCorrelationEqualToArgument:
+			if !isAddressArgument(method, correlation.RelatedArgumentIndex) {
+				return fmt.Errorf("address correlation for method '%v' relates to argument %d, which is not an address", method.Sig, correlation.RelatedArgumentIndex)
+			}
+			args[correlation.ArgumentIndex] = args[correlation.RelatedArgumentIndex]
+		case AddressCorrelationSender:
+			args[correlation.ArgumentIndex] = sender
+		case AddressCorrelationSenderAccount:
+			senders := g.worker.fuzzer.senders
+			if len(senders) > 0 {
+				args[correlation.ArgumentIndex] = senders[g.worker.randomProvider.Intn(len(senders))]
+			}
+		case AddressCorrelationDeployedContract:
+			addresses := g.worker.deployedContractAddresses
+			if len(addresses) > 0 {
+				args[correlation.ArgumentIndex] = addresses[g.worker.randomProvider.Intn(len(addresses))]
+			}
+		default:
+			return fmt.Errorf("address correlation for method '%v' has an unknown kind: %d", method.Sig, correlation.Kind)
+		}
+	}
+	return nil
+}
+
+// isAddressArgument checks whether the input argument of the provided method at the given index is an address.
+func isAddressArgument(method *abi.Method, index int) bool {
+	return index >= 0 && index < len(method.Inputs) && method.Inputs[index].Type.T == abi.AddressTy
+}
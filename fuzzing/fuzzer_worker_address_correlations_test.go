@@ -0,0 +1,79 @@
+package fuzzing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/crytic/medusa/chain"
+	chainTypes "github.com/crytic/medusa/chain/types"
+	"github.com/crytic/medusa/compilation/types"
+	"github.com/crytic/medusa/fuzzing/config"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCorrelateAddressArguments runs tests to ensure address correlations registered for a method replace its address
+// arguments, selecting deployed contracts from the addresses tracked as contracts are deployed and removed.
+func TestCorrelateAddressArguments(t *testing.T) {
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	generator := newTestCallSequenceGenerator(t, projectConfig)
+
+	addressType, err := abi.NewType("address", "", nil)
+	assert.NoError(t, err)
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("transferFrom", "transferFrom", abi.Function, "nonpayable", false, false, abi.Arguments{
+		{Name: "from", Type: addressType},
+		{Name: "to", Type: addressType},
+		{Name: "amount", Type: uint256Type},
+	}, nil)
+	generator.worker.fuzzer.Hooks.MethodAddressCorrelations = map[string][]AddressCorrelation{
+		MethodHookKey("Token", &method): {
+			{Kind: AddressCorrelationSender, ArgumentIndex: 0, Probability: 1},
+			{Kind: AddressCorrelationDeployedContract, ArgumentIndex: 1, Probability: 1},
+		},
+	}
+
+	// Register deployed contracts out of order, verifying their addresses are tracked in sorted order.
+	contract := fuzzerTypes.NewContract("Token", "contracts/Token.sol", &types.CompiledContract{}, nil)
+	deployedAddresses := []common.Address{common.HexToAddress("0x3000"), common.HexToAddress("0x1000"), common.HexToAddress("0x2000")}
+	for _, address := range deployedAddresses {
+		assert.NoError(t, generator.worker.registerDeployedContract(address, contract))
+	}
+	assert.NoError(t, generator.worker.registerDeployedContract(deployedAddresses[0], contract))
+	assert.EqualValues(t, []common.Address{deployedAddresses[1], deployedAddresses[2], deployedAddresses[0]}, generator.worker.deployedContractAddresses)
+
+	// Removed contracts are no longer tracked.
+	err = generator.worker.onChainContractDeploymentRemovedEvent(chain.ContractDeploymentsRemovedEvent{
+		Contract: &chainTypes.DeployedContractBytecode{Address: deployedAddresses[2]},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []common.Address{deployedAddresses[1], deployedAddresses[0]}, generator.worker.deployedContractAddresses)
+
+	// Correlated arguments are replaced by the sender and a deployed contract, while others are unaffected.
+	sender := common.HexToAddress("0x10000")
+	selected := make(map[common.Address]bool)
+	for i := 0; i < 100; i++ {
+		args := []any{common.Address{}, common.Address{}, big.NewInt(1)}
+		assert.NoError(t, generator.correlateAddressArguments("Token", &method, sender, args))
+		assert.EqualValues(t, sender, args[0])
+		assert.Contains(t, []common.Address{deployedAddresses[1], deployedAddresses[0]}, args[1])
+		assert.EqualValues(t, big.NewInt(1), args[2])
+		selected[args[1].(common.Address)] = true
+	}
+	assert.Len(t, selected, 2)
+
+	// Methods of other contracts are unaffected.
+	args := []any{common.Address{}, common.Address{}, big.NewInt(1)}
+	assert.NoError(t, generator.correlateAddressArguments("OtherToken", &method, sender, args))
+	assert.EqualValues(t, common.Address{}, args[0])
+
+	// Correlations targeting arguments which are not addresses are rejected.
+	generator.worker.fuzzer.Hooks.MethodAddressCorrelations[MethodHookKey("Token", &method)] = []AddressCorrelation{
+		{Kind: AddressCorrelationSender, ArgumentIndex: 2, Probability: 1},
+	}
+	assert.Error(t, generator.correlateAddressArguments("Token", &method, sender, args))
+}
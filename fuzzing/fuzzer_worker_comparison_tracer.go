@@ -0,0 +1,127 @@
+package fuzzing
+
+import (
+	"math/big"
+
+	"github.com/crytic/medusa/chain/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// comparisonTracerResultsKey describes the key to use when storing tracer results in call message results, or when
+// querying them.
+const comparisonTracerResultsKey = "ComparisonTracerResults"
+
+// maxComparisonOperandsPerTx describes the maximum amount of comparison operands a comparisonTracer records for a
+// single transaction, so loops over comparisons do not flood the value set. Only the most recent operands of each call
+// frame are retained, as those closest to a revert are the ones most likely to describe the unmet precondition, while
+// earlier ones (e.g. of method dispatching or argument decoding) are not.
+const maxComparisonOperandsPerTx = 64
+
+// getComparisonTracerResults obtains the comparison operands stored by a comparisonTracer from message results. This
+// is nil if no operands were recorded by a tracer (e.g. comparisonTracer was not attached during this message
+// execution).
+func getComparisonTracerResults(messageResults *types.MessageResults) []common.Hash {
+	if genericResult, ok := messageResults.AdditionalResults[comparisonTracerResultsKey]; ok {
+		if castedResult, ok := genericResult.([]common.Hash); ok {
+			return castedResult
+		}
+	}
+	return nil
+}
+
+// comparisonTracer implements vm.EVMLogger to collect the operands of comparison instructions (e.g. LT, GT, EQ)
+// executed in call frames which reverted. Such operands often describe the constants a `require` precondition
+// compares arguments against, so adding them to the value set raises the chance of satisfying the precondition.
+type comparisonTracer struct {
+	// operands describes the comparison operands recorded in reverted call frames during the current transaction.
+	operands []common.Hash
+
+	// callFrameOperands describes the comparison operands recorded for each call frame which has not yet exited.
+	callFrameOperands [][]common.Hash
+}
+
+// newComparisonTracer returns a new comparisonTracer.
+func newComparisonTracer() *comparisonTracer {
+	return &comparisonTracer{
+		operands:          make([]common.Hash, 0),
+		callFrameOperands: make([][]common.Hash, 0),
+	}
+}
+
+// CaptureTxStart is called upon the start of transaction execution, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureTxStart(gasLimit uint64) {
+	t.operands = make([]common.Hash, 0)
+	t.callFrameOperands = make([][]common.Hash, 0)
+}
+
+// CaptureTxEnd is called upon the end of transaction execution, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureTxEnd(restGas uint64) {
+}
+
+// CaptureStart initializes the tracing operation for the top of a call frame, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.callFrameOperands = append(t.callFrameOperands, make([]common.Hash, 0))
+}
+
+// CaptureEnd is called after a call to finalize tracing completes for the top of a call frame, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exitCallFrame(err)
+}
+
+// CaptureEnter is called upon entering of the call frame, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.callFrameOperands = append(t.callFrameOperands, make([]common.Hash, 0))
+}
+
+// CaptureExit is called upon exiting of the call frame, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exitCallFrame(err)
+}
+
+// exitCallFrame pops the operands recorded for the current call frame, retaining them if the call frame reverted.
+func (t *comparisonTracer) exitCallFrame(err error) {
+	lastIndex := len(t.callFrameOperands) - 1
+	if err != nil {
+		for _, operand := range t.callFrameOperands[lastIndex] {
+			if len(t.operands) >= maxComparisonOperandsPerTx {
+				break
+			}
+			t.operands = append(t.operands, operand)
+		}
+	}
+	t.callFrameOperands = t.callFrameOperands[:lastIndex]
+}
+
+// CaptureState records data from an EVM state update, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, vmErr error) {
+	// We only record the operands of comparison instructions, prior to their execution.
+	if op != vm.LT && op != vm.GT && op != vm.SLT && op != vm.SGT && op != vm.EQ {
+		return
+	}
+	if len(scope.Stack.Data()) < 2 {
+		return
+	}
+
+	// Record both operands for the current call frame, discarding the oldest operands once we reach our limit.
+	lastIndex := len(t.callFrameOperands) - 1
+	frameOperands := t.callFrameOperands[lastIndex]
+	if len(frameOperands) >= maxComparisonOperandsPerTx {
+		frameOperands = frameOperands[2:]
+	}
+	t.callFrameOperands[lastIndex] = append(frameOperands,
+		common.Hash(scope.Stack.Back(0).Bytes32()),
+		common.Hash(scope.Stack.Back(1).Bytes32()),
+	)
+}
+
+// CaptureFault records an execution fault, as defined by vm.EVMLogger.
+func (t *comparisonTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// CaptureTxEndSetAdditionalResults can be used to set additional results captured from execution tracing. If this
+// tracer is used during transaction execution (block creation), the results can later be queried from the block.
+// This method will only be called on the added tracer if it implements the extended TestChainTracer interface.
+func (t *comparisonTracer) CaptureTxEndSetAdditionalResults(results *types.MessageResults) {
+	results.AdditionalResults[comparisonTracerResultsKey] = t.operands
+}
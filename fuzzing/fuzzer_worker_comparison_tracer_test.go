@@ -0,0 +1,45 @@
+package fuzzing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestComparisonTracerRequireOperand runs a test to ensure the operands of the comparison made by a reverting
+// `require(x == K)` precondition are recorded by the comparisonTracer, even if the call frame made more comparisons
+// than are retained beforehand (e.g. when dispatching methods or decoding arguments).
+func TestComparisonTracerRequireOperand(t *testing.T) {
+	expectedOperand := common.BigToHash(big.NewInt(0x1337))
+
+	// Create code which makes many unrelated comparisons, before comparing the first calldata word against our
+	// expected operand, reverting if they are not equal.
+	code := make([]byte, 0)
+	for i := 0; i < maxComparisonOperandsPerTx; i++ {
+		code = append(code, byte(vm.PUSH1), 1, byte(vm.PUSH1), 2, byte(vm.EQ), byte(vm.POP))
+	}
+	code = append(code, byte(vm.PUSH32))
+	code = append(code, expectedOperand.Bytes()...)
+	code = append(code, byte(vm.PUSH1), 0, byte(vm.CALLDATALOAD), byte(vm.EQ))
+	jumpDestination := len(code) + 8
+	code = append(code,
+		byte(vm.PUSH2), byte(jumpDestination>>8), byte(jumpDestination), byte(vm.JUMPI),
+		byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.REVERT),
+		byte(vm.JUMPDEST), byte(vm.STOP),
+	)
+
+	// Execute the code with input which does not satisfy the precondition.
+	tracer := newComparisonTracer()
+	_, _, err := runtime.Execute(code, common.BigToHash(big.NewInt(1)).Bytes(), &runtime.Config{
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	assert.ErrorIs(t, err, vm.ErrExecutionReverted)
+
+	// Verify the expected operand was recorded, without exceeding our limit.
+	assert.Contains(t, tracer.operands, expectedOperand)
+	assert.LessOrEqual(t, len(tracer.operands), maxComparisonOperandsPerTx)
+}
@@ -0,0 +1,81 @@
+package fuzzing
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// isDeadlineArgument checks whether the provided method input is an unsigned integer whose name matches one of the
+// provided deadline argument patterns.
+func isDeadlineArgument(input abi.Argument, patterns []string) bool {
+	// Only unsigned integers large enough to hold a timestamp are considered.
+	if input.Type.T != abi.UintTy || input.Type.Size < 32 {
+		return false
+	}
+	name := strings.ToLower(input.Name)
+	for _, pattern := range patterns {
+		if pattern != "" && strings.Contains(name, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// setDeadlineArguments replaces deadline arguments in the provided generated input values for a method with a
+// timestamp at or after the one the call is expected to execute at, with the configured deadline argument
+// probability. Deadline arguments are unsigned integers whose names match a configured deadline argument pattern
+// (e.g. "deadline" or "expiry"). Deadline arguments which are not replaced keep their generated value.
+func (g *CallSequenceGenerator) setDeadlineArguments(method *abi.Method, args []any, blockTimestampDelay uint64) {
+	// If we have no patterns, there is nothing to do.
+	valueGenConfig := g.worker.fuzzer.config.Fuzzing.ValueGeneration
+	if len(valueGenConfig.DeadlineArgumentPatterns) == 0 {
+		return
+	}
+
+	// Determine the timestamp the call is expected to execute at. Calls without a timestamp delay may be added to the
+	// pending block, if there is one.
+	block := g.worker.chain.Head()
+	if pendingBlock := g.worker.chain.PendingBlock(); pendingBlock != nil {
+		block = pendingBlock
+	}
+	timestamp := block.Header.Time + blockTimestampDelay
+
+	for i, input := range method.Inputs {
+		if !isDeadlineArgument(input, valueGenConfig.DeadlineArgumentPatterns) {
+			continue
+		}
+
+		// Determine whether to replace this argument, or keep its generated value.
+		if g.worker.randomProvider.Float32() >= valueGenConfig.DeadlineArgumentProbability {
+			continue
+		}
+
+		// Generate a deadline within our maximum offset of the expected timestamp.
+		deadline := new(big.Int).SetUint64(timestamp)
+		if valueGenConfig.DeadlineArgumentMaxOffset > 0 {
+			offset := g.config.ValueGenerator.GenerateInteger(false, 64).Uint64() % (valueGenConfig.DeadlineArgumentMaxOffset + 1)
+			deadline.Add(deadline, new(big.Int).SetUint64(offset))
+		}
+
+		// Set the argument using the Go type for its size, saturating it if it does not fit.
+		switch {
+		case input.Type.Size == 32:
+			if !deadline.IsUint64() || deadline.Uint64() > math.MaxUint32 {
+				args[i] = uint32(math.MaxUint32)
+			} else {
+				args[i] = uint32(deadline.Uint64())
+			}
+		case input.Type.Size == 64:
+			if !deadline.IsUint64() {
+				args[i] = uint64(math.MaxUint64)
+			} else {
+				args[i] = deadline.Uint64()
+			}
+		default:
+			args[i] = deadline
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package fuzzing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/crytic/medusa/chain"
+	"github.com/crytic/medusa/fuzzing/config"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetDeadlineArguments runs tests to ensure deadline arguments are only replaced when their name matches a
+// configured pattern, with the configured probability, and with a timestamp within the configured offset of the
+// timestamp the call is expected to execute at.
+func TestSetDeadlineArguments(t *testing.T) {
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("swap", "swap", abi.Function, "nonpayable", false, false, abi.Arguments{
+		{Name: "amount", Type: uint256Type},
+		{Name: "deadline", Type: uint256Type},
+	}, nil)
+	generatedValue := new(big.Int).Lsh(big.NewInt(1), 255)
+	const blockTimestampDelay = 100
+	const maxOffset = 10
+
+	tests := []struct {
+		patterns         []string
+		probability      float32
+		minReplacedCount int
+		maxReplacedCount int
+	}{
+		{patterns: []string{}, probability: 1, minReplacedCount: 0, maxReplacedCount: 0},
+		{patterns: []string{"deadline"}, probability: 0, minReplacedCount: 0, maxReplacedCount: 0},
+		{patterns: []string{"DEADLINE"}, probability: 1, minReplacedCount: 1000, maxReplacedCount: 1000},
+		{patterns: []string{"deadline"}, probability: 0.5, minReplacedCount: 400, maxReplacedCount: 600},
+	}
+	for _, test := range tests {
+		projectConfig, err := config.GetDefaultProjectConfig("")
+		assert.NoError(t, err)
+		projectConfig.Fuzzing.ValueGeneration.DeadlineArgumentPatterns = test.patterns
+		projectConfig.Fuzzing.ValueGeneration.DeadlineArgumentProbability = test.probability
+		projectConfig.Fuzzing.ValueGeneration.DeadlineArgumentMaxOffset = maxOffset
+		generator := newTestCallSequenceGenerator(t, projectConfig)
+		generator.worker.chain, err = chain.NewTestChain(core.GenesisAlloc{}, nil)
+		assert.NoError(t, err)
+		timestamp := generator.worker.chain.Head().Header.Time + blockTimestampDelay
+
+		// Set deadline arguments many times, counting how often the deadline was replaced.
+		replacedCount := 0
+		for i := 0; i < 1000; i++ {
+			args := []any{generatedValue, generatedValue}
+			generator.setDeadlineArguments(&method, args, blockTimestampDelay)
+			assert.EqualValues(t, generatedValue, args[0])
+			if args[1] != generatedValue {
+				deadline := args[1].(*big.Int)
+				assert.GreaterOrEqual(t, deadline.Uint64(), timestamp)
+				assert.LessOrEqual(t, deadline.Uint64(), timestamp+maxOffset)
+				replacedCount++
+			}
+		}
+		assert.GreaterOrEqual(t, replacedCount, test.minReplacedCount)
+		assert.LessOrEqual(t, replacedCount, test.maxReplacedCount)
+	}
+}
@@ -3,10 +3,15 @@ package fuzzing
 import (
 	"fmt"
 	"github.com/crytic/medusa/fuzzing/calls"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
 	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/crytic/medusa/utils"
 	"github.com/crytic/medusa/utils/randomutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"math"
 	"math/big"
+	"sync"
 )
 
 // CallSequenceGenerator generates call sequences iteratively per element, for use in fuzzing campaigns. It is attached
@@ -35,6 +40,21 @@ type CallSequenceGenerator struct {
 	// mutationStrategyChooser is a weighted random selector of functions that prepare the CallSequenceGenerator with
 	// a baseSequence derived from corpus entries.
 	mutationStrategyChooser *randomutils.WeightedRandomChooser[CallSequenceGeneratorMutationStrategy]
+
+	// senderChooser is a weighted random selector of sender addresses to use when generating new calls.
+	senderChooser *randomutils.WeightedRandomChooser[common.Address]
+
+	// interestingPrefix describes the prefix of the most recently executed call sequence which was deemed
+	// interesting, ending at its last interesting call. It may be extended by the next call sequence generated.
+	interestingPrefix calls.CallSequence
+
+	// singleArgumentMutationCounter describes the amount of calls mutated by prefetchModifyCallFuncMutateSingleArgument,
+	// used to determine which argument index it mutates next.
+	singleArgumentMutationCounter int
+
+	// sequenceStructureMutationChooser is a weighted random selector of operators used to mutate the structure of a
+	// corpus call sequence (e.g. reordering or deleting calls).
+	sequenceStructureMutationChooser *randomutils.WeightedRandomChooser[sequenceStructureMutation]
 }
 
 // CallSequenceGeneratorConfig defines the configuration for a CallSequenceGenerator to be created and used by a
@@ -84,6 +104,18 @@ type CallSequenceGeneratorConfig struct {
 	// number of calls from each.
 	RandomMutatedInterleaveAtRandomWeight uint64
 
+	// RandomMutatedSingleArgumentCorpusHeadWeight defines the weight that the CallSequenceGenerator should use the call
+	// sequence generation strategy of taking the head of a corpus sequence and mutating only a single argument of each
+	// call, holding all others fixed, before appending newly generated calls to the end of it. The mutated argument
+	// index advances with each call mutated, so every argument is eventually mutated in isolation.
+	RandomMutatedSingleArgumentCorpusHeadWeight uint64
+
+	// RandomMutatedSequenceStructureWeight defines the weight that the CallSequenceGenerator should use the call
+	// sequence generation strategy of taking a corpus sequence and mutating its structure, by swapping, deleting,
+	// inserting or duplicating calls, before appending newly generated calls to the end of it. This explores
+	// ordering-dependent behavior which mutating call arguments alone cannot.
+	RandomMutatedSequenceStructureWeight uint64
+
 	// ValueGenerator defines the value provider to use when generating or mutating call sequences. This is used both
 	// for ABI call data generation, and generation of additional values such as the "value" field of a
 	// transaction/call.
@@ -112,9 +144,28 @@ type CallSequenceGeneratorMutationStrategy struct {
 // NewCallSequenceGenerator creates a CallSequenceGenerator to generate call sequences for use in fuzzing campaigns.
 func NewCallSequenceGenerator(worker *FuzzerWorker, config *CallSequenceGeneratorConfig) *CallSequenceGenerator {
 	generator := &CallSequenceGenerator{
-		worker:                  worker,
-		config:                  config,
-		mutationStrategyChooser: randomutils.NewWeightedRandomChooser[CallSequenceGeneratorMutationStrategy](),
+		worker:                           worker,
+		config:                           config,
+		mutationStrategyChooser:          randomutils.NewWeightedRandomChooserWithRand[CallSequenceGeneratorMutationStrategy](worker.randomProvider, &sync.Mutex{}),
+		senderChooser:                    randomutils.NewWeightedRandomChooserWithRand[common.Address](worker.randomProvider, &sync.Mutex{}),
+		sequenceStructureMutationChooser: randomutils.NewWeightedRandomChooserWithRand[sequenceStructureMutation](worker.randomProvider, &sync.Mutex{}),
+	}
+
+	// Add each sequence structure mutation operator with an equal weight.
+	for _, mutation := range []sequenceStructureMutation{
+		sequenceStructureMutationSwap,
+		sequenceStructureMutationDelete,
+		sequenceStructureMutationInsert,
+		sequenceStructureMutationDuplicate,
+	} {
+		generator.sequenceStructureMutationChooser.AddChoices(randomutils.NewWeightedRandomChoice(mutation, big.NewInt(1)))
+	}
+
+	// Add each sender address with its configured weight.
+	for _, sender := range worker.fuzzer.senders {
+		generator.senderChooser.AddChoices(
+			randomutils.NewWeightedRandomChoice(sender, new(big.Int).SetUint64(worker.fuzzer.senderWeights[sender])),
+		)
 	}
 
 	generator.mutationStrategyChooser.AddChoices(
@@ -174,6 +225,20 @@ func NewCallSequenceGenerator(worker *FuzzerWorker, config *CallSequenceGenerato
 			},
 			new(big.Int).SetUint64(config.RandomMutatedInterleaveAtRandomWeight),
 		),
+		randomutils.NewWeightedRandomChoice(
+			CallSequenceGeneratorMutationStrategy{
+				CallSequenceGeneratorFunc: callSeqGenFuncCorpusHead,
+				PrefetchModifyCallFunc:    prefetchModifyCallFuncMutateSingleArgument,
+			},
+			new(big.Int).SetUint64(config.RandomMutatedSingleArgumentCorpusHeadWeight),
+		),
+		randomutils.NewWeightedRandomChoice(
+			CallSequenceGeneratorMutationStrategy{
+				CallSequenceGeneratorFunc: callSeqGenFuncMutateSequenceStructure,
+				PrefetchModifyCallFunc:    nil,
+			},
+			new(big.Int).SetUint64(config.RandomMutatedSequenceStructureWeight),
+		),
 	)
 
 	return generator
@@ -184,11 +249,21 @@ func NewCallSequenceGenerator(worker *FuzzerWorker, config *CallSequenceGenerato
 // Returns a boolean indicating whether the initialized sequence is a newly generated sequence (rather than an
 // unmodified one loaded from the corpus), or an error if one occurred.
 func (g *CallSequenceGenerator) InitializeNextSequence() (bool, error) {
-	// Reset the state of our generator.
-	g.baseSequence = make(calls.CallSequence, g.worker.fuzzer.config.Fuzzing.CallSequenceLength)
+	// Reset the state of our generator, selecting a random length for our sequence within the configured bounds.
+	minLength, maxLength := g.worker.fuzzer.config.Fuzzing.CallSequenceLengthMin, g.worker.fuzzer.config.Fuzzing.CallSequenceLength
+	if minLength == 0 {
+		minLength = maxLength
+	}
+	g.baseSequence = make(calls.CallSequence, minLength+g.worker.randomProvider.Intn(maxLength-minLength+1))
 	g.fetchIndex = 0
 	g.prefetchModifyCallFunc = nil
 
+	// If we are ignoring the corpus, we return a call sequence with nil elements to signal that we want an entirely
+	// new sequence.
+	if g.worker.fuzzer.config.Fuzzing.CleanRoom {
+		return true, nil
+	}
+
 	// Check if there are any previously une-xecuted corpus call sequences. If there are, the fuzzer should execute
 	// those first.
 	unexecutedSequence := g.worker.fuzzer.corpus.UnexecutedCallSequence()
@@ -197,6 +272,19 @@ func (g *CallSequenceGenerator) InitializeNextSequence() (bool, error) {
 		return false, nil
 	}
 
+	// If we are only replaying the corpus, there is no work left once every corpus call sequence has been executed, so
+	// we stop fuzzing and return an empty sequence.
+	if g.worker.fuzzer.config.Fuzzing.ReplayOnly {
+		g.baseSequence = make(calls.CallSequence, 0)
+		g.worker.fuzzer.Stop()
+		return false, nil
+	}
+
+	// Determine whether we will extend the prefix of the last interesting call sequence, rather than starting anew.
+	if g.extendInterestingPrefix() {
+		return true, nil
+	}
+
 	// We'll decide whether to create a new call sequence or mutating existing corpus call sequences. Any entries we
 	// leave as nil will be populated by a newly generated call prior to being fetched from this provider.
 
@@ -227,6 +315,51 @@ func (g *CallSequenceGenerator) InitializeNextSequence() (bool, error) {
 	return true, nil
 }
 
+// RecordInterestingPrefix records the provided call sequence, which was deemed interesting after executing its last
+// call, so it may be extended by a subsequently generated call sequence.
+// Returns an error if one occurs.
+func (g *CallSequenceGenerator) RecordInterestingPrefix(callSequence calls.CallSequence) error {
+	// If we never extend sequences, there is no need to record the prefix.
+	if g.worker.fuzzer.config.Fuzzing.CallSequenceExtensionProbability <= 0 {
+		return nil
+	}
+	prefix, err := callSequence.Clone()
+	if err != nil {
+		return err
+	}
+	g.interestingPrefix = prefix
+	return nil
+}
+
+// extendInterestingPrefix decides whether the next call sequence should extend the prefix of the last interesting
+// call sequence recorded by RecordInterestingPrefix. If so, the base sequence is set to the prefix followed by new
+// calls to be generated, and the prefix is consumed.
+// Returns a boolean indicating whether the prefix is being extended.
+func (g *CallSequenceGenerator) extendInterestingPrefix() bool {
+	// Consume our prefix, so the same prefix is not extended repeatedly without yielding further interesting calls.
+	prefix := g.interestingPrefix
+	g.interestingPrefix = nil
+
+	// Verify we have a prefix which can be extended, and decide whether to extend it.
+	fuzzingConfig := g.worker.fuzzer.config.Fuzzing
+	maxLength := fuzzingConfig.CallSequenceExtensionMaxLength
+	if maxLength == 0 {
+		maxLength = fuzzingConfig.CallSequenceLength * 2
+	}
+	if len(prefix) == 0 || len(prefix) >= maxLength {
+		return false
+	}
+	if g.worker.randomProvider.Float32() >= fuzzingConfig.CallSequenceExtensionProbability {
+		return false
+	}
+
+	// Create our base sequence from our prefix, leaving nil elements to be populated with newly generated calls.
+	length := utils.Min(len(prefix)+fuzzingConfig.CallSequenceLength, maxLength)
+	g.baseSequence = make(calls.CallSequence, length)
+	copy(g.baseSequence, prefix)
+	return true
+}
+
 // PopSequenceElement obtains the next element for our call sequence requested by InitializeNextSequence. If there are no elements
 // left to return, this method returns nil. If an error occurs, it is returned instead.
 func (g *CallSequenceGenerator) PopSequenceElement() (*calls.CallSequenceElement, error) {
@@ -242,7 +375,7 @@ func (g *CallSequenceGenerator) PopSequenceElement() (*calls.CallSequenceElement
 	var err error
 	if element == nil {
 		element, err = g.generateNewElement()
-		if err != nil {
+		if err != nil || element == nil {
 			return nil, err
 		}
 	} else {
@@ -265,23 +398,107 @@ func (g *CallSequenceGenerator) PopSequenceElement() (*calls.CallSequenceElement
 
 // generateNewElement generates a new call sequence element which targets a state changing method in a contract
 // deployed to the CallSequenceGenerator's parent FuzzerWorker chain, with fuzzed call data.
-// Returns the call sequence element, or an error if one was encountered.
+// Returns the call sequence element, or an error if one was encountered. If the fuzzer context was cancelled during
+// generation, a nil element is returned.
 func (g *CallSequenceGenerator) generateNewElement() (*calls.CallSequenceElement, error) {
+	// With some probability, target a receive or fallback function rather than a method.
+	element, err := g.generateReceiveOrFallbackElement()
+	if element != nil || err != nil {
+		return element, err
+	}
+
 	// Verify we have state changing methods to call
 	if len(g.worker.stateChangingMethods) == 0 {
 		return nil, fmt.Errorf("cannot generate fuzzed tx as there are no state changing methods to call")
 	}
 
-	// Select a random method and sender
+	// Select a random method and a weighted random sender
 	selectedMethod := &g.worker.stateChangingMethods[g.worker.randomProvider.Intn(len(g.worker.stateChangingMethods))]
-	selectedSender := g.worker.fuzzer.senders[g.worker.randomProvider.Intn(len(g.worker.fuzzer.senders))]
+	selectedSender, err := g.senderChooser.Choose()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate fuzzed tx as no sender could be selected: %v", err)
+	}
+	if g.worker.fuzzer.methodExecutions != nil {
+		g.worker.fuzzer.methodExecutions.recordSelection(g.worker.workerIndex, selectedMethod.Contract.Name(), &selectedMethod.Method)
+	}
+
+	// If the method was learned to only succeed for a single sender, bias our selection towards that sender.
+	if g.worker.fuzzer.senderRestrictions != nil {
+		if restrictedSender, ok := g.worker.fuzzer.senderRestrictions.restrictedSender(selectedMethod.Address, selectedMethod.Method.ID); ok {
+			if g.worker.randomProvider.Float32() < senderRestrictionBias {
+				selectedSender = &restrictedSender
+			}
+		}
+	}
+
+	// Generate fuzzed parameters for the function call. If a validator was registered for this method, we regenerate
+	// them until they are accepted, up to a maximum amount of attempts. If none were accepted, the last are used.
+	var args []any
+	methodKey := MethodHookKey(selectedMethod.Contract.Name(), &selectedMethod.Method)
+	validator := g.worker.fuzzer.Hooks.MethodArgumentsValidators[methodKey]
+	valueProviders := g.worker.fuzzer.Hooks.MethodArgumentValueProviders[methodKey]
+	for attempt := 0; attempt < maxMethodArgumentsGenerationAttempts; attempt++ {
+		args = make([]any, len(selectedMethod.Method.Inputs))
+		for i := 0; i < len(args); i++ {
+			// Create our fuzzed parameters.
+			input := selectedMethod.Method.Inputs[i]
+			args[i], err = valuegeneration.GenerateAbiValueWithContext(g.worker.fuzzer.ctx, g.config.ValueGenerator, &input.Type)
+			if err != nil {
+				// If our fuzzer context was cancelled during generation, return no element so the sequence ends.
+				if utils.CheckContextDone(g.worker.fuzzer.ctx) {
+					return nil, nil
+				}
+				return nil, err
+			}
+		}
+
+		// Apply any correlation hints between the address arguments of this method and the call.
+		err = g.correlateAddressArguments(selectedMethod.Contract.Name(), &selectedMethod.Method, *selectedSender, args)
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate fuzzed tx as its address arguments could not be correlated: %v", err)
+		}
+
+		// Apply any value providers registered for the arguments of this method.
+		for _, provider := range valueProviders {
+			if provider.ArgumentIndex < 0 || provider.ArgumentIndex >= len(args) {
+				return nil, fmt.Errorf("cannot generate fuzzed tx as a value provider targets argument %d, but method '%s' has %d arguments", provider.ArgumentIndex, selectedMethod.Method.Sig, len(args))
+			}
+			args[provider.ArgumentIndex], err = provider.Provide(g.worker, selectedMethod, args[provider.ArgumentIndex])
+			if err != nil {
+				return nil, fmt.Errorf("cannot generate fuzzed tx as a value could not be provided for its arguments: %v", err)
+			}
+			err = valuegeneration.ValidateAbiValue(&selectedMethod.Method.Inputs[provider.ArgumentIndex].Type, args[provider.ArgumentIndex])
+			if err != nil {
+				return nil, fmt.Errorf("cannot generate fuzzed tx as the value provided for argument %d of method '%s' is invalid: %v", provider.ArgumentIndex, selectedMethod.Method.Sig, err)
+			}
+		}
+
+		// If we have no validator, we accept the arguments as-is. Otherwise, the validator may adjust or reject them.
+		if validator == nil {
+			break
+		}
+		accepted, err := validator(g.worker, selectedMethod, args)
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate fuzzed tx as its arguments could not be validated: %v", err)
+		}
+		if accepted {
+			break
+		}
+	}
+
+	// Determine our delay values for this element
+	blockNumberDelay, blockTimestampDelay := g.generateBlockDelays()
 
-	// Generate fuzzed parameters for the function call
-	args := make([]any, len(selectedMethod.Method.Inputs))
-	for i := 0; i < len(args); i++ {
-		// Create our fuzzed parameters.
-		input := selectedMethod.Method.Inputs[i]
-		args[i] = valuegeneration.GenerateAbiValue(g.config.ValueGenerator, &input.Type)
+	// Set any deadline arguments relative to the block timestamp this call is expected to execute at.
+	g.setDeadlineArguments(&selectedMethod.Method, args, blockTimestampDelay)
+
+	// If our encoded call data exceeds the configured maximum size, truncate our dynamic arguments until it fits.
+	g.truncateArgumentsToMaxCalldataSize(&selectedMethod.Method, args)
+
+	// If the call should be signed, replace any signature arguments with a valid signature.
+	err = g.signArguments(selectedMethod, args)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate fuzzed tx as its signature arguments could not be signed: %v", err)
 	}
 
 	// If this is a payable function, generate value to send
@@ -291,16 +508,104 @@ func (g *CallSequenceGenerator) generateNewElement() (*calls.CallSequenceElement
 		value = g.config.ValueGenerator.GenerateInteger(false, 64)
 	}
 
+	// Generate our gas pricing for this call.
+	gasPrice, gasFeeCap, gasTipCap := g.generateGasFees()
+
 	// Create our message using the provided parameters.
-	// We fill out some fields and populate the rest from our TestChain properties.
-	// TODO: We likely want to make gasPrice fluctuate within some sensible range here.
-	msg := calls.NewCallMessageWithAbiValueData(selectedSender, &selectedMethod.Address, 0, value, g.worker.fuzzer.config.Fuzzing.TransactionGasLimit, nil, nil, nil, &calls.CallMessageDataAbiValues{
-		Method:      &selectedMethod.Method,
-		InputValues: args,
+	// We fill out some fields and populate the rest from our TestChain properties. The nonce is not generated, as it
+	// is not validated during execution, and is instead populated from the sender's account nonce.
+	msg := calls.NewCallMessageWithAbiValueData(*selectedSender, &selectedMethod.Address, 0, value, g.worker.fuzzer.config.Fuzzing.TransactionGasLimit, gasPrice, gasFeeCap, gasTipCap, &calls.CallMessageDataAbiValues{
+		Method:             &selectedMethod.Method,
+		InputValues:        args,
+		JSONArgumentCodecs: g.worker.fuzzer.Hooks.JSONArgumentCodecs,
 	})
 	msg.FillFromTestChainProperties(g.worker.chain)
 
-	// Determine our delay values for this element
+	// Return our call sequence element.
+	return calls.NewCallSequenceElement(selectedMethod.Contract, msg, blockNumberDelay, blockTimestampDelay), nil
+}
+
+// generateReceiveOrFallbackElement randomly decides, according to the configured probabilities, whether the next call
+// should target the receive or fallback function of a deployed contract rather than one of its methods. Receive
+// functions are targeted by value transfers without call data. Fallback functions are targeted by short call data or
+// an unknown method selector, sending value only if the fallback function is payable.
+// Returns the generated element, or nil if a method should be targeted instead, or an error if one occurs.
+func (g *CallSequenceGenerator) generateReceiveOrFallbackElement() (*calls.CallSequenceElement, error) {
+	// Determine whether to target a receive or fallback function, if any contract defines one.
+	var targets []fuzzerTypes.DeployedContractMethod
+	targetReceive := false
+	roll := g.worker.randomProvider.Float32()
+	receiveCallProbability := g.worker.fuzzer.config.Fuzzing.ReceiveCallProbability
+	fallbackCallProbability := g.worker.fuzzer.config.Fuzzing.FallbackCallProbability
+	if roll < receiveCallProbability {
+		targets, targetReceive = g.worker.receiveMethods, true
+	} else if roll < receiveCallProbability+fallbackCallProbability {
+		targets = g.worker.fallbackMethods
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	// Select a random target and a weighted random sender
+	target := &targets[g.worker.randomProvider.Intn(len(targets))]
+	selectedSender, err := g.senderChooser.Choose()
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate fuzzed tx as no sender could be selected: %v", err)
+	}
+
+	// Receive functions are only executed for calls without data, and must be sent value to be distinguished from a
+	// call to a fallback function. Fallback functions are executed for calls which match no method.
+	value := big.NewInt(0)
+	var data []byte
+	if targetReceive {
+		data = []byte{}
+		value = g.config.ValueGenerator.GenerateInteger(false, 64)
+		if value.Sign() == 0 {
+			value = big.NewInt(1)
+		}
+	} else {
+		data = g.generateFallbackCallData(&target.Contract.CompiledContract().Abi)
+		if target.Method.IsPayable() {
+			value = g.config.ValueGenerator.GenerateInteger(false, 64)
+		}
+	}
+
+	// Determine our delay values and gas pricing for this element
+	blockNumberDelay, blockTimestampDelay := g.generateBlockDelays()
+	gasPrice, gasFeeCap, gasTipCap := g.generateGasFees()
+
+	// Create our message with raw call data, as there is no method to encode ABI values for.
+	msg := calls.NewCallMessage(*selectedSender, &target.Address, 0, value, g.worker.fuzzer.config.Fuzzing.TransactionGasLimit, gasPrice, gasFeeCap, gasTipCap, data)
+	msg.FillFromTestChainProperties(g.worker.chain)
+	return calls.NewCallSequenceElement(target.Contract, msg, blockNumberDelay, blockTimestampDelay), nil
+}
+
+// generateFallbackCallData generates call data which does not match any method in the provided contract ABI, so it is
+// handled by the contract's fallback function. The call data is either shorter than a method selector, or begins with
+// a random method selector the contract does not define, followed by random data.
+// Returns the generated call data.
+func (g *CallSequenceGenerator) generateFallbackCallData(contractAbi *abi.ABI) []byte {
+	// Generate call data too short to contain a method selector half of the time.
+	if g.worker.randomProvider.Intn(2) == 0 {
+		data := make([]byte, 1+g.worker.randomProvider.Intn(3))
+		g.worker.randomProvider.Read(data)
+		return data
+	}
+
+	// Otherwise, generate a random selector which matches no method, followed by up to a word of random data.
+	data := make([]byte, 4+g.worker.randomProvider.Intn(33))
+	for {
+		g.worker.randomProvider.Read(data)
+		if _, err := contractAbi.MethodById(data); err != nil {
+			return data
+		}
+	}
+}
+
+// generateBlockDelays generates the block number and timestamp delays for a new call sequence element, within the
+// configured maximum delays.
+// Returns the block number delay and block timestamp delay.
+func (g *CallSequenceGenerator) generateBlockDelays() (uint64, uint64) {
 	blockNumberDelay := uint64(0)
 	blockTimestampDelay := uint64(0)
 	if g.worker.fuzzer.config.Fuzzing.MaxBlockNumberDelay > 0 {
@@ -319,9 +624,71 @@ func (g *CallSequenceGenerator) generateNewElement() (*calls.CallSequenceElement
 			blockNumberDelay %= blockTimestampDelay
 		}
 	}
+	return blockNumberDelay, blockTimestampDelay
+}
+
+// truncateArgumentsToMaxCalldataSize checks whether the encoded call data for the provided method and input values
+// exceeds the configured maximum call data size. If it does, every dynamic argument (slices, bytes, and strings) is
+// repeatedly truncated in place until the call data fits, or nothing is left to truncate.
+func (g *CallSequenceGenerator) truncateArgumentsToMaxCalldataSize(method *abi.Method, args []any) {
+	// If we have no maximum call data size, there is nothing to do.
+	maxCalldataSize := g.worker.fuzzer.config.Fuzzing.MaxCalldataSize
+	if maxCalldataSize == 0 {
+		return
+	}
+
+	for {
+		// Encode our arguments and check if they fit (accounting for the method selector).
+		argData, err := method.Inputs.Pack(args...)
+		if err != nil || uint64(len(method.ID)+len(argData)) <= maxCalldataSize {
+			return
+		}
+
+		// Truncate our dynamic arguments, stopping if there was nothing left to truncate.
+		truncated := false
+		for i, input := range method.Inputs {
+			var argTruncated bool
+			args[i], argTruncated = valuegeneration.TruncateAbiValue(&input.Type, args[i])
+			truncated = truncated || argTruncated
+		}
+		if !truncated {
+			return
+		}
+	}
+}
 
-	// Return our call sequence element.
-	return calls.NewCallSequenceElement(selectedMethod.Contract, msg, blockNumberDelay, blockTimestampDelay), nil
+// generateGasFees generates a gas price, gas fee cap, and gas tip cap for a new call. The gas fee cap will be no less
+// than the greater of the configured base fee and the chain's current base fee, and the gas tip cap will be no greater
+// than the configured maximum. If gas fee generation is disabled, nil values are returned, so they are populated
+// with defaults from the test chain properties.
+func (g *CallSequenceGenerator) generateGasFees() (*big.Int, *big.Int, *big.Int) {
+	// If no base fee was configured, gas fee generation is disabled.
+	fuzzingConfig := g.worker.fuzzer.config.Fuzzing
+	if fuzzingConfig.BaseFee == 0 {
+		return nil, nil, nil
+	}
+
+	// Determine the base fee we must cover so our call is not rejected as underpriced.
+	baseFee := new(big.Int).SetUint64(fuzzingConfig.BaseFee)
+	if headBaseFee := g.worker.chain.Head().Header.BaseFee; headBaseFee != nil && headBaseFee.Cmp(baseFee) > 0 {
+		baseFee.Set(headBaseFee)
+	}
+
+	// Generate a tip within our configured range, and set our fee cap such that it covers the base fee and tip.
+	gasTipCap := big.NewInt(0)
+	if fuzzingConfig.MaxGasTipCap > 0 {
+		// Any 64-bit value is within range if our max tip is the max uint64, and adding one to it would overflow.
+		tip := g.config.ValueGenerator.GenerateInteger(false, 64).Uint64()
+		if fuzzingConfig.MaxGasTipCap < math.MaxUint64 {
+			tip %= fuzzingConfig.MaxGasTipCap + 1
+		}
+		gasTipCap.SetUint64(tip)
+	}
+	gasFeeCap := new(big.Int).Add(baseFee, gasTipCap)
+
+	// The effective gas price paid is the base fee plus the tip, which our fee cap was set to.
+	gasPrice := new(big.Int).Set(gasFeeCap)
+	return gasPrice, gasFeeCap, gasTipCap
 }
 
 // callSeqGenFuncCorpusHead is a CallSequenceGeneratorFunc which prepares a CallSequenceGenerator to generate a sequence
@@ -433,6 +800,119 @@ func callSeqGenFuncInterleaveAtRandom(sequenceGenerator *CallSequenceGenerator,
 	return nil
 }
 
+// sequenceStructureMutation defines an operator which mutates the structure of a call sequence. Nil elements may be
+// inserted, which are populated with newly generated calls prior to being fetched.
+// Returns the mutated call sequence, or an error if one occurs.
+type sequenceStructureMutation func(sequenceGenerator *CallSequenceGenerator, sequence calls.CallSequence) (calls.CallSequence, error)
+
+// callSeqGenFuncMutateSequenceStructure is a CallSequenceGeneratorFunc which prepares a CallSequenceGenerator to
+// generate a sequence based off of an existing corpus call sequence, whose structure is mutated by a randomly
+// selected sequenceStructureMutation.
+// Returns an error if one occurs.
+func callSeqGenFuncMutateSequenceStructure(sequenceGenerator *CallSequenceGenerator, sequence calls.CallSequence) error {
+	// Obtain a call sequence from the corpus
+	corpusSequence, err := sequenceGenerator.worker.fuzzer.corpus.RandomMutationTargetSequence()
+	if err != nil {
+		return fmt.Errorf("could not obtain corpus call sequence for sequence structure mutation: %v", err)
+	}
+
+	// Select a structure mutation and apply it.
+	mutation, err := sequenceGenerator.sequenceStructureMutationChooser.Choose()
+	if err != nil {
+		return fmt.Errorf("could not obtain a sequence structure mutation: %v", err)
+	}
+	corpusSequence, err = (*mutation)(sequenceGenerator, corpusSequence)
+	if err != nil {
+		return fmt.Errorf("could not apply sequence structure mutation: %v", err)
+	}
+
+	// Copy the mutated sequence to our destination sequence, leaving any remaining elements to be generated.
+	maxLength := utils.Min(len(sequence), len(corpusSequence))
+	copy(sequence, corpusSequence[:maxLength])
+	return nil
+}
+
+// sequenceStructureMutationSwap is a sequenceStructureMutation which swaps the calls at two random positions. Block
+// delays and block context overrides remain at their positions, so each position is executed at the same point in
+// the block timeline as before.
+// Returns the mutated call sequence, or an error if one occurs.
+func sequenceStructureMutationSwap(sequenceGenerator *CallSequenceGenerator, sequence calls.CallSequence) (calls.CallSequence, error) {
+	if len(sequence) < 2 {
+		return sequence, nil
+	}
+	i := sequenceGenerator.worker.randomProvider.Intn(len(sequence))
+	j := sequenceGenerator.worker.randomProvider.Intn(len(sequence) - 1)
+	if j >= i {
+		j++
+	}
+
+	// Swap our calls, then swap their block metadata back.
+	sequence[i], sequence[j] = sequence[j], sequence[i]
+	sequence[i].BlockNumberDelay, sequence[j].BlockNumberDelay = sequence[j].BlockNumberDelay, sequence[i].BlockNumberDelay
+	sequence[i].BlockTimestampDelay, sequence[j].BlockTimestampDelay = sequence[j].BlockTimestampDelay, sequence[i].BlockTimestampDelay
+	sequence[i].BlockContextOverrides, sequence[j].BlockContextOverrides = sequence[j].BlockContextOverrides, sequence[i].BlockContextOverrides
+	return sequence, nil
+}
+
+// sequenceStructureMutationDelete is a sequenceStructureMutation which deletes the call at a random position. Its
+// block delays are added to the call which follows it, so subsequent calls execute at the same point in the block
+// timeline as before. If the call which follows it has no block context overrides, it inherits those of the deleted
+// call, as its block context would otherwise be derived from them.
+// Returns the mutated call sequence, or an error if one occurs.
+func sequenceStructureMutationDelete(sequenceGenerator *CallSequenceGenerator, sequence calls.CallSequence) (calls.CallSequence, error) {
+	if len(sequence) < 2 {
+		return sequence, nil
+	}
+	i := sequenceGenerator.worker.randomProvider.Intn(len(sequence))
+	if i+1 < len(sequence) {
+		// If the following call's block context was derived from the deleted call's pinned block context, pin it to
+		// the block context it would have been derived as.
+		if overrides := sequence[i].BlockContextOverrides; overrides != nil && sequence[i+1].BlockContextOverrides == nil {
+			if overrides.BlockNumber != nil {
+				blockNumber := *overrides.BlockNumber + sequence[i+1].BlockNumberDelay
+				overrides.BlockNumber = &blockNumber
+			}
+			if overrides.BlockTimestamp != nil && sequence[i+1].BlockNumberDelay > 0 {
+				blockTimestamp := *overrides.BlockTimestamp + sequence[i+1].BlockTimestampDelay
+				overrides.BlockTimestamp = &blockTimestamp
+			}
+			sequence[i+1].BlockContextOverrides = overrides
+		}
+		sequence[i+1].BlockNumberDelay += sequence[i].BlockNumberDelay
+		sequence[i+1].BlockTimestampDelay += sequence[i].BlockTimestampDelay
+	}
+	return append(sequence[:i], sequence[i+1:]...), nil
+}
+
+// sequenceStructureMutationInsert is a sequenceStructureMutation which inserts a newly generated call at a random
+// position. The inserted call has no block context overrides. Its block delays may advance the block timeline past
+// the overrides of a subsequent call, in which case those overrides are skipped when it is executed.
+// Returns the mutated call sequence, or an error if one occurs.
+func sequenceStructureMutationInsert(sequenceGenerator *CallSequenceGenerator, sequence calls.CallSequence) (calls.CallSequence, error) {
+	i := sequenceGenerator.worker.randomProvider.Intn(len(sequence) + 1)
+	sequence = append(sequence[:i], append(calls.CallSequence{nil}, sequence[i:]...)...)
+	return sequence, nil
+}
+
+// sequenceStructureMutationDuplicate is a sequenceStructureMutation which duplicates the call at a random position.
+// The duplicate immediately follows the original, without any block delay.
+// Returns the mutated call sequence, or an error if one occurs.
+func sequenceStructureMutationDuplicate(sequenceGenerator *CallSequenceGenerator, sequence calls.CallSequence) (calls.CallSequence, error) {
+	if len(sequence) == 0 {
+		return sequence, nil
+	}
+	i := sequenceGenerator.worker.randomProvider.Intn(len(sequence))
+	duplicate, err := sequence[i].Clone()
+	if err != nil {
+		return nil, err
+	}
+	duplicate.BlockNumberDelay = 0
+	duplicate.BlockTimestampDelay = 0
+	duplicate.BlockContextOverrides = nil
+	sequence = append(sequence[:i+1], append(calls.CallSequence{duplicate}, sequence[i+1:]...)...)
+	return sequence, nil
+}
+
 // prefetchModifyCallFuncMutate is a PrefetchModifyCallFunc, called by a CallSequenceGenerator to apply mutations
 // to a call sequence element, prior to it being fetched.
 // Returns an error if one occurs.
@@ -451,5 +931,33 @@ func prefetchModifyCallFuncMutate(sequenceGenerator *CallSequenceGenerator, elem
 		}
 		abiValuesMsgData.InputValues[i] = mutatedInput
 	}
+
+	// Mutations may grow dynamic arguments, so ensure the call data still fits the configured maximum size.
+	sequenceGenerator.truncateArgumentsToMaxCalldataSize(abiValuesMsgData.Method, abiValuesMsgData.InputValues)
+	return nil
+}
+
+// prefetchModifyCallFuncMutateSingleArgument is a PrefetchModifyCallFunc, called by a CallSequenceGenerator to mutate
+// a single input argument of a call sequence element prior to it being fetched, holding all others fixed. The index of
+// the argument mutated advances with each call, cycling through every argument.
+// Returns an error if one occurs.
+func prefetchModifyCallFuncMutateSingleArgument(sequenceGenerator *CallSequenceGenerator, element *calls.CallSequenceElement) error {
+	// If this element has no ABI value based call data or no inputs, exit early.
+	if element.Call == nil || element.Call.MsgDataAbiValues == nil || len(element.Call.MsgDataAbiValues.InputValues) == 0 {
+		return nil
+	}
+
+	// Determine which input value is active and mutate only it.
+	abiValuesMsgData := element.Call.MsgDataAbiValues
+	i := sequenceGenerator.singleArgumentMutationCounter % len(abiValuesMsgData.InputValues)
+	sequenceGenerator.singleArgumentMutationCounter++
+	mutatedInput, err := valuegeneration.MutateAbiValue(sequenceGenerator.config.ValueGenerator, &abiValuesMsgData.Method.Inputs[i].Type, abiValuesMsgData.InputValues[i])
+	if err != nil {
+		return fmt.Errorf("error when mutating call sequence input argument: %v", err)
+	}
+	abiValuesMsgData.InputValues[i] = mutatedInput
+
+	// Mutations may grow dynamic arguments, so ensure the call data still fits the configured maximum size.
+	sequenceGenerator.truncateArgumentsToMaxCalldataSize(abiValuesMsgData.Method, abiValuesMsgData.InputValues)
 	return nil
 }
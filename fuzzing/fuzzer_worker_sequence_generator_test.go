@@ -0,0 +1,403 @@
+package fuzzing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/crytic/medusa/chain"
+	"github.com/crytic/medusa/compilation/types"
+	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/crytic/medusa/fuzzing/config"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/fuzzing/corpus"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCallSequenceGenerator creates a FuzzerWorker for a Fuzzer with the provided project configuration and no
+// compilation targets, returning its CallSequenceGenerator.
+func newTestCallSequenceGenerator(t *testing.T, projectConfig *config.ProjectConfig) *CallSequenceGenerator {
+	fuzzer, err := NewFuzzer(*projectConfig)
+	assert.NoError(t, err)
+	fuzzer.corpus, err = corpus.NewCorpus("", 1, true, 0, nil)
+	assert.NoError(t, err)
+
+	worker, err := newFuzzerWorker(fuzzer, 0, rand.New(rand.NewSource(1)))
+	assert.NoError(t, err)
+	return worker.sequenceGenerator
+}
+
+// newTestMethodCallSequenceGenerator creates a CallSequenceGenerator as newTestCallSequenceGenerator does, whose
+// worker has a test chain and a single deployed contract defining the provided method, which new calls target.
+func newTestMethodCallSequenceGenerator(t *testing.T, projectConfig *config.ProjectConfig, method abi.Method) *CallSequenceGenerator {
+	generator := newTestCallSequenceGenerator(t, projectConfig)
+	generator.worker.fuzzer.ctx = context.Background()
+	var err error
+	generator.worker.chain, err = chain.NewTestChain(core.GenesisAlloc{}, nil)
+	assert.NoError(t, err)
+
+	contract := fuzzerTypes.NewContract("TestContract", "contracts/TestContract.sol", &types.CompiledContract{
+		Abi: abi.ABI{Methods: map[string]abi.Method{method.Name: method}},
+	}, nil)
+	assert.NoError(t, generator.worker.registerDeployedContract(common.HexToAddress("0x1000"), contract))
+	return generator
+}
+
+// TestCallSequenceGeneratorLengthBounds runs tests to ensure generated call sequences are of the configured length
+// when no minimum length is specified, and within the configured bounds otherwise.
+func TestCallSequenceGeneratorLengthBounds(t *testing.T) {
+	tests := []struct {
+		minLength         int
+		maxLength         int
+		expectedMinLength int
+	}{
+		{minLength: 0, maxLength: 10, expectedMinLength: 10},
+		{minLength: 3, maxLength: 10, expectedMinLength: 3},
+		{minLength: 10, maxLength: 10, expectedMinLength: 10},
+	}
+	for _, test := range tests {
+		projectConfig, err := config.GetDefaultProjectConfig("")
+		assert.NoError(t, err)
+		projectConfig.Fuzzing.CallSequenceLengthMin = test.minLength
+		projectConfig.Fuzzing.CallSequenceLength = test.maxLength
+		generator := newTestCallSequenceGenerator(t, projectConfig)
+
+		// Generate many sequences, tracking every length observed.
+		observedLengths := make(map[int]bool)
+		for i := 0; i < 1000; i++ {
+			isNewSequence, err := generator.InitializeNextSequence()
+			assert.NoError(t, err)
+			assert.True(t, isNewSequence)
+			length := len(generator.baseSequence)
+			assert.GreaterOrEqual(t, length, test.expectedMinLength)
+			assert.LessOrEqual(t, length, test.maxLength)
+			observedLengths[length] = true
+		}
+
+		// Every length within the bounds should have been observed.
+		assert.Len(t, observedLengths, test.maxLength-test.expectedMinLength+1)
+	}
+}
+
+// newTestStructureMutationSequence creates a call sequence for use in sequence structure mutation tests. Each element
+// has a distinct nonce, block delays, and pinned block number.
+func newTestStructureMutationSequence(length int) calls.CallSequence {
+	sequence := make(calls.CallSequence, length)
+	for i := 0; i < length; i++ {
+		call := calls.NewCallMessage(common.Address{}, &common.Address{}, uint64(i), big.NewInt(0), 0, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil)
+		sequence[i] = calls.NewCallSequenceElement(nil, call, uint64(i+1), uint64(10*(i+1)))
+		blockNumber := uint64(100 * (i + 1))
+		sequence[i].BlockContextOverrides = &calls.BlockContextOverrides{BlockNumber: &blockNumber}
+	}
+	return sequence
+}
+
+// TestSequenceStructureMutations runs tests to ensure each sequence structure mutation keeps block metadata coherent,
+// so the block context of each position in the sequence is preserved.
+func TestSequenceStructureMutations(t *testing.T) {
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	generator := newTestCallSequenceGenerator(t, projectConfig)
+
+	for i := 0; i < 100; i++ {
+		// Swapping calls keeps block metadata at each position.
+		sequence, err := sequenceStructureMutationSwap(generator, newTestStructureMutationSequence(5))
+		assert.NoError(t, err)
+		assert.Len(t, sequence, 5)
+		swappedCount := 0
+		for j, element := range sequence {
+			assert.EqualValues(t, j+1, element.BlockNumberDelay)
+			assert.EqualValues(t, 10*(j+1), element.BlockTimestampDelay)
+			assert.EqualValues(t, 100*(j+1), *element.BlockContextOverrides.BlockNumber)
+			if element.Call.Nonce() != uint64(j) {
+				swappedCount++
+			}
+		}
+		assert.EqualValues(t, 2, swappedCount)
+
+		// Deleting a call moves its block metadata to the call which follows it.
+		sequence, err = sequenceStructureMutationDelete(generator, newTestStructureMutationSequence(5))
+		assert.NoError(t, err)
+		assert.Len(t, sequence, 4)
+		for j, element := range sequence {
+			if element.Call.Nonce() != uint64(j) {
+				// This is the call following the deleted one.
+				assert.EqualValues(t, 2*j+3, element.BlockNumberDelay)
+				assert.EqualValues(t, 10*(2*j+3), element.BlockTimestampDelay)
+				assert.EqualValues(t, 100*(j+2), *element.BlockContextOverrides.BlockNumber)
+				break
+			}
+		}
+
+		// Deleting a call pins the block context of the following call if it had none.
+		sequence = newTestStructureMutationSequence(2)
+		sequence[1].BlockContextOverrides = nil
+		sequence, err = sequenceStructureMutationDelete(generator, sequence)
+		assert.NoError(t, err)
+		assert.Len(t, sequence, 1)
+		if sequence[0].Call.Nonce() == 1 {
+			assert.EqualValues(t, 102, *sequence[0].BlockContextOverrides.BlockNumber)
+		}
+
+		// Inserting a call adds a nil element to be generated, without altering the block metadata of others.
+		sequence, err = sequenceStructureMutationInsert(generator, newTestStructureMutationSequence(5))
+		assert.NoError(t, err)
+		assert.Len(t, sequence, 6)
+		nonce := uint64(0)
+		for _, element := range sequence {
+			if element == nil {
+				continue
+			}
+			assert.EqualValues(t, nonce, element.Call.Nonce())
+			assert.EqualValues(t, 100*(nonce+1), *element.BlockContextOverrides.BlockNumber)
+			nonce++
+		}
+		assert.EqualValues(t, 5, nonce)
+
+		// Duplicating a call adds a copy immediately after it, in the same block and without overrides.
+		sequence, err = sequenceStructureMutationDuplicate(generator, newTestStructureMutationSequence(5))
+		assert.NoError(t, err)
+		assert.Len(t, sequence, 6)
+		for j := 1; j < len(sequence); j++ {
+			if sequence[j].Call.Nonce() == sequence[j-1].Call.Nonce() {
+				assert.Zero(t, sequence[j].BlockNumberDelay)
+				assert.Zero(t, sequence[j].BlockTimestampDelay)
+				assert.Nil(t, sequence[j].BlockContextOverrides)
+			}
+		}
+	}
+}
+
+// TestMutatedCallsMaxCalldataSize runs tests to ensure the arguments of mutated calls are truncated so their call data
+// does not exceed the configured maximum call data size.
+func TestMutatedCallsMaxCalldataSize(t *testing.T) {
+	const maxCalldataSize = 200
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	projectConfig.Fuzzing.MaxCalldataSize = maxCalldataSize
+	generator := newTestCallSequenceGenerator(t, projectConfig)
+
+	bytesType, err := abi.NewType("bytes", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("store", "store", abi.Function, "nonpayable", false, false, abi.Arguments{{Name: "data", Type: bytesType}}, nil)
+	for _, mutationFunc := range []PrefetchModifyCallFunc{prefetchModifyCallFuncMutate, prefetchModifyCallFuncMutateSingleArgument} {
+		for i := 0; i < 100; i++ {
+			// Create a call whose arguments exceed the maximum call data size, then mutate it.
+			msg := calls.NewCallMessageWithAbiValueData(common.Address{}, &common.Address{}, 0, big.NewInt(0), 0, nil, nil, nil, &calls.CallMessageDataAbiValues{
+				Method:      &method,
+				InputValues: []any{make([]byte, maxCalldataSize*4)},
+			})
+			element := calls.NewCallSequenceElement(nil, msg, 1, 1)
+			err = mutationFunc(generator, element)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(element.Call.Data()), maxCalldataSize)
+		}
+	}
+}
+
+// TestMutateSingleArgument runs tests to ensure the single argument mutation strategy mutates at most one argument of
+// each call, holding all others fixed, and that the mutated argument advances with each call so every argument is
+// eventually mutated.
+func TestMutateSingleArgument(t *testing.T) {
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	generator := newTestCallSequenceGenerator(t, projectConfig)
+
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("set", "set", abi.Function, "nonpayable", false, false, abi.Arguments{
+		{Name: "a", Type: uint256Type},
+		{Name: "b", Type: uint256Type},
+		{Name: "c", Type: uint256Type},
+	}, nil)
+	mutatedCounts := make([]int, len(method.Inputs))
+	for i := 0; i < 300; i++ {
+		// Mutate a call with fixed arguments, and determine which arguments changed.
+		msg := calls.NewCallMessageWithAbiValueData(common.Address{}, &common.Address{}, 0, big.NewInt(0), 0, nil, nil, nil, &calls.CallMessageDataAbiValues{
+			Method:      &method,
+			InputValues: []any{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
+		})
+		element := calls.NewCallSequenceElement(nil, msg, 1, 1)
+		err = prefetchModifyCallFuncMutateSingleArgument(generator, element)
+		assert.NoError(t, err)
+
+		// Only the argument at the current index may have changed.
+		for j, value := range element.Call.MsgDataAbiValues.InputValues {
+			if value.(*big.Int).Cmp(big.NewInt(int64(j+1))) != 0 {
+				assert.EqualValues(t, i%len(method.Inputs), j)
+				mutatedCounts[j]++
+			}
+		}
+	}
+	for _, mutatedCount := range mutatedCounts {
+		assert.Greater(t, mutatedCount, 0)
+	}
+
+	// Calls without arguments are unaffected, and do not advance the mutated argument index.
+	noArgsMethod := abi.NewMethod("reset", "reset", abi.Function, "nonpayable", false, false, abi.Arguments{}, nil)
+	msg := calls.NewCallMessageWithAbiValueData(common.Address{}, &common.Address{}, 0, big.NewInt(0), 0, nil, nil, nil, &calls.CallMessageDataAbiValues{
+		Method:      &noArgsMethod,
+		InputValues: []any{},
+	})
+	counter := generator.singleArgumentMutationCounter
+	assert.NoError(t, prefetchModifyCallFuncMutateSingleArgument(generator, calls.NewCallSequenceElement(nil, msg, 1, 1)))
+	assert.EqualValues(t, counter, generator.singleArgumentMutationCounter)
+}
+
+// TestMethodArgumentValueProviders runs tests to ensure values provided for arguments of a method replace the values
+// generated for them, and that providers targeting a missing argument or providing invalid values are rejected.
+func TestMethodArgumentValueProviders(t *testing.T) {
+	uint8Type, err := abi.NewType("uint8", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("setFee", "setFee", abi.Function, "nonpayable", false, false, abi.Arguments{
+		{Name: "fee", Type: uint8Type},
+		{Name: "maxFee", Type: uint8Type},
+	}, nil)
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	generator := newTestMethodCallSequenceGenerator(t, projectConfig, method)
+	methodKey := MethodHookKey("TestContract", &method)
+
+	// Provided values replace the generated value of the targeted argument only.
+	generator.worker.fuzzer.Hooks.MethodArgumentValueProviders[methodKey] = []MethodArgumentValueProvider{{
+		ArgumentIndex: 1,
+		Provide: func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, generatedValue any) (any, error) {
+			return generatedValue.(uint8) % 10, nil
+		},
+	}}
+	for i := 0; i < 100; i++ {
+		element, err := generator.generateNewElement()
+		assert.NoError(t, err)
+		assert.Less(t, element.Call.MsgDataAbiValues.InputValues[1].(uint8), uint8(10))
+	}
+
+	// Errors returned by providers, providers targeting arguments which do not exist, and provided values which are
+	// not valid for the argument's type are rejected.
+	providers := []MethodArgumentValueProvider{
+		{ArgumentIndex: 0, Provide: func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, generatedValue any) (any, error) {
+			return nil, errors.New("provider error")
+		}},
+		{ArgumentIndex: 2, Provide: func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, generatedValue any) (any, error) {
+			return generatedValue, nil
+		}},
+		{ArgumentIndex: 0, Provide: func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, generatedValue any) (any, error) {
+			return big.NewInt(1), nil
+		}},
+	}
+	for _, provider := range providers {
+		generator.worker.fuzzer.Hooks.MethodArgumentValueProviders[methodKey] = []MethodArgumentValueProvider{provider}
+		_, err = generator.generateNewElement()
+		assert.Error(t, err)
+	}
+}
+
+// TestMethodArgumentsValidators runs tests to ensure arguments rejected by a method's validator are regenerated until
+// they are accepted, that the last generated arguments are used once the maximum amount of attempts is reached, and
+// that validator errors are returned.
+func TestMethodArgumentsValidators(t *testing.T) {
+	uint8Type, err := abi.NewType("uint8", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("setFee", "setFee", abi.Function, "nonpayable", false, false, abi.Arguments{
+		{Name: "fee", Type: uint8Type},
+		{Name: "maxFee", Type: uint8Type},
+	}, nil)
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	generator := newTestMethodCallSequenceGenerator(t, projectConfig, method)
+	methodKey := MethodHookKey("TestContract", &method)
+
+	// Create a validator which rejects arguments until a given attempt, recording the arguments it was called with.
+	var validatedArgs [][]any
+	newValidator := func(acceptAttempt int) MethodArgumentsValidatorFunc {
+		validatedArgs = nil
+		return func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) (bool, error) {
+			validatedArgs = append(validatedArgs, append([]any{}, args...))
+			return len(validatedArgs) == acceptAttempt, nil
+		}
+	}
+
+	// Rejected arguments are regenerated, and the accepted arguments are used.
+	generator.worker.fuzzer.Hooks.MethodArgumentsValidators[methodKey] = newValidator(3)
+	element, err := generator.generateNewElement()
+	assert.NoError(t, err)
+	assert.Len(t, validatedArgs, 3)
+	assert.EqualValues(t, validatedArgs[2], element.Call.MsgDataAbiValues.InputValues)
+
+	// If arguments are never accepted, generation stops after the maximum amount of attempts, using the last arguments.
+	generator.worker.fuzzer.Hooks.MethodArgumentsValidators[methodKey] = newValidator(-1)
+	element, err = generator.generateNewElement()
+	assert.NoError(t, err)
+	assert.Len(t, validatedArgs, maxMethodArgumentsGenerationAttempts)
+	assert.EqualValues(t, validatedArgs[maxMethodArgumentsGenerationAttempts-1], element.Call.MsgDataAbiValues.InputValues)
+
+	// Validators may adjust the arguments they accept.
+	generator.worker.fuzzer.Hooks.MethodArgumentsValidators[methodKey] = func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) (bool, error) {
+		args[1] = uint8(255)
+		return true, nil
+	}
+	element, err = generator.generateNewElement()
+	assert.NoError(t, err)
+	assert.EqualValues(t, uint8(255), element.Call.MsgDataAbiValues.InputValues[1])
+
+	// Errors returned by validators are returned.
+	generator.worker.fuzzer.Hooks.MethodArgumentsValidators[methodKey] = func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) (bool, error) {
+		return false, errors.New("validator error")
+	}
+	_, err = generator.generateNewElement()
+	assert.Error(t, err)
+}
+
+// TestGenerateGasFees runs tests to ensure generated gas fees are disabled without a base fee, and otherwise cover the
+// base fee of the chain head along with a tip within the configured maximum, including the maximum uint64 tip.
+func TestGenerateGasFees(t *testing.T) {
+	tests := []struct {
+		baseFee      uint64
+		maxGasTipCap uint64
+	}{
+		{baseFee: 0, maxGasTipCap: 10},
+		{baseFee: 100, maxGasTipCap: 0},
+		{baseFee: 100, maxGasTipCap: 10},
+		{baseFee: 100, maxGasTipCap: math.MaxUint64},
+	}
+	for _, test := range tests {
+		projectConfig, err := config.GetDefaultProjectConfig("")
+		assert.NoError(t, err)
+		projectConfig.Fuzzing.BaseFee = test.baseFee
+		projectConfig.Fuzzing.MaxGasTipCap = test.maxGasTipCap
+		generator := newTestCallSequenceGenerator(t, projectConfig)
+		generator.worker.chain, err = chain.NewTestChain(core.GenesisAlloc{}, nil)
+		assert.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			gasPrice, gasFeeCap, gasTipCap := generator.generateGasFees()
+			if test.baseFee == 0 {
+				assert.Nil(t, gasPrice)
+				assert.Nil(t, gasFeeCap)
+				assert.Nil(t, gasTipCap)
+				continue
+			}
+			assert.True(t, gasTipCap.IsUint64())
+			assert.LessOrEqual(t, gasTipCap.Uint64(), test.maxGasTipCap)
+			assert.EqualValues(t, new(big.Int).Add(new(big.Int).SetUint64(test.baseFee), gasTipCap), gasFeeCap)
+			assert.EqualValues(t, gasFeeCap, gasPrice)
+		}
+	}
+
+	// If the chain head's base fee exceeds the configured base fee, it is covered instead.
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	projectConfig.Fuzzing.BaseFee = 100
+	projectConfig.Fuzzing.MaxGasTipCap = 0
+	generator := newTestCallSequenceGenerator(t, projectConfig)
+	generator.worker.chain, err = chain.NewTestChain(core.GenesisAlloc{}, nil)
+	assert.NoError(t, err)
+	generator.worker.chain.Head().Header.BaseFee = big.NewInt(1000)
+	_, gasFeeCap, _ := generator.generateGasFees()
+	assert.EqualValues(t, big.NewInt(1000), gasFeeCap)
+}
@@ -0,0 +1,57 @@
+package fuzzing
+
+import (
+	"crypto/ecdsa"
+	"strings"
+
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// SignatureDigestFunc describes a function which computes the digest (e.g. an EIP-712 typed data hash, see
+// utils.EIP712TypedDataHash) that signature arguments of a method call should sign, given the targeted method and its
+// other generated input values. It also returns the private key of the signer. If the method call should not be
+// signed, a nil digest is returned.
+// Returns the digest and signer private key, or an error if one occurs.
+type SignatureDigestFunc func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) ([]byte, *ecdsa.PrivateKey, error)
+
+// signArguments replaces the signature arguments in the provided generated input values for a method with a valid
+// signature, if a SignatureDigestFunc is set and provides a digest to sign for the call. Signature arguments are
+// either a uint8 "v", bytes32 "r" and bytes32 "s" argument triple, or a dynamic bytes argument whose name contains
+// "sig" (which receives a 65-byte packed r, s, v signature).
+// Returns an error if one occurs.
+func (g *CallSequenceGenerator) signArguments(method *fuzzerTypes.DeployedContractMethod, args []any) error {
+	// If we have no function to compute the digest to sign, there is nothing to do.
+	signatureDigestFunc := g.worker.fuzzer.Hooks.SignatureDigestFunc
+	if signatureDigestFunc == nil {
+		return nil
+	}
+
+	// Obtain the digest to sign and the signer key.
+	digest, privateKey, err := signatureDigestFunc(g.worker, method, args)
+	if err != nil || digest == nil {
+		return err
+	}
+
+	// Sign the digest.
+	v, r, s, err := utils.SignDigest(privateKey, digest)
+	if err != nil {
+		return err
+	}
+
+	// Set the signature components in our arguments.
+	for i, input := range method.Method.Inputs {
+		switch {
+		case input.Name == "v" && input.Type.T == abi.UintTy && input.Type.Size == 8:
+			args[i] = v
+		case input.Name == "r" && input.Type.T == abi.FixedBytesTy && input.Type.Size == 32:
+			args[i] = r
+		case input.Name == "s" && input.Type.T == abi.FixedBytesTy && input.Type.Size == 32:
+			args[i] = s
+		case input.Type.T == abi.BytesTy && strings.Contains(strings.ToLower(input.Name), "sig"):
+			args[i] = append(append(append([]byte{}, r[:]...), s[:]...), v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package fuzzing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/crytic/medusa/fuzzing/config"
+	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSignArguments runs tests to ensure signature arguments of generated method calls are replaced with a signature
+// of the digest provided by the SignatureDigestFunc hook, in both v, r, s and packed bytes forms, which recovers to
+// the signer, and that errors computing the digest are returned.
+func TestSignArguments(t *testing.T) {
+	uint8Type, err := abi.NewType("uint8", "", nil)
+	assert.NoError(t, err)
+	bytes32Type, err := abi.NewType("bytes32", "", nil)
+	assert.NoError(t, err)
+	bytesType, err := abi.NewType("bytes", "", nil)
+	assert.NoError(t, err)
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	method := abi.NewMethod("permit", "permit", abi.Function, "nonpayable", false, false, abi.Arguments{
+		{Name: "value", Type: uint256Type},
+		{Name: "v", Type: uint8Type},
+		{Name: "r", Type: bytes32Type},
+		{Name: "s", Type: bytes32Type},
+		{Name: "signature", Type: bytesType},
+	}, nil)
+	projectConfig, err := config.GetDefaultProjectConfig("")
+	assert.NoError(t, err)
+	generator := newTestMethodCallSequenceGenerator(t, projectConfig, method)
+
+	// Sign an EIP-712 digest of the generated value with the private key keccak256("cow").
+	privateKey, err := utils.GetPrivateKey(crypto.Keccak256([]byte("cow")))
+	assert.NoError(t, err)
+	domainSeparator := crypto.Keccak256([]byte("domain"))
+	getDigest := func(args []any) []byte {
+		return utils.EIP712TypedDataHash(domainSeparator, crypto.Keccak256(common.LeftPadBytes(args[0].(*big.Int).Bytes(), 32)))
+	}
+	generator.worker.fuzzer.Hooks.SignatureDigestFunc = func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) ([]byte, *ecdsa.PrivateKey, error) {
+		return getDigest(args), privateKey, nil
+	}
+	for i := 0; i < 10; i++ {
+		element, err := generator.generateNewElement()
+		assert.NoError(t, err)
+		args := element.Call.MsgDataAbiValues.InputValues
+		digest := getDigest(args)
+
+		// Verify the v, r, s arguments recover to the signer.
+		v, r, s := args[1].(uint8), args[2].([32]byte), args[3].([32]byte)
+		signature := append(append(append([]byte{}, r[:]...), s[:]...), v-27)
+		publicKey, err := crypto.SigToPub(digest, signature)
+		assert.NoError(t, err)
+		assert.EqualValues(t, crypto.PubkeyToAddress(privateKey.PublicKey), crypto.PubkeyToAddress(*publicKey))
+
+		// Verify the packed signature argument matches the v, r, s arguments.
+		assert.EqualValues(t, append(append(append([]byte{}, r[:]...), s[:]...), v), args[4])
+	}
+
+	// If no digest is provided, the call is generated without signing, and errors computing the digest are returned.
+	generator.worker.fuzzer.Hooks.SignatureDigestFunc = func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) ([]byte, *ecdsa.PrivateKey, error) {
+		return nil, nil, nil
+	}
+	_, err = generator.generateNewElement()
+	assert.NoError(t, err)
+	generator.worker.fuzzer.Hooks.SignatureDigestFunc = func(worker *FuzzerWorker, method *fuzzerTypes.DeployedContractMethod, args []any) ([]byte, *ecdsa.PrivateKey, error) {
+		return nil, nil, errors.New("digest error")
+	}
+	_, err = generator.generateNewElement()
+	assert.Error(t, err)
+}
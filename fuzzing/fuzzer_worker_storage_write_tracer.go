@@ -0,0 +1,88 @@
+package fuzzing
+
+import (
+	"math/big"
+
+	"github.com/crytic/medusa/chain/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// storageWriteTracerResultsKey describes the key to use when storing tracer results in call message results, or when
+// querying them.
+const storageWriteTracerResultsKey = "StorageWriteTracerResults"
+
+// maxStorageWriteValuesPerTx describes the maximum amount of values a storageWriteTracer records for a single
+// transaction, so loops over storage writes do not flood the value set.
+const maxStorageWriteValuesPerTx = 64
+
+// getStorageWriteTracerResults obtains the values written to storage which were stored by a storageWriteTracer from
+// message results. This is nil if no values were recorded by a tracer (e.g. storageWriteTracer was not attached during
+// this message execution).
+func getStorageWriteTracerResults(messageResults *types.MessageResults) []common.Hash {
+	if genericResult, ok := messageResults.AdditionalResults[storageWriteTracerResultsKey]; ok {
+		if castedResult, ok := genericResult.([]common.Hash); ok {
+			return castedResult
+		}
+	}
+	return nil
+}
+
+// storageWriteTracer implements vm.EVMLogger to collect the values written to storage by SSTORE instructions, as they
+// often describe interesting runtime values (e.g. addresses and amounts) which may be used in generated arguments.
+type storageWriteTracer struct {
+	// values describes the values written to storage during the current transaction.
+	values []common.Hash
+}
+
+// newStorageWriteTracer returns a new storageWriteTracer.
+func newStorageWriteTracer() *storageWriteTracer {
+	return &storageWriteTracer{
+		values: make([]common.Hash, 0),
+	}
+}
+
+// CaptureTxStart is called upon the start of transaction execution, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureTxStart(gasLimit uint64) {
+	t.values = make([]common.Hash, 0)
+}
+
+// CaptureTxEnd is called upon the end of transaction execution, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureTxEnd(restGas uint64) {
+}
+
+// CaptureStart initializes the tracing operation for the top of a call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+}
+
+// CaptureEnd is called after a call to finalize tracing completes for the top of a call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+}
+
+// CaptureEnter is called upon entering of the call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+}
+
+// CaptureExit is called upon exiting of the call frame, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+}
+
+// CaptureState records data from an EVM state update, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, vmErr error) {
+	// We only record the values written by SSTORE instructions, prior to their execution, up to our limit.
+	if op != vm.SSTORE || len(scope.Stack.Data()) < 2 || len(t.values) >= maxStorageWriteValuesPerTx {
+		return
+	}
+	t.values = append(t.values, common.Hash(scope.Stack.Back(1).Bytes32()))
+}
+
+// CaptureFault records an execution fault, as defined by vm.EVMLogger.
+func (t *storageWriteTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// CaptureTxEndSetAdditionalResults can be used to set additional results captured from execution tracing. If this
+// tracer is used during transaction execution (block creation), the results can later be queried from the block.
+// This method will only be called on the added tracer if it implements the extended TestChainTracer interface.
+func (t *storageWriteTracer) CaptureTxEndSetAdditionalResults(results *types.MessageResults) {
+	results.AdditionalResults[storageWriteTracerResultsKey] = t.values
+}
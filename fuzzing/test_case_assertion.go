@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/crytic/medusa/compilation/abiutils"
 	"github.com/crytic/medusa/fuzzing/calls"
 	fuzzerTypes "github.com/crytic/medusa/fuzzing/contracts"
+	"github.com/crytic/medusa/fuzzing/valuegeneration"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 )
 
@@ -30,24 +32,58 @@ func (t *AssertionTestCase) CallSequence() *calls.CallSequence {
 
 // Name describes the name of the test case.
 func (t *AssertionTestCase) Name() string {
-	return fmt.Sprintf("Assertion Test: %s.%s", t.targetContract.Name(), t.targetMethod.Sig)
+	return fmt.Sprintf("Assertion Test: %s.%s", t.targetContract.Name(), fuzzerTypes.GetMethodSignature(&t.targetMethod))
 }
 
 // Message obtains a text-based printable message which describes the test result.
 func (t *AssertionTestCase) Message() string {
 	// If the test failed, return a failure message.
 	if t.Status() == TestCaseStatusFailed {
-		return fmt.Sprintf(
+		message := fmt.Sprintf(
 			"Test for method \"%s.%s\" failed after the following call sequence resulted in an assertion:\n%s",
 			t.targetContract.Name(),
-			t.targetMethod.Sig,
+			fuzzerTypes.GetMethodSignature(&t.targetMethod),
 			t.CallSequence().String(),
 		)
+
+		// If the failing call reverted with a custom error, describe it.
+		if customErrorText := t.customRevertErrorString(); customErrorText != "" {
+			message += fmt.Sprintf("\nThe final call reverted with the custom error: %s", customErrorText)
+		}
+		return message
 	}
 	return ""
 }
 
+// customRevertErrorString decodes a custom Solidity error the last call in the test's call sequence reverted with,
+// using the ABI of the target contract.
+// Returns a string representing the error and its arguments (e.g. "InsufficientBalance(have=5, want=10)"), or an
+// empty string if the call did not revert with a custom error which could be decoded.
+func (t *AssertionTestCase) customRevertErrorString() string {
+	// Obtain the execution result of the last call in our sequence, if it was executed.
+	if t.callSequence == nil || len(*t.callSequence) == 0 {
+		return ""
+	}
+	lastCall := (*t.callSequence)[len(*t.callSequence)-1]
+	if lastCall.ChainReference == nil {
+		return ""
+	}
+	executionResult := lastCall.ChainReference.MessageResults().ExecutionResult
+
+	// Decode the custom error using the target contract's ABI.
+	contractAbi := &t.targetContract.CompiledContract().Abi
+	customError, customErrorArgs := abiutils.GetSolidityCustomRevertError(contractAbi, executionResult.Err, executionResult.ReturnData)
+	if customError == nil {
+		return ""
+	}
+	customErrorArgsText, err := valuegeneration.EncodeABIArgumentsToNamedString(customError.Inputs, customErrorArgs)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s(%s)", customError.Name, customErrorArgsText)
+}
+
 // ID obtains a unique identifier for a test result.
 func (t *AssertionTestCase) ID() string {
-	return strings.Replace(fmt.Sprintf("ASSERTION-%s-%s", t.targetContract.Name(), t.targetMethod.Sig), "_", "-", -1)
+	return strings.Replace(fmt.Sprintf("ASSERTION-%s-%s", t.targetContract.Name(), fuzzerTypes.GetMethodSignature(&t.targetMethod)), "_", "-", -1)
 }
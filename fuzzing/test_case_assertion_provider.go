@@ -1,8 +1,10 @@
 package fuzzing
 
 import (
+	"bytes"
 	"github.com/crytic/medusa/compilation/abiutils"
 	"github.com/crytic/medusa/fuzzing/calls"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"golang.org/x/exp/slices"
 	"sync"
 
@@ -22,6 +24,12 @@ type AssertionTestCaseProvider struct {
 
 	// testCasesLock is used for thread-synchronization when updating testCases
 	testCasesLock sync.Mutex
+
+	// failOnRevertReasons describes revert reason strings which should be treated as assertion failures.
+	failOnRevertReasons []string
+
+	// failOnRevertSelectors describes 4-byte error selectors which should be treated as assertion failures.
+	failOnRevertSelectors [][]byte
 }
 
 // Define our ABI method
@@ -43,11 +51,28 @@ func attachAssertionTestCaseProvider(fuzzer *Fuzzer) *AssertionTestCaseProvider
 	return t
 }
 
-// isTestableMethod checks whether the method is configured by the attached fuzzer to be a target of assertion testing.
+// isTestableMethod checks whether the method of the provided contract is configured by the attached fuzzer to be a
+// target of assertion testing.
 // Returns true if this target should be tested, false otherwise.
-func (t *AssertionTestCaseProvider) isTestableMethod(method abi.Method) bool {
-	// Only test constant methods (pure/view) if we are configured to.
-	return !method.IsConstant() || t.fuzzer.config.Fuzzing.Testing.AssertionTesting.TestViewMethods
+func (t *AssertionTestCaseProvider) isTestableMethod(contract *contracts.Contract, method abi.Method) bool {
+	return isAssertionTestMethod(contract, method, t.fuzzer.config.Fuzzing.Testing)
+}
+
+// contractMethods returns the methods of the provided contract which may be targeted by assertion tests, including its
+// receive and fallback functions if it defines them.
+func (t *AssertionTestCaseProvider) contractMethods(contract *contracts.Contract) []abi.Method {
+	contractAbi := contract.CompiledContract().Abi
+	methods := make([]abi.Method, 0, len(contractAbi.Methods)+2)
+	for _, method := range contractAbi.Methods {
+		methods = append(methods, method)
+	}
+	if contractAbi.HasReceive() {
+		methods = append(methods, contractAbi.Receive)
+	}
+	if contractAbi.HasFallback() {
+		methods = append(methods, contractAbi.Fallback)
+	}
+	return methods
 }
 
 // checkAssertionFailures checks the results of the last call for assertion failures.
@@ -64,36 +89,81 @@ func (t *AssertionTestCaseProvider) checkAssertionFailures(callSequence calls.Ca
 	if err != nil {
 		return nil, false, err
 	}
+
+	// Calls which do not target a resolved contract cannot be assertion tests.
+	if lastCallMethod == nil {
+		return nil, false, nil
+	}
 	methodId := contracts.GetContractMethodID(lastCall.Contract, lastCallMethod)
 
 	// Check if we encountered an assertion error.
-	// Try to unpack our error and return data for a panic code and verify it matches the "assert failed" panic code.
-	// Solidity >0.8.0 introduced asserts failing as reverts but with special return data. But we indicate we also
-	// want to be backwards compatible with older Solidity which simply hit an invalid opcode and did not actually
-	// have a panic code.
+	// Try to unpack our error and return data for a panic code and verify it matches a panic code we are configured
+	// to treat as a failure (by default, only the "assert failed" panic code). Solidity >0.8.0 introduced asserts
+	// failing as reverts but with special return data. But we indicate we also want to be backwards compatible with
+	// older Solidity which simply hit an invalid opcode and did not actually have a panic code.
 	lastExecutionResult := lastCall.ChainReference.MessageResults().ExecutionResult
 	panicCode := abiutils.GetSolidityPanicCode(lastExecutionResult.Err, lastExecutionResult.ReturnData, true)
-	encounteredAssertionFailure := panicCode != nil && panicCode.Uint64() == abiutils.PanicCodeAssertFailed
+	encounteredAssertionFailure := panicCode != nil && panicCode.IsUint64() &&
+		slices.Contains(t.fuzzer.config.Fuzzing.Testing.AssertionTesting.PanicCodeFailures, panicCode.Uint64())
+
+	// If we did not encounter an assertion failure, check if we reverted with a reason we should treat as one.
+	if !encounteredAssertionFailure {
+		encounteredAssertionFailure = t.isFailingRevert(lastExecutionResult.Err, lastExecutionResult.ReturnData)
+	}
 
 	return &methodId, encounteredAssertionFailure, nil
 }
 
+// isFailingRevert checks whether the provided VM error and return data represent a revert with a reason or error
+// selector which is configured to be treated as an assertion failure.
+// Returns true if the revert should be treated as a failure, false otherwise.
+func (t *AssertionTestCaseProvider) isFailingRevert(returnError error, returnData []byte) bool {
+	if returnError != vm.ErrExecutionReverted {
+		return false
+	}
+
+	// Check if the revert reason string matches any we should fail on.
+	if len(t.failOnRevertReasons) > 0 {
+		revertReason := abiutils.GetSolidityRevertErrorString(returnError, returnData)
+		if revertReason != nil && slices.Contains(t.failOnRevertReasons, *revertReason) {
+			return true
+		}
+	}
+
+	// Check if the error selector matches any we should fail on.
+	if len(returnData) >= 4 {
+		for _, selector := range t.failOnRevertSelectors {
+			if bytes.Equal(returnData[:4], selector) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // onFuzzerStarting is the event handler triggered when the Fuzzer is starting a fuzzing campaign. It creates test cases
 // in a "not started" state for every method to test discovered in the contract definitions known to the Fuzzer.
 func (t *AssertionTestCaseProvider) onFuzzerStarting(event FuzzerStartingEvent) error {
 	// Reset our state
 	t.testCases = make(map[contracts.ContractMethodID]*AssertionTestCase)
 
+	// Parse the revert reasons and error selectors we should treat as assertion failures.
+	var err error
+	t.failOnRevertReasons, t.failOnRevertSelectors, err = t.fuzzer.config.Fuzzing.Testing.AssertionTesting.ParseFailOnRevertReasons()
+	if err != nil {
+		return err
+	}
+
 	// Create a test case for every test method.
 	for _, contract := range t.fuzzer.ContractDefinitions() {
 		// If we're not testing all contracts, verify the current contract is one we specified in our deployment order.
-		if !t.fuzzer.config.Fuzzing.Testing.TestAllContracts && !slices.Contains(t.fuzzer.config.Fuzzing.DeploymentOrder, contract.Name()) {
+		if !isTestContract(contract, t.fuzzer.config.Fuzzing) {
 			continue
 		}
 
-		for _, method := range contract.CompiledContract().Abi.Methods {
+		for _, method := range t.contractMethods(contract) {
 			// Verify this method is an assertion testable method
-			if !t.isTestableMethod(method) {
+			if !t.isTestableMethod(contract, method) {
 				continue
 			}
 
@@ -150,7 +220,7 @@ func (t *AssertionTestCaseProvider) onWorkerDeployedContractAdded(event FuzzerWo
 	}
 
 	// Loop through all methods and find ones for which we have tests
-	for _, method := range event.ContractDefinition.CompiledContract().Abi.Methods {
+	for _, method := range t.contractMethods(event.ContractDefinition) {
 		// Obtain an identifier for this pair
 		methodId := contracts.GetContractMethodID(event.ContractDefinition, &method)
 
@@ -179,6 +249,9 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 	if err != nil {
 		return nil, err
 	}
+	if methodId == nil {
+		return shrinkRequests, nil
+	}
 
 	// Obtain the test case for this method we're targeting for assertion testing.
 	t.testCasesLock.Lock()
@@ -208,7 +281,7 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 				}
 
 				// If we encountered assertion failures on the same method, this shrunk sequence is satisfactory.
-				return shrunkSeqTestFailed && *methodId == *shrunkSeqMethodId, nil
+				return shrunkSeqTestFailed && shrunkSeqMethodId != nil && *methodId == *shrunkSeqMethodId, nil
 			},
 			FinishedCallback: func(worker *FuzzerWorker, shrunkenCallSequence calls.CallSequence) error {
 				// When we're finished shrinking, attach an execution trace to the last call
@@ -222,12 +295,14 @@ func (t *AssertionTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorke
 				// Update our test state and report it finalized.
 				testCase.status = TestCaseStatusFailed
 				testCase.callSequence = &shrunkenCallSequence
-				worker.Fuzzer().ReportTestCaseFinished(testCase)
-				return nil
+				return worker.Fuzzer().ReportTestCaseFinished(testCase)
 			},
 			RecordResultInCorpus: true,
 		}
 
+		// The same verifier confirms the finalized shrunken sequence still fails the same test.
+		shrinkRequest.PostShrinkVerifierFunction = shrinkRequest.VerifierFunction
+
 		// Add our shrink request to our list.
 		shrinkRequests = append(shrinkRequests, shrinkRequest)
 	}
@@ -0,0 +1,50 @@
+package fuzzing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/crytic/medusa/fuzzing/config"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAssertionTestCaseProviderFailOnRevertReasons runs tests to ensure reverts are only treated as assertion failures
+// if they revert with a configured revert reason string or custom error selector.
+func TestAssertionTestCaseProviderFailOnRevertReasons(t *testing.T) {
+	assertionTesting := config.AssertionTestingConfig{FailOnRevertReasons: []string{"insolvent", "0x12345678"}}
+	provider := &AssertionTestCaseProvider{}
+	var err error
+	provider.failOnRevertReasons, provider.failOnRevertSelectors, err = assertionTesting.ParseFailOnRevertReasons()
+	assert.NoError(t, err)
+
+	// Create the return data for reverts with a reason string.
+	stringType, err := abi.NewType("string", "", nil)
+	assert.NoError(t, err)
+	errorMethod := abi.NewMethod("Error", "Error", abi.Function, "", false, false, abi.Arguments{{Type: stringType}}, nil)
+	newRevertReasonData := func(reason string) []byte {
+		packed, err := errorMethod.Inputs.Pack(reason)
+		assert.NoError(t, err)
+		return append(append([]byte{}, errorMethod.ID...), packed...)
+	}
+
+	tests := []struct {
+		returnError error
+		returnData  []byte
+		failing     bool
+	}{
+		{returnError: vm.ErrExecutionReverted, returnData: newRevertReasonData("insolvent"), failing: true},
+		{returnError: vm.ErrExecutionReverted, returnData: newRevertReasonData("unauthorized"), failing: false},
+		{returnError: vm.ErrExecutionReverted, returnData: []byte{0x12, 0x34, 0x56, 0x78}, failing: true},
+		{returnError: vm.ErrExecutionReverted, returnData: []byte{0x12, 0x34, 0x56, 0x78, 0x00, 0x01}, failing: true},
+		{returnError: vm.ErrExecutionReverted, returnData: []byte{0x12, 0x34, 0x56, 0x79}, failing: false},
+		{returnError: vm.ErrExecutionReverted, returnData: []byte{0x12, 0x34, 0x56}, failing: false},
+		{returnError: vm.ErrExecutionReverted, returnData: nil, failing: false},
+		{returnError: errors.New("out of gas"), returnData: []byte{0x12, 0x34, 0x56, 0x78}, failing: false},
+		{returnError: nil, returnData: newRevertReasonData("insolvent"), failing: false},
+	}
+	for i, test := range tests {
+		assert.EqualValues(t, test.failing, provider.isFailingRevert(test.returnError, test.returnData), "test %d", i)
+	}
+}
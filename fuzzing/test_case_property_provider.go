@@ -7,9 +7,7 @@ import (
 	"github.com/crytic/medusa/fuzzing/executiontracer"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/core"
-	"golang.org/x/exp/slices"
 	"math/big"
-	"strings"
 	"sync"
 )
 
@@ -65,15 +63,7 @@ func attachPropertyTestCaseProvider(fuzzer *Fuzzer) *PropertyTestCaseProvider {
 // isPropertyTest check whether the method is a property test given potential naming prefixes it must conform to
 // and its underlying input/output arguments.
 func (t *PropertyTestCaseProvider) isPropertyTest(method abi.Method) bool {
-	// Loop through all enabled prefixes to find a match
-	for _, prefix := range t.fuzzer.Config().Fuzzing.Testing.PropertyTesting.TestPrefixes {
-		if strings.HasPrefix(method.Name, prefix) {
-			if len(method.Inputs) == 0 && len(method.Outputs) == 1 && method.Outputs[0].Type.T == abi.BoolTy {
-				return true
-			}
-		}
-	}
-	return false
+	return isPropertyTestMethod(method, t.fuzzer.Config().Fuzzing.Testing)
 }
 
 // checkPropertyTestFailed executes a given property test method to see if it returns a failed status. This is used to
@@ -144,7 +134,7 @@ func (t *PropertyTestCaseProvider) onFuzzerStarting(event FuzzerStartingEvent) e
 	// Create a test case for every property test method.
 	for _, contract := range t.fuzzer.ContractDefinitions() {
 		// If we're not testing all contracts, verify the current contract is one we specified in our deployment order.
-		if !t.fuzzer.config.Fuzzing.Testing.TestAllContracts && !slices.Contains(t.fuzzer.config.Fuzzing.DeploymentOrder, contract.Name()) {
+		if !isTestContract(contract, t.fuzzer.config.Fuzzing) {
 			continue
 		}
 
@@ -349,12 +339,14 @@ func (t *PropertyTestCaseProvider) callSequencePostCallTest(worker *FuzzerWorker
 					testCase.status = TestCaseStatusFailed
 					testCase.callSequence = &shrunkenCallSequence
 					testCase.propertyTestTrace = executionTrace
-					worker.Fuzzer().ReportTestCaseFinished(testCase)
-					return nil
+					return worker.Fuzzer().ReportTestCaseFinished(testCase)
 				},
 				RecordResultInCorpus: true,
 			}
 
+			// The same verifier confirms the finalized shrunken sequence still fails the same test.
+			shrinkRequest.PostShrinkVerifierFunction = shrinkRequest.VerifierFunction
+
 			// Add our shrink request to our list.
 			shrinkRequests = append(shrinkRequests, shrinkRequest)
 		}
@@ -0,0 +1,15 @@
+// This contract ensures the fuzzer reports panics with codes it is configured to treat as assertion failures, while
+// ignoring others.
+contract TestContract {
+    uint[] values;
+
+    function divideByZero(uint value, uint divisor) public {
+        // This triggers a division by zero panic (0x12) when the divisor is zero.
+        uint result = value / divisor;
+    }
+
+    function arrayOutOfBounds(uint value) public {
+        // This triggers an out-of-bounds array access panic (0x32).
+        values[value];
+    }
+}
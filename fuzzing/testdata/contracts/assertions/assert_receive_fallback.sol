@@ -0,0 +1,15 @@
+// This contract ensures the fuzzer can encounter assertion failures in receive and fallback functions.
+contract TestContract {
+    function doNothing() public {
+    }
+
+    receive() external payable {
+        // ASSERTION: We always fail when value is sent without call data.
+        assert(false);
+    }
+
+    fallback() external {
+        // ASSERTION: We always fail when a call matches no method.
+        assert(false);
+    }
+}
@@ -0,0 +1,20 @@
+// This contract ensures the fuzzer only tests the view methods it is configured to when testing view methods only.
+contract TestContract {
+    uint x;
+
+    function failing_state_changing_method(uint value) public {
+        // ASSERTION: We always fail when you call this function.
+        x = value;
+        assert(false);
+    }
+
+    function failingIncludedViewMethod() public view {
+        // ASSERTION: We always fail when you call this function.
+        assert(x != x);
+    }
+
+    function failingExcludedViewMethod() public view {
+        // ASSERTION: We always fail when you call this function.
+        assert(x != x);
+    }
+}
@@ -0,0 +1,19 @@
+// This contract ensures the zero value smoke test reports methods which cannot be called by fuzzer senders.
+contract TestContract {
+    address owner;
+    uint x;
+
+    constructor() {
+        owner = msg.sender;
+    }
+
+    function setX(uint value) public {
+        x = value;
+    }
+
+    function setXAsOwner(uint value) public {
+        // Only the deployer may call this method, so calls from senders always revert.
+        require(msg.sender == owner);
+        x = value;
+    }
+}
@@ -0,0 +1,203 @@
+package valuegeneration
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"golang.org/x/exp/slices"
+)
+
+// AbiTypesEqual compares two abi.Type objects for structural equality. Element types of arrays and slices, as well as
+// tuple element types and field names, are compared recursively.
+// Returns a boolean indicating whether the provided types are equal.
+func AbiTypesEqual(a, b *abi.Type) bool {
+	// If either type is nil, they are only equal if both are.
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	// Compare the type's kind and size information.
+	if a.T != b.T || a.Size != b.Size {
+		return false
+	}
+
+	// Compare any element type for arrays and slices.
+	if (a.T == abi.ArrayTy || a.T == abi.SliceTy) && !AbiTypesEqual(a.Elem, b.Elem) {
+		return false
+	}
+
+	// Compare tuple names and each of its elements.
+	if a.T == abi.TupleTy {
+		if a.TupleRawName != b.TupleRawName || !slices.Equal(a.TupleRawNames, b.TupleRawNames) {
+			return false
+		}
+		if len(a.TupleElems) != len(b.TupleElems) {
+			return false
+		}
+		for i := 0; i < len(a.TupleElems); i++ {
+			if !AbiTypesEqual(a.TupleElems[i], b.TupleElems[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CheckAbiTypeNotRecursive verifies that the provided abi.Type does not contain itself through its element or tuple
+// element types, either by referencing an enclosing type or by containing a tuple of the same struct as an enclosing
+// tuple. Recursive types cannot be instantiated through reflection, and would otherwise cause value generation to
+// recurse until the stack overflows. This is intended to be checked once per method, prior to generating values.
+// Returns an error if the type is recursive.
+func CheckAbiTypeNotRecursive(inputType *abi.Type) error {
+	return checkAbiTypeNotRecursive(inputType, make(map[*abi.Type]struct{}), make(map[string]struct{}))
+}
+
+// checkAbiTypeNotRecursive verifies that the provided abi.Type is not one of the types currently being visited, and
+// is not a tuple of the same struct as one being visited, where visited types are those enclosing it. It is used by
+// CheckAbiTypeNotRecursive.
+// Returns an error if the type is recursive.
+func checkAbiTypeNotRecursive(inputType *abi.Type, visitingTypes map[*abi.Type]struct{}, visitingTupleNames map[string]struct{}) error {
+	if inputType == nil {
+		return nil
+	}
+	if _, ok := visitingTypes[inputType]; ok {
+		return fmt.Errorf("abi type '%s' is recursive", inputType.String())
+	}
+
+	// Mark this type as being visited while checking its element types, so references back to it are detected.
+	visitingTypes[inputType] = struct{}{}
+	defer delete(visitingTypes, inputType)
+	switch inputType.T {
+	case abi.ArrayTy, abi.SliceTy:
+		return checkAbiTypeNotRecursive(inputType.Elem, visitingTypes, visitingTupleNames)
+	case abi.TupleTy:
+		// Tuples describing named structs are also identified by their struct name, as they may be redefined by
+		// distinct types.
+		if inputType.TupleRawName != "" {
+			if _, ok := visitingTupleNames[inputType.TupleRawName]; ok {
+				return fmt.Errorf("abi type '%s' is recursive", inputType.TupleRawName)
+			}
+			visitingTupleNames[inputType.TupleRawName] = struct{}{}
+			defer delete(visitingTupleNames, inputType.TupleRawName)
+		}
+		for _, elem := range inputType.TupleElems {
+			if err := checkAbiTypeNotRecursive(elem, visitingTypes, visitingTupleNames); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ParseAbiSignature parses a human-written method signature (e.g. "transfer(address,uint256)") into the method name and
+// its abi.Arguments, without requiring an ABI definition. Arguments may be followed by a parameter name (e.g.
+// "transfer(address to, uint256 amount)"), and may be tuples or arrays of tuples (e.g. "f((uint256,bool)[2],bytes)").
+// Tuple components are named by their position (e.g. "field0"), as signatures do not describe them.
+// Returns the method name and arguments, or an error if the signature could not be parsed.
+func ParseAbiSignature(signature string) (string, abi.Arguments, error) {
+	signature = strings.TrimSpace(signature)
+
+	// Split the method name from its argument list.
+	argsIndex := strings.Index(signature, "(")
+	if argsIndex < 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("could not parse abi signature '%v': expected an argument list enclosed in parentheses", signature)
+	}
+	name := strings.TrimSpace(signature[:argsIndex])
+
+	// Parse each argument into its type marshaling, then construct the abi.Type from it.
+	argMarshalings, err := parseAbiSignatureArguments(signature[argsIndex+1 : len(signature)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("could not parse abi signature '%v': %v", signature, err)
+	}
+	args := make(abi.Arguments, len(argMarshalings))
+	for i, argMarshaling := range argMarshalings {
+		argType, err := abi.NewType(argMarshaling.Type, "", argMarshaling.Components)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not parse abi signature '%v': %v", signature, err)
+		}
+		args[i] = abi.Argument{Name: argMarshaling.Name, Type: argType}
+	}
+	return name, args, nil
+}
+
+// parseAbiSignatureArguments parses a comma-separated list of arguments from within a signature's parentheses into
+// their abi.ArgumentMarshaling, which describes the type string and any tuple components for each argument. It is
+// used by ParseAbiSignature.
+// Returns the parsed argument marshalings, or an error if the argument list could not be parsed.
+func parseAbiSignatureArguments(argsString string) ([]abi.ArgumentMarshaling, error) {
+	// An empty argument list has no arguments.
+	if strings.TrimSpace(argsString) == "" {
+		return []abi.ArgumentMarshaling{}, nil
+	}
+
+	// Split the arguments on commas which are not nested within a tuple.
+	argStrings := make([]string, 0)
+	depth, start := 0, 0
+	for i, c := range argsString {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in '%v'", argsString)
+			}
+		case ',':
+			if depth == 0 {
+				argStrings = append(argStrings, argsString[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in '%v'", argsString)
+	}
+	argStrings = append(argStrings, argsString[start:])
+
+	// Parse each argument.
+	argMarshalings := make([]abi.ArgumentMarshaling, len(argStrings))
+	for i, argString := range argStrings {
+		argString = strings.TrimSpace(argString)
+		if argString == "" {
+			return nil, fmt.Errorf("empty argument at position %d", i)
+		}
+
+		// Tuples are described by their components enclosed in parentheses, followed by any array suffixes. Other
+		// types are described by a single token.
+		var typeString, argName string
+		var components []abi.ArgumentMarshaling
+		if argString[0] == '(' {
+			closeIndex := strings.LastIndex(argString, ")")
+			var err error
+			components, err = parseAbiSignatureArguments(argString[1:closeIndex])
+			if err != nil {
+				return nil, err
+			}
+			for j := range components {
+				components[j].Name = fmt.Sprintf("field%d", j)
+			}
+
+			// Split any array suffixes from a trailing parameter name.
+			suffix := argString[closeIndex+1:]
+			suffixEnd := strings.IndexFunc(suffix, unicode.IsSpace)
+			if suffixEnd < 0 {
+				suffixEnd = len(suffix)
+			}
+			typeString, argName = "tuple"+suffix[:suffixEnd], suffix[suffixEnd:]
+		} else {
+			fields := strings.Fields(argString)
+			typeString = fields[0]
+			argName = strings.Join(fields[1:], " ")
+		}
+
+		// Parameter names may only be a single identifier.
+		argName = strings.TrimSpace(argName)
+		if strings.IndexFunc(argName, unicode.IsSpace) >= 0 {
+			return nil, fmt.Errorf("could not parse argument '%v'", argString)
+		}
+		argMarshalings[i] = abi.ArgumentMarshaling{Name: argName, Type: typeString, Components: components}
+	}
+	return argMarshalings, nil
+}
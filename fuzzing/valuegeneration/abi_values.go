@@ -1,13 +1,16 @@
 package valuegeneration
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/crytic/medusa/utils"
 	"github.com/crytic/medusa/utils/reflectionutils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -17,43 +20,83 @@ import (
 // contract address will be resolved by searching the deployed contracts for a contract with this name.
 const addressJSONContractNameOverridePrefix = "DeployedContract:"
 
-// GenerateAbiValue generates a value of the provided abi.Type using the provided ValueGenerator.
-// The generated value is returned.
-func GenerateAbiValue(generator ValueGenerator, inputType *abi.Type) any {
+// GenerateAbiValue generates a value of the provided abi.Type using the provided ValueGenerator. If the generator
+// implements ValueGenerationStrategyProvider, leaf values (addresses, integers, dynamic bytes and strings) are
+// generated by a ValueGenerationStrategy it selects. If it implements AbiValueGenerationStatsProvider, generated values
+// are recorded in the statistics it provides.
+// Returns the generated value, or an error if the type is unsupported.
+// Note: Recursive types cannot be generated. CheckAbiTypeNotRecursive should be used to verify a type once, prior to
+// generating values for it.
+func GenerateAbiValue(generator ValueGenerator, inputType *abi.Type) (any, error) {
+	return GenerateAbiValueWithContext(context.Background(), generator, inputType)
+}
+
+// GenerateAbiValueWithContext generates a value of the provided abi.Type using the provided ValueGenerator, the same
+// way GenerateAbiValue does. The provided context is checked while generating the elements of arrays, slices and
+// tuples, so generation of large nested values can be cancelled promptly.
+// Returns the generated value, the context's error if it was cancelled during generation, or an error if the type is
+// unsupported.
+func GenerateAbiValueWithContext(ctx context.Context, generator ValueGenerator, inputType *abi.Type) (any, error) {
+	return generateAbiValue(ctx, generator, inputType)
+}
+
+// generateAbiValue generates a value of the provided abi.Type using the provided ValueGenerator. It is used by
+// GenerateAbiValueWithContext, and to generate the elements of composite values.
+// Returns the generated value, the context's error if it was cancelled during generation, or an error if the type is
+// unsupported.
+func generateAbiValue(ctx context.Context, generator ValueGenerator, inputType *abi.Type) (any, error) {
+	// If our context was cancelled, stop generating. This is checked for every element of a composite value.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Generate our value, recording it in the generator's statistics if it collects them.
+	value, err := generateAbiValueOfType(ctx, generator, inputType)
+	if err == nil {
+		recordAbiValueGenerated(generator, inputType, value)
+	}
+	return value, err
+}
+
+// generateAbiValueOfType generates a value of the provided abi.Type using the provided ValueGenerator, by the type's
+// category. It is used by generateAbiValue, which it calls to generate the elements of composite values.
+// Returns the generated value, the context's error if it was cancelled during generation, or an error if the type is
+// unsupported.
+func generateAbiValueOfType(ctx context.Context, generator ValueGenerator, inputType *abi.Type) (any, error) {
 	// Determine the type of value to generate based on the ABI type.
 	switch inputType.T {
 	case abi.AddressTy:
-		return generator.GenerateAddress()
+		return generateAddress(generator), nil
 	case abi.UintTy:
 		if inputType.Size == 64 {
-			return generator.GenerateInteger(false, inputType.Size).Uint64()
+			return generateInteger(generator, false, inputType.Size).Uint64(), nil
 		} else if inputType.Size == 32 {
-			return uint32(generator.GenerateInteger(false, inputType.Size).Uint64())
+			return uint32(generateInteger(generator, false, inputType.Size).Uint64()), nil
 		} else if inputType.Size == 16 {
-			return uint16(generator.GenerateInteger(false, inputType.Size).Uint64())
+			return uint16(generateInteger(generator, false, inputType.Size).Uint64()), nil
 		} else if inputType.Size == 8 {
-			return uint8(generator.GenerateInteger(false, inputType.Size).Uint64())
+			return uint8(generateInteger(generator, false, inputType.Size).Uint64()), nil
 		} else {
-			return generator.GenerateInteger(false, inputType.Size)
+			return generateInteger(generator, false, inputType.Size), nil
 		}
 	case abi.IntTy:
 		if inputType.Size == 64 {
-			return generator.GenerateInteger(true, inputType.Size).Int64()
+			return generateInteger(generator, true, inputType.Size).Int64(), nil
 		} else if inputType.Size == 32 {
-			return int32(generator.GenerateInteger(true, inputType.Size).Int64())
+			return int32(generateInteger(generator, true, inputType.Size).Int64()), nil
 		} else if inputType.Size == 16 {
-			return int16(generator.GenerateInteger(true, inputType.Size).Int64())
+			return int16(generateInteger(generator, true, inputType.Size).Int64()), nil
 		} else if inputType.Size == 8 {
-			return int8(generator.GenerateInteger(true, inputType.Size).Int64())
+			return int8(generateInteger(generator, true, inputType.Size).Int64()), nil
 		} else {
-			return generator.GenerateInteger(true, inputType.Size)
+			return generateInteger(generator, true, inputType.Size), nil
 		}
 	case abi.BoolTy:
-		return generator.GenerateBool()
+		return generator.GenerateBool(), nil
 	case abi.StringTy:
-		return generator.GenerateString()
+		return generateString(generator), nil
 	case abi.BytesTy:
-		return generator.GenerateBytes()
+		return generateBytes(generator), nil
 	case abi.FixedBytesTy:
 		// This needs to be an array type, not a slice. But arrays can't be dynamically defined without reflection.
 		// We opt to keep our API for generators simple, creating the array here and copying elements from a slice.
@@ -62,41 +105,282 @@ func GenerateAbiValue(generator ValueGenerator, inputType *abi.Type) any {
 		for i := 0; i < array.Len(); i++ {
 			array.Index(i).Set(bytes.Index(i))
 		}
-		return array.Interface()
+		return array.Interface(), nil
+	case abi.FunctionTy:
+		// Function types are represented as the address of a contract followed by a method selector (24 bytes).
+		var function [24]byte
+		address := generateAddress(generator)
+		copy(function[:common.AddressLength], address.Bytes())
+		copy(function[common.AddressLength:], generator.GenerateFixedBytes(4))
+		return function, nil
 	case abi.ArrayTy:
 		// Read notes for fixed bytes to understand the need to create this array through reflection.
 		array := reflect.Indirect(reflect.New(inputType.GetType()))
 		for i := 0; i < array.Len(); i++ {
-			array.Index(i).Set(reflect.ValueOf(GenerateAbiValue(generator, inputType.Elem)))
+			element, err := generateAbiValue(ctx, generator, inputType.Elem)
+			if err != nil {
+				return nil, err
+			}
+			array.Index(i).Set(reflect.ValueOf(element))
 		}
-		return array.Interface()
+		return array.Interface(), nil
 	case abi.SliceTy:
 		// Dynamic sized arrays are represented as slices.
-		sliceSize := generator.GenerateArrayOfLength()
+		sliceSize := generateArrayLength(generator, inputType)
 		slice := reflect.MakeSlice(inputType.GetType(), sliceSize, sliceSize)
 		for i := 0; i < slice.Len(); i++ {
-			slice.Index(i).Set(reflect.ValueOf(GenerateAbiValue(generator, inputType.Elem)))
+			element, err := generateAbiValue(ctx, generator, inputType.Elem)
+			if err != nil {
+				return nil, err
+			}
+			slice.Index(i).Set(reflect.ValueOf(element))
 		}
-		return slice.Interface()
+		return slice.Interface(), nil
 	case abi.TupleTy:
 		// Tuples are used to represent structs. For go-ethereum's ABI provider, we're intended to supply matching
 		// struct implementations, so we create and populate them through reflection.
 		st := reflect.Indirect(reflect.New(inputType.GetType()))
 		for i := 0; i < len(inputType.TupleElems); i++ {
 			field := st.Field(i)
-			fieldValue := GenerateAbiValue(generator, inputType.TupleElems[i])
+			fieldValue, err := generateAbiValue(ctx, generator, inputType.TupleElems[i])
+			if err != nil {
+				return nil, err
+			}
 			reflectionutils.SetField(field, fieldValue)
 		}
-		return st.Interface()
+
+		// Allow any provided fixup to adjust the fields for consistency, now that all are generated.
+		if err := applyTupleFixup(generator, inputType, st); err != nil {
+			return nil, err
+		}
+		return st.Interface(), nil
 	default:
-		// Unexpected types will result in a panic as we should support these values as soon as possible:
+		// Unexpected types will result in an error as we should support these values as soon as possible:
 		// - Mappings cannot be used in public/external methods and must reference storage, so we shouldn't ever
 		//	 see cases of it unless Solidity was updated in the future.
 		// - FixedPoint types are currently unsupported.
+		return nil, fmt.Errorf("attempt to generate function argument of unsupported type: '%s'", inputType.String())
+	}
+}
+
+// GenerateZeroAbiValue generates the zero value of the provided abi.Type, mirroring the structure of values produced
+// by GenerateAbiValue. Integers are zero, addresses are the zero address, dynamic bytes, strings and arrays are empty,
+// and fixed-size arrays and tuples contain zero values for each of their elements.
+// The generated value is returned.
+func GenerateZeroAbiValue(inputType *abi.Type) any {
+	switch inputType.T {
+	case abi.AddressTy:
+		return common.Address{}
+	case abi.UintTy:
+		if inputType.Size == 64 {
+			return uint64(0)
+		} else if inputType.Size == 32 {
+			return uint32(0)
+		} else if inputType.Size == 16 {
+			return uint16(0)
+		} else if inputType.Size == 8 {
+			return uint8(0)
+		} else {
+			return big.NewInt(0)
+		}
+	case abi.IntTy:
+		if inputType.Size == 64 {
+			return int64(0)
+		} else if inputType.Size == 32 {
+			return int32(0)
+		} else if inputType.Size == 16 {
+			return int16(0)
+		} else if inputType.Size == 8 {
+			return int8(0)
+		} else {
+			return big.NewInt(0)
+		}
+	case abi.BoolTy:
+		return false
+	case abi.StringTy:
+		return ""
+	case abi.BytesTy:
+		return []byte{}
+	case abi.FixedBytesTy, abi.FunctionTy:
+		// Fixed bytes and function types are arrays of bytes, which are zeroed upon creation.
+		return reflect.Indirect(reflect.New(inputType.GetType())).Interface()
+	case abi.ArrayTy:
+		array := reflect.Indirect(reflect.New(inputType.GetType()))
+		for i := 0; i < array.Len(); i++ {
+			array.Index(i).Set(reflect.ValueOf(GenerateZeroAbiValue(inputType.Elem)))
+		}
+		return array.Interface()
+	case abi.SliceTy:
+		return reflect.MakeSlice(inputType.GetType(), 0, 0).Interface()
+	case abi.TupleTy:
+		st := reflect.Indirect(reflect.New(inputType.GetType()))
+		for i := 0; i < len(inputType.TupleElems); i++ {
+			reflectionutils.SetField(st.Field(i), GenerateZeroAbiValue(inputType.TupleElems[i]))
+		}
+		return st.Interface()
+	default:
 		panic(fmt.Sprintf("attempt to generate function argument of unsupported type: '%s'", inputType.String()))
 	}
 }
 
+// generateArrayLength generates a length for a dynamic array of the provided type, using the ValueGenerator's
+// TypedArrayLengthGenerator implementation if it has one.
+func generateArrayLength(generator ValueGenerator, inputType *abi.Type) int {
+	if typedGenerator, ok := generator.(TypedArrayLengthGenerator); ok {
+		return typedGenerator.GenerateArrayOfLengthForType(inputType)
+	}
+	return generator.GenerateArrayOfLength()
+}
+
+// TruncateAbiValue takes an ABI packable input value, alongside its type definition, and halves the length of every
+// dynamic value (slices, bytes, and strings) within it, recursively. This is used to reduce the encoded size of a value.
+// Returns the truncated value, and a boolean indicating whether any dynamic value was truncated.
+func TruncateAbiValue(inputType *abi.Type, value any) (any, bool) {
+	switch inputType.T {
+	case abi.StringTy:
+		str, ok := value.(string)
+		if !ok || len(str) == 0 {
+			return value, false
+		}
+		return str[:len(str)/2], true
+	case abi.BytesTy:
+		b, ok := value.([]byte)
+		if !ok || len(b) == 0 {
+			return value, false
+		}
+		return b[:len(b)/2], true
+	case abi.SliceTy:
+		// Halve our slice, then truncate the remaining elements.
+		reflectedSlice := reflect.ValueOf(value)
+		truncated := reflectedSlice.Len() > 0
+		slice := reflect.MakeSlice(reflectedSlice.Type(), reflectedSlice.Len()/2, reflectedSlice.Len()/2)
+		for i := 0; i < slice.Len(); i++ {
+			element, elementTruncated := TruncateAbiValue(inputType.Elem, reflectedSlice.Index(i).Interface())
+			slice.Index(i).Set(reflect.ValueOf(element))
+			truncated = truncated || elementTruncated
+		}
+		return slice.Interface(), truncated
+	case abi.ArrayTy:
+		// Arrays have a fixed length, so we only truncate their elements.
+		// Note: We create a copy, as existing arrays may not be assignable.
+		array := reflectionutils.CopyReflectedType(reflect.ValueOf(value))
+		truncated := false
+		for i := 0; i < array.Len(); i++ {
+			element, elementTruncated := TruncateAbiValue(inputType.Elem, array.Index(i).Interface())
+			array.Index(i).Set(reflect.ValueOf(element))
+			truncated = truncated || elementTruncated
+		}
+		return array.Interface(), truncated
+	case abi.TupleTy:
+		// Structs are used to represent tuples, so we truncate each of their fields.
+		// Note: We create a copy, as existing tuples may not be assignable.
+		tuple := reflectionutils.CopyReflectedType(reflect.ValueOf(value))
+		truncated := false
+		for i := 0; i < len(inputType.TupleElems); i++ {
+			field := tuple.Field(i)
+			fieldValue, fieldTruncated := TruncateAbiValue(inputType.TupleElems[i], reflectionutils.GetField(field))
+			reflectionutils.SetField(field, fieldValue)
+			truncated = truncated || fieldTruncated
+		}
+		return tuple.Interface(), truncated
+	default:
+		// Other types are statically sized and cannot be truncated.
+		return value, false
+	}
+}
+
+// ValidateAbiValue verifies that the provided value is a go-ethereum ABI packable value of the provided abi.Type. The
+// Go type of the value must match the one expected for the abi.Type, and integers represented as big.Int must fit
+// within the bit length and signedness of their type. Elements of arrays, slices and tuples are validated recursively.
+// Returns an error describing the mismatch if the value is not valid, or nil if it is.
+func ValidateAbiValue(inputType *abi.Type, value any) error {
+	if value == nil {
+		return fmt.Errorf("value for %v is nil", inputType.String())
+	}
+	reflectedValue := reflect.ValueOf(value)
+
+	switch inputType.T {
+	case abi.ArrayTy, abi.SliceTy:
+		// Verify the container kind and length, then validate each element.
+		expectedKind := reflect.Array
+		if inputType.T == abi.SliceTy {
+			expectedKind = reflect.Slice
+		}
+		if reflectedValue.Kind() != expectedKind {
+			return fmt.Errorf("value for %v is of type %v, expected %v", inputType.String(), reflectedValue.Type(), inputType.GetType())
+		}
+		if inputType.T == abi.ArrayTy && reflectedValue.Len() != inputType.Size {
+			return fmt.Errorf("value for %v has %d elements, expected %d", inputType.String(), reflectedValue.Len(), inputType.Size)
+		}
+		for i := 0; i < reflectedValue.Len(); i++ {
+			if err := ValidateAbiValue(inputType.Elem, reflectedValue.Index(i).Interface()); err != nil {
+				return fmt.Errorf("invalid element %d of %v: %v", i, inputType.String(), err)
+			}
+		}
+		return nil
+	case abi.TupleTy:
+		// Tuples may be represented by any struct with a field for each tuple element, so we validate each field.
+		if reflectedValue.Kind() != reflect.Struct || reflectedValue.NumField() != len(inputType.TupleElems) {
+			return fmt.Errorf("value for %v is of type %v, expected a struct with %d fields", inputType.String(), reflectedValue.Type(), len(inputType.TupleElems))
+		}
+		for i := 0; i < len(inputType.TupleElems); i++ {
+			if err := ValidateAbiValue(inputType.TupleElems[i], reflectedValue.Field(i).Interface()); err != nil {
+				// Tuple types constructed without raw names are described by field index instead.
+				if i < len(inputType.TupleRawNames) {
+					return fmt.Errorf("invalid field '%v' of %v: %v", inputType.TupleRawNames[i], inputType.String(), err)
+				}
+				return fmt.Errorf("invalid field %d of %v: %v", i, inputType.String(), err)
+			}
+		}
+		return nil
+	}
+
+	// All other types must be of the exact Go type go-ethereum expects.
+	if reflectedValue.Type() != inputType.GetType() {
+		return fmt.Errorf("value for %v is of type %v, expected %v", inputType.String(), reflectedValue.Type(), inputType.GetType())
+	}
+
+	// Integers represented by a big.Int must additionally be within the bounds of their type.
+	if integer, ok := value.(*big.Int); ok && (inputType.T == abi.UintTy || inputType.T == abi.IntTy) {
+		if integer == nil {
+			return fmt.Errorf("value for %v is nil", inputType.String())
+		}
+		minValue, maxValue := utils.GetIntegerConstraints(inputType.T == abi.IntTy, inputType.Size)
+		if integer.Cmp(minValue) < 0 || integer.Cmp(maxValue) > 0 {
+			return fmt.Errorf("value %v is out of range for %v", integer, inputType.String())
+		}
+	}
+	return nil
+}
+
+// ShrinkIntegerBySignReinterpretation attempts to shrink an integer of the provided signedness and bit length by
+// flipping the most significant bit of its two's complement bit pattern, reinterpreting it across the signed boundary.
+// For signed integers, this maps large positive values to small negative ones (e.g. int256 max maps to -1) and large
+// negative values to small positive ones. For unsigned integers, this clears the most significant bit.
+// Returns the reinterpreted value and true if its magnitude is smaller than the provided value, otherwise nil and false.
+func ShrinkIntegerBySignReinterpretation(i *big.Int, signed bool, bitLength int) (*big.Int, bool) {
+	if i == nil || bitLength <= 0 {
+		return nil, false
+	}
+
+	// Obtain the value's two's complement bit pattern and flip its most significant bit.
+	modulus := new(big.Int).Lsh(big.NewInt(1), uint(bitLength))
+	pattern := new(big.Int).Mod(i, modulus)
+	pattern.Xor(pattern, new(big.Int).Rsh(modulus, 1))
+
+	// Reinterpret the bit pattern with the provided signedness.
+	reinterpreted := pattern
+	if signed && pattern.Bit(bitLength-1) == 1 {
+		reinterpreted = new(big.Int).Sub(pattern, modulus)
+	}
+
+	// Only report the reinterpretation if it is smaller in magnitude.
+	if new(big.Int).Abs(reinterpreted).Cmp(new(big.Int).Abs(i)) >= 0 {
+		return nil, false
+	}
+	return reinterpreted, true
+}
+
 // MutateAbiValue takes an ABI packable input value, alongside its type definition and a value generator, to mutate
 // existing ABI input values.
 func MutateAbiValue(generator ValueGenerator, inputType *abi.Type, value any) (any, error) {
@@ -190,15 +474,15 @@ func MutateAbiValue(generator ValueGenerator, inputType *abi.Type, value any) (a
 			return nil, fmt.Errorf("could not mutate dynamic-sized bytes input as the value provided is not a byte slice type")
 		}
 		return generator.MutateBytes(v), nil
-	case abi.FixedBytesTy:
+	case abi.FixedBytesTy, abi.FunctionTy:
+		// Function types are represented as 24-byte arrays, so they are mutated like fixed-sized bytes.
 		// This needs to be an array type, not a slice. But arrays can't be dynamically defined without reflection.
 		// We opt to keep our API for generators simple, creating the array here and copying elements from a slice.
 		valueAsSlice := reflectionutils.ArrayToSlice(reflect.ValueOf(value)).([]byte)
 		mutatedValue := generator.MutateFixedBytes(valueAsSlice)
-		mutatedValueAsArray := reflectionutils.SliceToArray(reflect.ValueOf(mutatedValue))
-		mutatedValueAsArrayLen := reflect.ValueOf(mutatedValueAsArray).Len()
-		if mutatedValueAsArrayLen != inputType.Size {
-			return nil, fmt.Errorf("could not mutate fixed-sized bytes input as the mutated value returned was not of the correct length. expected %v, got %v", inputType.Size, mutatedValueAsArrayLen)
+		mutatedValueAsArray, err := reflectionutils.SliceToArray(reflect.ValueOf(mutatedValue), inputType.Size)
+		if err != nil {
+			return nil, fmt.Errorf("could not mutate fixed-sized bytes input as the mutated value returned was not of the correct length: %v", err)
 		}
 		return mutatedValueAsArray, nil
 	case abi.ArrayTy:
@@ -219,7 +503,10 @@ func MutateAbiValue(generator ValueGenerator, inputType *abi.Type, value any) (a
 
 			// If any item is nil, we generate a new element in its place instead. Otherwise, we mutate the existing value.
 			if mutatedValues[i] == nil {
-				generatedElement := GenerateAbiValue(generator, inputType.Elem)
+				generatedElement, err := GenerateAbiValue(generator, inputType.Elem)
+				if err != nil {
+					return nil, fmt.Errorf("could not mutate array input as the value generator encountered an error: %v", err)
+				}
 				reflectedElement.Set(reflect.ValueOf(generatedElement))
 			} else {
 				mutatedElement, err := MutateAbiValue(generator, inputType.Elem, mutatedValues[i])
@@ -249,7 +536,10 @@ func MutateAbiValue(generator ValueGenerator, inputType *abi.Type, value any) (a
 
 			// If any item is nil, we generate a new element in its place instead. Otherwise, we mutate the existing value.
 			if mutatedValues[i] == nil {
-				generatedElement := GenerateAbiValue(generator, inputType.Elem)
+				generatedElement, err := GenerateAbiValue(generator, inputType.Elem)
+				if err != nil {
+					return nil, fmt.Errorf("could not mutate slice input as the value generator encountered an error: %v", err)
+				}
 				reflectedElement.Set(reflect.ValueOf(generatedElement))
 			} else {
 				mutatedElement, err := MutateAbiValue(generator, inputType.Elem, mutatedValues[i])
@@ -273,39 +563,97 @@ func MutateAbiValue(generator ValueGenerator, inputType *abi.Type, value any) (a
 			}
 			reflectionutils.SetField(field, mutatedValue)
 		}
+
+		// Allow any provided fixup to adjust the fields for consistency, now that all are mutated.
+		if err := applyTupleFixup(generator, inputType, tuple); err != nil {
+			return nil, fmt.Errorf("could not mutate struct/tuple input: %v", err)
+		}
 		return tuple.Interface(), nil
 	default:
 		return nil, fmt.Errorf("could not mutate argument, type is unsupported: %v", inputType)
 	}
 }
 
+// jsonArgumentKeys obtains the keys used to represent each input argument in a map of JSON encoded arguments. This is
+// the argument's name, or a positional key (e.g. "arg0", "arg1") if the argument is unnamed, so unnamed arguments do
+// not collide with one another. If a positional key is already the name of another argument, it is suffixed with
+// underscores until it is unique.
+func jsonArgumentKeys(inputs abi.Arguments) []string {
+	// Reserve the names of named arguments first, so positional keys never collide with them.
+	usedKeys := make(map[string]struct{}, len(inputs))
+	for _, input := range inputs {
+		if input.Name != "" {
+			usedKeys[input.Name] = struct{}{}
+		}
+	}
+
+	keys := make([]string, len(inputs))
+	for i, input := range inputs {
+		if input.Name != "" {
+			keys[i] = input.Name
+			continue
+		}
+		key := fmt.Sprintf("arg%d", i)
+		for {
+			if _, used := usedKeys[key]; !used {
+				break
+			}
+			key += "_"
+		}
+		usedKeys[key] = struct{}{}
+		keys[i] = key
+	}
+	return keys
+}
+
+// UnknownJSONArgumentKeys obtains the keys in a map of JSON encoded arguments which do not correspond to any of the
+// provided input arguments, as they would be ignored by DecodeJSONArgumentsFromMap.
+// Returns the unknown keys, sorted alphabetically.
+func UnknownJSONArgumentKeys(inputs abi.Arguments, values map[string]any) []string {
+	knownKeys := make(map[string]struct{}, len(inputs))
+	for _, key := range jsonArgumentKeys(inputs) {
+		knownKeys[key] = struct{}{}
+	}
+	unknownKeys := make([]string, 0)
+	for key := range values {
+		if _, ok := knownKeys[key]; !ok {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+	sort.Strings(unknownKeys)
+	return unknownKeys
+}
+
 // EncodeJSONArgumentsToMap encodes provided go-ethereum ABI packable input values into a generic JSON type values
-// (e.g. []any, map[string]any, etc).
+// (e.g. []any, map[string]any, etc). Values are keyed by argument name, or by position (e.g. "arg0", "arg1") for
+// unnamed arguments. Optionally, JSONArgumentCodecs may be provided to customize the encoding of values by type.
 // Returns the encoded values, or an error if one occurs.
-func EncodeJSONArgumentsToMap(inputs abi.Arguments, values []any) (map[string]any, error) {
+func EncodeJSONArgumentsToMap(inputs abi.Arguments, values []any, codecs JSONArgumentCodecs) (map[string]any, error) {
 	// Create a variable to store encoded arguments, fill it with the respective encoded arguments.
 	var encodedArgs = make(map[string]any)
+	keys := jsonArgumentKeys(inputs)
 	for i, input := range inputs {
-		arg, err := encodeJSONArgument(&input.Type, values[i])
+		arg, err := encodeJSONArgument(&input.Type, values[i], codecs)
 		if err != nil {
 			err = fmt.Errorf("ABI value argument could not be decoded from JSON: \n"+
 				"name: %v, abi type: %v, value: %v error: %s",
 				input.Name, input.Type, values[i], err)
 			return nil, err
 		}
-		encodedArgs[input.Name] = arg
+		encodedArgs[keys[i]] = arg
 	}
 	return encodedArgs, nil
 }
 
 // EncodeJSONArgumentsToSlice encodes provided go-ethereum ABI packable input values into generic JSON compatible values
-// (e.g. []any, map[string]any, etc).
+// (e.g. []any, map[string]any, etc). Optionally, JSONArgumentCodecs may be provided to customize the encoding of
+// values by type.
 // Returns the encoded values, or an error if one occurs.
-func EncodeJSONArgumentsToSlice(inputs abi.Arguments, values []any) ([]any, error) {
+func EncodeJSONArgumentsToSlice(inputs abi.Arguments, values []any, codecs JSONArgumentCodecs) ([]any, error) {
 	// Create a variable to store encoded arguments, fill it with the respective encoded arguments.
 	var encodedArgs = make([]any, len(inputs))
 	for i, input := range inputs {
-		arg, err := encodeJSONArgument(&input.Type, values[i])
+		arg, err := encodeJSONArgument(&input.Type, values[i], codecs)
 		if err != nil {
 			err = fmt.Errorf("ABI value argument could not be decoded from JSON: \n"+
 				"name: %v, abi type: %v, value: %v error: %s",
@@ -321,6 +669,25 @@ func EncodeJSONArgumentsToSlice(inputs abi.Arguments, values []any) ([]any, erro
 // human-readable for console output purpose.
 // Returns the string, or an error if one occurs.
 func EncodeABIArgumentsToString(inputs abi.Arguments, values []any) (string, error) {
+	return encodeABIArgumentsToString(inputs, values, false)
+}
+
+// EncodeABIArgumentsToNamedString encodes provided go-ethereum ABI packable input values of given types into a
+// human-readable string, prefixing each value with its argument name where one is defined (e.g. "have=5, want=10").
+// Returns the string, or an error if one occurs.
+func EncodeABIArgumentsToNamedString(inputs abi.Arguments, values []any) (string, error) {
+	return encodeABIArgumentsToString(inputs, values, true)
+}
+
+// encodeABIArgumentsToString encodes provided go-ethereum ABI package input values into a human-readable string,
+// optionally prefixing each value with its argument name where one is defined.
+// Returns the string, or an error if one occurs.
+func encodeABIArgumentsToString(inputs abi.Arguments, values []any, named bool) (string, error) {
+	// Verify we have a value for each input
+	if len(values) != len(inputs) {
+		return "", fmt.Errorf("ABI values could not be encoded to a string, %v arguments were expected, got %v", len(inputs), len(values))
+	}
+
 	// Create a variable to store string arguments, fill it with the respective arguments
 	var encodedArgs = make([]string, len(inputs))
 
@@ -330,11 +697,17 @@ func EncodeABIArgumentsToString(inputs abi.Arguments, values []any) (string, err
 		arg, err := encodeABIArgumentToString(&input.Type, values[i])
 		if err != nil {
 			// If error occurs while encoding the input value, return error message
-			err = fmt.Errorf("ABI value argument could not be decoded from JSON: \n"+
+			err = fmt.Errorf("ABI value argument could not be encoded to a string: \n"+
 				"name: %v, abi type: %v, value: %v error: %s",
 				input.Name, input.Type, values[i], err)
 			return "", err
 		}
+
+		// Prefix the argument with its name if requested and it has one
+		if named && input.Name != "" {
+			arg = input.Name + "=" + arg
+		}
+
 		// Store the encoded argument at the current index in the encodedArgs slice
 		encodedArgs[i] = arg
 	}
@@ -454,6 +827,11 @@ func encodeABIArgumentToString(inputType *abi.Type, value any) (string, error) {
 		b := reflectionutils.ArrayToSlice(reflect.ValueOf(value)).([]byte)
 		// Convert the byte array to a string and use the QuoteToASCII method to format the string with Go escape sequences.
 		return strconv.QuoteToASCII(string(b)), nil
+	case abi.FunctionTy:
+		// Prepare a function type, represented as a contract address followed by a method selector. Return it as a
+		// hex string.
+		b := reflectionutils.ArrayToSlice(reflect.ValueOf(value)).([]byte)
+		return "0x" + hex.EncodeToString(b), nil
 	case abi.ArrayTy:
 		// Prepare an array. Return as a string enclosed with [], where specific elements are comma-separated.
 		reflectedArray := reflect.ValueOf(value)
@@ -525,9 +903,15 @@ func encodeABIArgumentToString(inputType *abi.Type, value any) (string, error) {
 }
 
 // encodeJSONArgument encodes a provided go-ethereum ABI packable input value of a given type, into generic JSON
-// compatible values (e.g. []any, map[string]any, etc).
+// compatible values (e.g. []any, map[string]any, etc). If a JSONArgumentCodec was provided for the type, it is used
+// instead of the default encoding.
 // Returns the encoded value, or an error if one occurs.
-func encodeJSONArgument(inputType *abi.Type, value any) (any, error) {
+func encodeJSONArgument(inputType *abi.Type, value any, codecs JSONArgumentCodecs) (any, error) {
+	// If a custom codec was provided for this type, use it instead.
+	if codec, ok := codecs[inputType.T]; ok && codec.Encode != nil {
+		return codec.Encode(inputType, value)
+	}
+
 	switch inputType.T {
 	case abi.AddressTy:
 		addr, ok := value.(common.Address)
@@ -619,7 +1003,7 @@ func encodeJSONArgument(inputType *abi.Type, value any) (any, error) {
 			return nil, fmt.Errorf("could not encode dynamic-sized bytes as the value provided is not of the correct type")
 		}
 		return hex.EncodeToString(b), nil
-	case abi.FixedBytesTy:
+	case abi.FixedBytesTy, abi.FunctionTy:
 		// TODO: Error checking to ensure `value` is of the correct type.
 		b := reflectionutils.ArrayToSlice(reflect.ValueOf(value)).([]byte)
 		return hex.EncodeToString(b), nil
@@ -628,7 +1012,7 @@ func encodeJSONArgument(inputType *abi.Type, value any) (any, error) {
 		reflectedArray := reflect.ValueOf(value)
 		arrayData := make([]any, 0)
 		for i := 0; i < reflectedArray.Len(); i++ {
-			elementData, err := encodeJSONArgument(inputType.Elem, reflectedArray.Index(i).Interface())
+			elementData, err := encodeJSONArgument(inputType.Elem, reflectedArray.Index(i).Interface(), codecs)
 			if err != nil {
 				return nil, err
 			}
@@ -640,7 +1024,7 @@ func encodeJSONArgument(inputType *abi.Type, value any) (any, error) {
 		reflectedArray := reflect.ValueOf(value)
 		sliceData := make([]any, 0)
 		for i := 0; i < reflectedArray.Len(); i++ {
-			elementData, err := encodeJSONArgument(inputType.Elem, reflectedArray.Index(i).Interface())
+			elementData, err := encodeJSONArgument(inputType.Elem, reflectedArray.Index(i).Interface(), codecs)
 			if err != nil {
 				return nil, err
 			}
@@ -654,7 +1038,7 @@ func encodeJSONArgument(inputType *abi.Type, value any) (any, error) {
 		for i := 0; i < len(inputType.TupleElems); i++ {
 			field := reflectedTuple.Field(i)
 			fieldValue := reflectionutils.GetField(field)
-			fieldData, err := encodeJSONArgument(inputType.TupleElems[i], fieldValue)
+			fieldData, err := encodeJSONArgument(inputType.TupleElems[i], fieldValue, codecs)
 			if err != nil {
 				return nil, err
 			}
@@ -666,19 +1050,52 @@ func encodeJSONArgument(inputType *abi.Type, value any) (any, error) {
 	}
 }
 
+// ABIEnumNames describes the member names of Solidity enums used as input arguments, keyed by argument name. Solidity
+// enums are represented as uint8 values in the ABI, where each member name maps to its index in the list. It can be
+// provided when decoding JSON arguments so enum values may be specified by member name rather than integer value.
+type ABIEnumNames map[string][]string
+
+// resolveEnumName checks if the provided JSON value for an input argument is a member name of an enum described by
+// the provided ABIEnumNames. If so, it returns the JSON representation of the enum member's underlying integer value.
+// Otherwise, the provided value is returned unchanged.
+func resolveEnumName(input abi.Argument, value any, enumNames ABIEnumNames) any {
+	// Enums are only represented as uint8 in the ABI.
+	if input.Type.T != abi.UintTy || input.Type.Size != 8 {
+		return value
+	}
+
+	// If the value is a member name of the enum, return its index.
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+	for i, memberName := range enumNames[input.Name] {
+		if memberName == str {
+			return strconv.Itoa(i)
+		}
+	}
+	return value
+}
+
 // DecodeJSONArgumentsFromMap decodes JSON values into a provided values of the given types, or returns an error of one occurs.
 // The values provided must be generic JSON types (e.g. []any, map[string]any, etc) which will be transformed into
-// a go-ethereum ABI packable values.
-func DecodeJSONArgumentsFromMap(inputs abi.Arguments, values map[string]any, deployedContractAddr map[string]common.Address) ([]any, error) {
+// a go-ethereum ABI packable values. Values are keyed by argument name, or by position (e.g. "arg0", "arg1") for
+// unnamed arguments. Optionally, ABIEnumNames may be provided to allow enum arguments to be specified by member name.
+// Dynamic arrays, byte arrays and strings longer than maxDecodedLength are rejected with an error, unless it is zero.
+// Optionally, JSONArgumentCodecs may be provided to customize the decoding of values by type.
+func DecodeJSONArgumentsFromMap(inputs abi.Arguments, values map[string]any, deployedContractAddr map[string]common.Address, enumNames ABIEnumNames, maxDecodedLength int, codecs JSONArgumentCodecs) ([]any, error) {
 	// Create a variable to store decoded arguments, fill it with the respective decoded arguments.
 	var decodedArgs = make([]any, len(inputs))
+	keys := jsonArgumentKeys(inputs)
 	for i, input := range inputs {
-		value, ok := values[input.Name]
+		key := keys[i]
+		value, ok := values[key]
 		if !ok {
-			err := fmt.Errorf("constructor argument not provided for: name: %v", input.Name)
+			err := fmt.Errorf("constructor argument not provided for: name: %v", key)
 			return nil, err
 		}
-		arg, err := decodeJSONArgument(&input.Type, value, deployedContractAddr)
+		value = resolveEnumName(input, value, enumNames)
+		arg, err := decodeJSONArgument(&input.Type, value, deployedContractAddr, maxDecodedLength, codecs)
 		if err != nil {
 			err = fmt.Errorf("ABI value argument could not be decoded from JSON: \n"+
 				"name: %v, abi type: %v, value: %v error: %s",
@@ -692,8 +1109,10 @@ func DecodeJSONArgumentsFromMap(inputs abi.Arguments, values map[string]any, dep
 
 // DecodeJSONArgumentsFromSlice decodes JSON values into a provided values of the given types, or returns an error of one occurs.
 // The values provided must be generic JSON types (e.g. []any, map[string]any, etc) which will be transformed into
-// a go-ethereum ABI packable values.
-func DecodeJSONArgumentsFromSlice(inputs abi.Arguments, values []any, deployedContractAddr map[string]common.Address) ([]any, error) {
+// a go-ethereum ABI packable values. Optionally, ABIEnumNames may be provided to allow enum arguments to be specified
+// by member name. Dynamic arrays, byte arrays and strings longer than maxDecodedLength are rejected with an error,
+// unless it is zero. Optionally, JSONArgumentCodecs may be provided to customize the decoding of values by type.
+func DecodeJSONArgumentsFromSlice(inputs abi.Arguments, values []any, deployedContractAddr map[string]common.Address, enumNames ABIEnumNames, maxDecodedLength int, codecs JSONArgumentCodecs) ([]any, error) {
 	// Check our argument value count against our ABI method arguments count.
 	if len(values) != len(inputs) {
 		err := fmt.Errorf("constructor argument count mismatch, expected %v but got %v", len(inputs), len(values))
@@ -703,7 +1122,7 @@ func DecodeJSONArgumentsFromSlice(inputs abi.Arguments, values []any, deployedCo
 	// Create a variable to store decoded arguments, fill it with the respective decoded arguments.
 	var decodedArgs = make([]any, len(inputs))
 	for i, input := range inputs {
-		arg, err := decodeJSONArgument(&input.Type, values[i], deployedContractAddr)
+		arg, err := decodeJSONArgument(&input.Type, resolveEnumName(input, values[i], enumNames), deployedContractAddr, maxDecodedLength, codecs)
 		if err != nil {
 			err = fmt.Errorf("ABI value argument could not be decoded from JSON: \n"+
 				"name: %v, abi type: %v, value: %v error: %s",
@@ -715,10 +1134,35 @@ func DecodeJSONArgumentsFromSlice(inputs abi.Arguments, values []any, deployedCo
 	return decodedArgs, nil
 }
 
+// decodeJSONHexString decodes a hex string representing a bytes value in JSON. Surrounding whitespace and an optional
+// "0x" prefix are ignored, as hand-edited JSON often contains them inconsistently.
+// Returns the decoded bytes, or an error describing why the string is not valid hex.
+func decodeJSONHexString(str string) ([]byte, error) {
+	str = strings.TrimSpace(str)
+	if len(str) >= 2 && str[0] == '0' && (str[1] == 'x' || str[1] == 'X') {
+		str = str[2:]
+	}
+	if len(str)%2 != 0 {
+		return nil, fmt.Errorf("invalid hex '%v': odd number of digits", str)
+	}
+	decodedBytes, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex '%v': %v", str, err)
+	}
+	return decodedBytes, nil
+}
+
 // decodeJSONArgument decodes JSON value into a provided value of a given type, or returns an error of one occurs.
 // The value provided must be a generic JSON type (e.g. []any, map[string]any, etc) which will be transformed into
-// a go-ethereum ABI packable value.
-func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map[string]common.Address) (any, error) {
+// a go-ethereum ABI packable value. If a JSONArgumentCodec was provided for the type, it is used instead of the
+// default decoding. Dynamic arrays, byte arrays and strings longer than maxDecodedLength are rejected with an error,
+// unless it is zero.
+func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map[string]common.Address, maxDecodedLength int, codecs JSONArgumentCodecs) (any, error) {
+	// If a custom codec was provided for this type, use it instead.
+	if codec, ok := codecs[inputType.T]; ok && codec.Decode != nil {
+		return codec.Decode(inputType, value)
+	}
+
 	var v any
 	switch inputType.T {
 	case abi.AddressTy:
@@ -794,43 +1238,39 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 		if !ok {
 			return nil, fmt.Errorf("invalid string value")
 		}
+		if maxDecodedLength > 0 && len(str) > maxDecodedLength {
+			return nil, fmt.Errorf("invalid string length %v, exceeds the maximum decoded length %v", len(str), maxDecodedLength)
+		}
 		v = str
 	case abi.BytesTy:
 		str, ok := value.(string)
 		if !ok {
 			return nil, fmt.Errorf("bytes value should be added as string in JSON")
 		}
-		if len(str) >= 2 && str[0] == '0' && (str[1] == 'x' || str[1] == 'X') {
-			str = str[2:]
-		}
-		decodedBytes, err := hex.DecodeString(str)
+		decodedBytes, err := decodeJSONHexString(str)
 		if err != nil {
 			return nil, err
 		}
+		if maxDecodedLength > 0 && len(decodedBytes) > maxDecodedLength {
+			return nil, fmt.Errorf("invalid bytes length %v, exceeds the maximum decoded length %v", len(decodedBytes), maxDecodedLength)
+		}
 		v = decodedBytes
-	case abi.FixedBytesTy:
+	case abi.FixedBytesTy, abi.FunctionTy:
+		// Function types are represented as 24-byte arrays, so they are decoded like fixed-sized bytes.
 		str, ok := value.(string)
 		if !ok {
 			return nil, fmt.Errorf("%s value should be added as string in JSON", inputType)
 		}
-		if len(str) >= 2 && str[0] == '0' && (str[1] == 'x' || str[1] == 'X') {
-			str = str[2:]
-		}
-		decodedBytes, err := hex.DecodeString(str)
+		decodedBytes, err := decodeJSONHexString(str)
 		if err != nil {
 			return nil, err
 		}
-		if len(decodedBytes) != inputType.Size {
-			return nil, fmt.Errorf("invalid number of bytes %v", len(decodedBytes))
-		}
 
 		// This needs to be an array type, not a slice. But arrays can't be dynamically defined without reflection.
-		bytesValue := reflect.ValueOf(decodedBytes)
-		fixedBytes := reflect.Indirect(reflect.New(inputType.GetType()))
-		for i := 0; i < fixedBytes.Len(); i++ {
-			fixedBytes.Index(i).Set(bytesValue.Index(i))
+		v, err = reflectionutils.SliceToArray(reflect.ValueOf(decodedBytes), inputType.Size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number of bytes %v for %s", len(decodedBytes), inputType)
 		}
-		v = fixedBytes.Interface()
 	case abi.ArrayTy:
 		arr, ok := value.([]any)
 		if !ok {
@@ -838,8 +1278,11 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 		}
 		// This needs to be an array type, not a slice. But arrays can't be dynamically defined without reflection.
 		array := reflect.Indirect(reflect.New(inputType.GetType()))
+		if len(arr) != array.Len() {
+			return nil, fmt.Errorf("invalid number of elements %v for %s, expected %v", len(arr), inputType, array.Len())
+		}
 		for i, e := range arr {
-			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr)
+			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr, maxDecodedLength, codecs)
 			if err != nil {
 				return nil, err
 			}
@@ -851,10 +1294,15 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 		if !ok {
 			return nil, fmt.Errorf("invalid JSON value, array expected")
 		}
+		// Reject slices exceeding our maximum length before allocating them.
+		if maxDecodedLength > 0 && len(arr) > maxDecodedLength {
+			return nil, fmt.Errorf("invalid number of elements %v for %s, exceeds the maximum decoded length %v", len(arr), inputType, maxDecodedLength)
+		}
+
 		// Element type of slice is dynamic therefore it needs to be created with reflection.
 		slice := reflect.MakeSlice(inputType.GetType(), len(arr), len(arr))
 		for i, e := range arr {
-			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr)
+			ele, err := decodeJSONArgument(inputType.Elem, e, deployedContractAddr, maxDecodedLength, codecs)
 			if err != nil {
 				return nil, err
 			}
@@ -875,7 +1323,7 @@ func decodeJSONArgument(inputType *abi.Type, value any, deployedContractAddr map
 			if !ok {
 				return nil, fmt.Errorf("value for struct field %s not provided", fieldName)
 			}
-			eleValue, err := decodeJSONArgument(eleType, fieldValue, deployedContractAddr)
+			eleValue, err := decodeJSONArgument(eleType, fieldValue, deployedContractAddr, maxDecodedLength, codecs)
 			if !ok {
 				return nil, fmt.Errorf("can not parse struct field %s, error: %s", fieldName, err)
 			}
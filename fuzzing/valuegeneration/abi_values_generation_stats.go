@@ -0,0 +1,184 @@
+package valuegeneration
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"golang.org/x/exp/maps"
+)
+
+// AbiValueGenerationStats describes statistics about the values generated by GenerateAbiValue, including the
+// elements of composite values. They can be used to verify value generation is distributed as configured.
+// Statistics are collected for values generated with a ValueGenerator which is an AbiValueGenerationStatsProvider.
+type AbiValueGenerationStats struct {
+	// TypeCounts describes the count of values generated for each ABI type category (e.g. "uint", "address").
+	TypeCounts map[string]uint64
+
+	// IntegerBitLengths describes the count of generated integers by the bit length of their magnitude (e.g. 0 for
+	// zero, 1 for one and negative one, 256 for the maximum uint256).
+	IntegerBitLengths map[int]uint64
+
+	// ArrayLengths describes the count of generated dynamic arrays by their length.
+	ArrayLengths map[int]uint64
+
+	// statsLock provides thread-synchronization to avoid race conditions when accessing the statistics. Statistics
+	// are typically owned by a single FuzzerWorker, so the lock is rarely contended.
+	statsLock sync.Mutex
+}
+
+// AbiValueGenerationStatsProvider describes a ValueGenerator which collects statistics about the values
+// GenerateAbiValue generates with it.
+type AbiValueGenerationStatsProvider interface {
+	// GenerationStats returns the statistics to record generated values in, or nil if they should not be collected.
+	GenerationStats() *AbiValueGenerationStats
+}
+
+// NewAbiValueGenerationStats creates a new, empty AbiValueGenerationStats.
+func NewAbiValueGenerationStats() *AbiValueGenerationStats {
+	return &AbiValueGenerationStats{
+		TypeCounts:        make(map[string]uint64),
+		IntegerBitLengths: make(map[int]uint64),
+		ArrayLengths:      make(map[int]uint64),
+	}
+}
+
+// Clone obtains a copy of the statistics collected so far.
+func (s *AbiValueGenerationStats) Clone() *AbiValueGenerationStats {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	return &AbiValueGenerationStats{
+		TypeCounts:        maps.Clone(s.TypeCounts),
+		IntegerBitLengths: maps.Clone(s.IntegerBitLengths),
+		ArrayLengths:      maps.Clone(s.ArrayLengths),
+	}
+}
+
+// Merge adds the statistics collected by the provided AbiValueGenerationStats to these statistics, such as when a
+// FuzzerWorker which collected them is destroyed.
+func (s *AbiValueGenerationStats) Merge(other *AbiValueGenerationStats) {
+	// Copy the other statistics first, so both locks are never held at once.
+	other = other.Clone()
+
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	for typeName, count := range other.TypeCounts {
+		s.TypeCounts[typeName] += count
+	}
+	for bitLength, count := range other.IntegerBitLengths {
+		s.IntegerBitLengths[bitLength] += count
+	}
+	for length, count := range other.ArrayLengths {
+		s.ArrayLengths[length] += count
+	}
+}
+
+// record records a value generated for the provided type in the statistics.
+func (s *AbiValueGenerationStats) record(inputType *abi.Type, value any) {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	s.TypeCounts[abiTypeCategoryName(inputType)]++
+	switch inputType.T {
+	case abi.UintTy, abi.IntTy:
+		if integer := integerFromAbiValue(value); integer != nil {
+			s.IntegerBitLengths[integer.BitLen()]++
+		}
+	case abi.SliceTy:
+		s.ArrayLengths[reflect.ValueOf(value).Len()]++
+	}
+}
+
+// recordAbiValueGenerated records a value generated for the provided type in the statistics of the provided
+// ValueGenerator, if it is an AbiValueGenerationStatsProvider which collects them.
+func recordAbiValueGenerated(generator ValueGenerator, inputType *abi.Type, value any) {
+	if statsProvider, ok := generator.(AbiValueGenerationStatsProvider); ok {
+		if stats := statsProvider.GenerationStats(); stats != nil {
+			stats.record(inputType, value)
+		}
+	}
+}
+
+// integerFromAbiValue converts an integer value of any Go type used to represent ABI integers into a big.Int.
+// Returns the integer, or nil if the value is not an integer.
+func integerFromAbiValue(value any) *big.Int {
+	if b, ok := value.(*big.Int); ok {
+		return b
+	}
+	reflectedValue := reflect.ValueOf(value)
+	switch reflectedValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(reflectedValue.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(reflectedValue.Uint())
+	default:
+		return nil
+	}
+}
+
+// abiTypeCategoryName obtains a name describing the category of the provided ABI type (e.g. "uint" for any unsigned
+// integer type).
+func abiTypeCategoryName(inputType *abi.Type) string {
+	switch inputType.T {
+	case abi.AddressTy:
+		return "address"
+	case abi.UintTy:
+		return "uint"
+	case abi.IntTy:
+		return "int"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy:
+		return "string"
+	case abi.BytesTy:
+		return "bytes"
+	case abi.FixedBytesTy:
+		return "fixed bytes"
+	case abi.FunctionTy:
+		return "function"
+	case abi.ArrayTy:
+		return "array"
+	case abi.SliceTy:
+		return "slice"
+	case abi.TupleTy:
+		return "tuple"
+	default:
+		return inputType.String()
+	}
+}
+
+// String returns a human-readable report of the value generation statistics.
+func (s *AbiValueGenerationStats) String() string {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	var builder strings.Builder
+
+	// Report type counts, most frequent first.
+	typeNames := maps.Keys(s.TypeCounts)
+	sort.Slice(typeNames, func(i, j int) bool {
+		if s.TypeCounts[typeNames[i]] != s.TypeCounts[typeNames[j]] {
+			return s.TypeCounts[typeNames[i]] > s.TypeCounts[typeNames[j]]
+		}
+		return typeNames[i] < typeNames[j]
+	})
+	builder.WriteString("generated values by type:\n")
+	for _, typeName := range typeNames {
+		builder.WriteString(fmt.Sprintf("\t%s: %d\n", typeName, s.TypeCounts[typeName]))
+	}
+
+	// Report integer magnitudes and array lengths, in ascending order.
+	writeHistogram := func(title string, histogram map[int]uint64) {
+		keys := maps.Keys(histogram)
+		sort.Ints(keys)
+		builder.WriteString(title)
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("\t%d: %d\n", key, histogram[key]))
+		}
+	}
+	writeHistogram("generated integers by bit length of magnitude:\n", s.IntegerBitLengths)
+	writeHistogram("generated dynamic arrays by length:\n", s.ArrayLengths)
+	return builder.String()
+}
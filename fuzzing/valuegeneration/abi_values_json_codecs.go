@@ -0,0 +1,26 @@
+package valuegeneration
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// JSONArgumentCodec describes a custom JSON representation for ABI values of a given type category (e.g. abi.UintTy).
+// It allows values to be encoded and decoded in a format expected by external tools, such as other fuzzers' corpus
+// files.
+type JSONArgumentCodec struct {
+	// Encode encodes a go-ethereum ABI packable value of the given type into a generic JSON compatible value.
+	// If nil, the default encoding is used.
+	Encode func(inputType *abi.Type, value any) (any, error)
+
+	// Decode decodes a generic JSON value into a go-ethereum ABI packable value of the given type.
+	// If nil, the default decoding is used.
+	// Note: Decoders for address types replace the default decoding entirely, including the resolution of deployed
+	// contract names.
+	Decode func(inputType *abi.Type, value any) (any, error)
+}
+
+// JSONArgumentCodecs describes the custom JSONArgumentCodec to use when encoding or decoding JSON values for each ABI
+// type category (e.g. abi.UintTy). Categories without a codec use the default JSON representation. Elements of
+// composite types (arrays, slices, tuples) are encoded and decoded using the codec provided for their own category.
+// A nil JSONArgumentCodecs uses the default JSON representation for every category.
+type JSONArgumentCodecs map[byte]JSONArgumentCodec
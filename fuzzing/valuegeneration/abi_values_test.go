@@ -2,12 +2,19 @@ package valuegeneration
 
 import (
 	"fmt"
+	"math/big"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -67,6 +74,19 @@ func getTestABIArguments() abi.Arguments {
 			},
 			Indexed: false,
 		},
+		{
+			Name: "testFunction",
+			Type: abi.Type{
+				Elem:          nil,
+				Size:          24,
+				T:             abi.FunctionTy,
+				TupleRawName:  "",
+				TupleElems:    nil,
+				TupleRawNames: nil,
+				TupleType:     nil,
+			},
+			Indexed: false,
+		},
 	}
 
 	// Append all fixed byte sizes
@@ -221,18 +241,19 @@ func TestABIRoundtripEncodingAllTypes(t *testing.T) {
 		// Test each argument round trip serialization with different generated values (iterate a number of times).
 		for i := 0; i < 10; i++ {
 			// Generate a value for this argument
-			value := GenerateAbiValue(valueGenerator, &arg.Type)
+			value, err := GenerateAbiValue(valueGenerator, &arg.Type)
+			assert.NoError(t, err)
 
 			// Encode the generated value for this argument
-			encodedValue, err := encodeJSONArgument(&arg.Type, value)
+			encodedValue, err := encodeJSONArgument(&arg.Type, value, nil)
 			assert.NoError(t, err)
 
 			// Decode the generated value
-			decodedValue, err := decodeJSONArgument(&arg.Type, encodedValue, nil)
+			decodedValue, err := decodeJSONArgument(&arg.Type, encodedValue, nil, 0, nil)
 			assert.NoError(t, err)
 
 			// Re-encode the generated value for this argument
-			reencodedValue, err := encodeJSONArgument(&arg.Type, decodedValue)
+			reencodedValue, err := encodeJSONArgument(&arg.Type, decodedValue, nil)
 			assert.NoError(t, err)
 
 			// Compare the encoded and re-encoded values.
@@ -283,7 +304,8 @@ func TestABIGenerationAndMutation(t *testing.T) {
 		// Test each argument round trip serialization with different generated values (iterate a number of times).
 		for i := 0; i < 5; i++ {
 			// Generate a value for this argument
-			value := GenerateAbiValue(valueGenerator, &arg.Type)
+			value, err := GenerateAbiValue(valueGenerator, &arg.Type)
+			assert.NoError(t, err)
 
 			// Mutate and ensure no error occurred.
 			mutatedValue, err := MutateAbiValue(valueGenerator, &arg.Type, value)
@@ -316,11 +338,707 @@ func TestEncodeABIArgumentToString(t *testing.T) {
 		// Test each argument encoding to string with different generated values (iterate a number of times).
 		for i := 0; i < 10; i++ {
 			// Generate a value for this argument
-			value := GenerateAbiValue(valueGenerator, &arg.Type)
+			value, err := GenerateAbiValue(valueGenerator, &arg.Type)
+			assert.NoError(t, err)
 
 			// Encode the generated value for this argument and ensure no error occurred.
-			_, err := encodeABIArgumentToString(&arg.Type, value)
+			_, err = encodeABIArgumentToString(&arg.Type, value)
 			assert.NoError(t, err)
 		}
 	}
 }
+
+// TestEncodeABIArgumentsToNamedString runs tests to ensure that ABI input values are encoded to a string prefixed with
+// their argument names where they are defined, matching the unnamed encoding otherwise.
+func TestEncodeABIArgumentsToNamedString(t *testing.T) {
+	args := abi.Arguments{
+		{Name: "have", Type: abi.Type{T: abi.UintTy, Size: 256}},
+		{Type: abi.Type{T: abi.BoolTy}},
+		{Name: "want", Type: abi.Type{T: abi.IntTy, Size: 8}},
+	}
+	values := []any{big.NewInt(5), true, int8(-10)}
+
+	named, err := EncodeABIArgumentsToNamedString(args, values)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "have=5, true, want=-10", named)
+	unnamed, err := EncodeABIArgumentsToString(args, values)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "5, true, -10", unnamed)
+
+	// Values which do not match their arguments are rejected.
+	_, err = EncodeABIArgumentsToNamedString(args, values[:2])
+	assert.Error(t, err)
+	_, err = EncodeABIArgumentsToNamedString(args, []any{big.NewInt(5), "true", int8(-10)})
+	assert.Error(t, err)
+}
+
+// TestDecodeJSONArgumentsWithEnumNames runs tests to ensure that enum arguments provided by member name are decoded
+// to their underlying uint8 values, while integer values continue to be decoded as normal.
+func TestDecodeJSONArgumentsWithEnumNames(t *testing.T) {
+	// Define an enum argument and the names of its members.
+	args := abi.Arguments{
+		{
+			Name: "status",
+			Type: abi.Type{
+				T:    abi.UintTy,
+				Size: 8,
+			},
+		},
+	}
+	enumNames := ABIEnumNames{
+		"status": {"Pending", "Active", "Closed"},
+	}
+
+	// Decode the enum by member name and ensure the underlying value is resolved.
+	decoded, err := DecodeJSONArgumentsFromMap(args, map[string]any{"status": "Active"}, nil, enumNames, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []any{uint8(1)}, decoded)
+
+	// Decode the enum by integer value and ensure it is still supported.
+	decoded, err = DecodeJSONArgumentsFromSlice(args, []any{"2"}, nil, enumNames, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []any{uint8(2)}, decoded)
+
+	// Decode an unknown member name and ensure an error is returned.
+	_, err = DecodeJSONArgumentsFromSlice(args, []any{"Unknown"}, nil, enumNames, 0, nil)
+	assert.Error(t, err)
+}
+
+// TestDecodeJSONArgumentFixedArrayLength runs tests to ensure that decoding a JSON array into a fixed-size array type
+// returns an error rather than panicking if the element count does not match the array size.
+func TestDecodeJSONArgumentFixedArrayLength(t *testing.T) {
+	// Define a uint256[3] argument.
+	uintType := abi.Type{T: abi.UintTy, Size: 256}
+	args := abi.Arguments{
+		{
+			Name: "values",
+			Type: abi.Type{
+				T:    abi.ArrayTy,
+				Size: 3,
+				Elem: &uintType,
+			},
+		},
+	}
+
+	// Decode arrays with too many and too few elements and ensure an error is returned.
+	_, err := DecodeJSONArgumentsFromSlice(args, []any{[]any{"1", "2", "3", "4"}}, nil, nil, 0, nil)
+	assert.Error(t, err)
+	_, err = DecodeJSONArgumentsFromSlice(args, []any{[]any{"1", "2"}}, nil, nil, 0, nil)
+	assert.Error(t, err)
+
+	// Decode an array of the correct length and ensure it succeeds.
+	decoded, err := DecodeJSONArgumentsFromSlice(args, []any{[]any{"1", "2", "3"}}, nil, nil, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []any{[3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}}, decoded)
+}
+
+// TestDecodeJSONArgumentFixedBytesLength runs tests to ensure that decoding a JSON hex string into a fixed-size bytes
+// type returns an error if the decoded byte count does not match the type size.
+func TestDecodeJSONArgumentFixedBytesLength(t *testing.T) {
+	args := abi.Arguments{{Name: "selector", Type: abi.Type{T: abi.FixedBytesTy, Size: 4}}}
+
+	// Decode too many and too few bytes and ensure an error is returned.
+	_, err := DecodeJSONArgumentsFromSlice(args, []any{"0x1234567890"}, nil, nil, 0, nil)
+	assert.Error(t, err)
+	_, err = DecodeJSONArgumentsFromSlice(args, []any{"0x123456"}, nil, nil, 0, nil)
+	assert.Error(t, err)
+
+	// Decode the correct amount of bytes and ensure it succeeds.
+	decoded, err := DecodeJSONArgumentsFromSlice(args, []any{"0x12345678"}, nil, nil, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []any{[4]byte{0x12, 0x34, 0x56, 0x78}}, decoded)
+}
+
+// TestJSONArgumentMapKeys runs tests to ensure arguments encoded to and decoded from JSON argument maps are keyed by
+// name, or by position if unnamed, without positional keys colliding with arguments explicitly named like them.
+func TestJSONArgumentMapKeys(t *testing.T) {
+	uintType := abi.Type{T: abi.UintTy, Size: 256}
+	args := abi.Arguments{
+		{Name: "", Type: uintType},
+		{Name: "arg0", Type: uintType},
+		{Name: "", Type: uintType},
+		{Name: "amount", Type: uintType},
+	}
+	values := []any{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+
+	// Encode the arguments, verifying each is keyed uniquely.
+	encoded, err := EncodeJSONArgumentsToMap(args, values, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]any{"arg0_": "1", "arg0": "2", "arg2": "3", "amount": "4"}, encoded)
+	assert.Empty(t, UnknownJSONArgumentKeys(args, encoded))
+
+	// Decoding the encoded arguments yields the original values.
+	decoded, err := DecodeJSONArgumentsFromMap(args, encoded, nil, nil, 0, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, values, decoded)
+
+	// Keys which do not correspond to an argument are reported, and missing keys are rejected.
+	assert.EqualValues(t, []string{"arg1"}, UnknownJSONArgumentKeys(args, map[string]any{"arg0": "1", "arg1": "2"}))
+	_, err = DecodeJSONArgumentsFromMap(args, map[string]any{"arg0": "1", "arg2": "3", "amount": "4"}, nil, nil, 0, nil)
+	assert.Error(t, err)
+}
+
+// TestDecodeJSONArgumentMaxLength runs tests to ensure that decoding a JSON array, byte array or string longer than the
+// maximum decoded length returns an error.
+func TestDecodeJSONArgumentMaxLength(t *testing.T) {
+	uintType := abi.Type{T: abi.UintTy, Size: 256}
+	tests := []struct {
+		argType        abi.Type
+		withinLimit    any
+		exceedingLimit any
+	}{
+		{argType: abi.Type{T: abi.SliceTy, Elem: &uintType}, withinLimit: []any{"1", "2"}, exceedingLimit: []any{"1", "2", "3"}},
+		{argType: abi.Type{T: abi.BytesTy}, withinLimit: "0x0102", exceedingLimit: "0x010203"},
+		{argType: abi.Type{T: abi.StringTy}, withinLimit: "ab", exceedingLimit: "abc"},
+	}
+	for _, test := range tests {
+		args := abi.Arguments{{Name: "value", Type: test.argType}}
+
+		// Decode values within and beyond our limit.
+		_, err := DecodeJSONArgumentsFromSlice(args, []any{test.withinLimit}, nil, nil, 2, nil)
+		assert.NoError(t, err)
+		_, err = DecodeJSONArgumentsFromSlice(args, []any{test.exceedingLimit}, nil, nil, 2, nil)
+		assert.ErrorContains(t, err, "maximum decoded length")
+
+		// Decode values beyond our limit with no limit enforced.
+		_, err = DecodeJSONArgumentsFromSlice(args, []any{test.exceedingLimit}, nil, nil, 0, nil)
+		assert.NoError(t, err)
+	}
+}
+
+// TestDecodeJSONArgumentBytesWhitespace runs tests to ensure that decoding bytes from JSON ignores surrounding
+// whitespace and prefix casing, and reports odd-length hex clearly.
+func TestDecodeJSONArgumentBytesWhitespace(t *testing.T) {
+	args := abi.Arguments{{Name: "data", Type: abi.Type{T: abi.BytesTy}}}
+
+	// Decode hex strings with stray whitespace and ensure they succeed.
+	for _, str := range []string{" 0x0102 ", "\t0X0102\n", "0102 "} {
+		decoded, err := DecodeJSONArgumentsFromSlice(args, []any{str}, nil, nil, 0, nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, []any{[]byte{1, 2}}, decoded)
+	}
+
+	// Decode an odd-length hex string and ensure the error describes the cause.
+	_, err := DecodeJSONArgumentsFromSlice(args, []any{"0x102"}, nil, nil, 0, nil)
+	assert.ErrorContains(t, err, "odd number of digits")
+}
+
+// TestTruncateAbiValue runs tests to ensure every dynamic value within a value is halved in length by TruncateAbiValue,
+// recursively, while the value remains valid for its type and statically sized values are left unaltered.
+func TestTruncateAbiValue(t *testing.T) {
+	tupleType, err := abi.NewType("tuple", "struct Test.Data", []abi.ArgumentMarshaling{
+		{Name: "name", Type: "string"},
+		{Name: "data", Type: "bytes"},
+		{Name: "values", Type: "uint256[]"},
+		{Name: "labels", Type: "string[2]"},
+		{Name: "count", Type: "uint256"},
+	})
+	assert.NoError(t, err)
+	value := reflect.New(tupleType.GetType()).Elem()
+	value.Field(0).Set(reflect.ValueOf("abcdefgh"))
+	value.Field(1).Set(reflect.ValueOf([]byte{1, 2, 3, 4}))
+	value.Field(2).Set(reflect.ValueOf([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}))
+	value.Field(3).Set(reflect.ValueOf([2]string{"abcd", "ab"}))
+	value.Field(4).Set(reflect.ValueOf(big.NewInt(7)))
+	original := value.Interface()
+
+	// Truncate our value and ensure every dynamic value was halved, without altering the original.
+	truncated, ok := TruncateAbiValue(&tupleType, original)
+	assert.True(t, ok)
+	assert.NoError(t, ValidateAbiValue(&tupleType, truncated))
+	reflectedTruncated := reflect.ValueOf(truncated)
+	assert.EqualValues(t, "abcd", reflectedTruncated.Field(0).Interface())
+	assert.EqualValues(t, []byte{1, 2}, reflectedTruncated.Field(1).Interface())
+	assert.EqualValues(t, []*big.Int{big.NewInt(1)}, reflectedTruncated.Field(2).Interface())
+	assert.EqualValues(t, [2]string{"ab", "a"}, reflectedTruncated.Field(3).Interface())
+	assert.EqualValues(t, big.NewInt(7), reflectedTruncated.Field(4).Interface())
+	assert.EqualValues(t, "abcdefgh", reflect.ValueOf(original).Field(0).Interface())
+
+	// Truncate repeatedly until nothing is left to truncate, and ensure only empty dynamic values remain.
+	for i := 0; ok; i++ {
+		assert.Less(t, i, 10, "truncation did not terminate")
+		truncated, ok = TruncateAbiValue(&tupleType, truncated)
+	}
+	reflectedTruncated = reflect.ValueOf(truncated)
+	assert.Empty(t, reflectedTruncated.Field(0).Interface())
+	assert.Empty(t, reflectedTruncated.Field(1).Interface())
+	assert.Empty(t, reflectedTruncated.Field(2).Interface())
+	assert.EqualValues(t, [2]string{"", ""}, reflectedTruncated.Field(3).Interface())
+
+	// Statically sized values cannot be truncated.
+	uint256Type := abi.Type{T: abi.UintTy, Size: 256}
+	truncated, ok = TruncateAbiValue(&uint256Type, big.NewInt(100))
+	assert.False(t, ok)
+	assert.EqualValues(t, big.NewInt(100), truncated)
+}
+
+// TestValidateAbiValue runs tests to ensure generated values of every type are valid for their type, and that values
+// of the wrong Go type or out of range for their type are rejected.
+func TestValidateAbiValue(t *testing.T) {
+	// Create a value generator
+	valueGenerator := NewRandomValueGenerator(&RandomValueGeneratorConfig{
+		GenerateRandomArrayMinSize:  0,
+		GenerateRandomArrayMaxSize:  10,
+		GenerateRandomBytesMinSize:  0,
+		GenerateRandomBytesMaxSize:  100,
+		GenerateRandomStringMinSize: 0,
+		GenerateRandomStringMaxSize: 100,
+	}, rand.New(rand.NewSource(time.Now().UnixNano())))
+
+	// Ensure generated values for every argument are valid.
+	for _, arg := range getTestABIArguments() {
+		value, err := GenerateAbiValue(valueGenerator, &arg.Type)
+		assert.NoError(t, err)
+		assert.NoError(t, ValidateAbiValue(&arg.Type, value), "generated value for '%v' was not valid", arg.Name)
+	}
+
+	// Ensure values of the wrong Go type are rejected.
+	uint8Type := abi.Type{T: abi.UintTy, Size: 8}
+	assert.Error(t, ValidateAbiValue(&uint8Type, big.NewInt(1)))
+	assert.Error(t, ValidateAbiValue(&uint8Type, nil))
+
+	// Ensure integers out of range for their type are rejected.
+	uint256Type := abi.Type{T: abi.UintTy, Size: 256}
+	int128Type := abi.Type{T: abi.IntTy, Size: 128}
+	assert.Error(t, ValidateAbiValue(&uint256Type, big.NewInt(-1)))
+	assert.Error(t, ValidateAbiValue(&int128Type, new(big.Int).Lsh(big.NewInt(1), 127)))
+	assert.NoError(t, ValidateAbiValue(&int128Type, new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))))
+
+	// Ensure invalid elements of composite types are rejected.
+	arrayType := abi.Type{T: abi.ArrayTy, Size: 2, Elem: &uint256Type}
+	assert.NoError(t, ValidateAbiValue(&arrayType, [2]*big.Int{big.NewInt(1), big.NewInt(2)}))
+	assert.Error(t, ValidateAbiValue(&arrayType, [2]*big.Int{big.NewInt(1), big.NewInt(-2)}))
+
+	// Ensure nil integers are rejected, including as elements of composite types.
+	assert.Error(t, ValidateAbiValue(&uint256Type, (*big.Int)(nil)))
+	assert.Error(t, ValidateAbiValue(&arrayType, [2]*big.Int{big.NewInt(1), nil}))
+
+	// Ensure invalid fields of tuples are rejected, whether or not the tuple type defines raw field names.
+	tupleValue := struct {
+		Amount *big.Int
+		Fee    uint8
+	}{Amount: big.NewInt(-1), Fee: 1}
+	namedTupleType := abi.Type{T: abi.TupleTy, TupleElems: []*abi.Type{&uint256Type, &uint8Type}, TupleRawNames: []string{"amount", "fee"}}
+	unnamedTupleType := abi.Type{T: abi.TupleTy, TupleElems: []*abi.Type{&uint256Type, &uint8Type}}
+	assert.ErrorContains(t, ValidateAbiValue(&namedTupleType, tupleValue), "'amount'")
+	assert.ErrorContains(t, ValidateAbiValue(&unnamedTupleType, tupleValue), "field 0")
+	tupleValue.Amount = nil
+	assert.Error(t, ValidateAbiValue(&unnamedTupleType, tupleValue))
+	tupleValue.Amount = big.NewInt(1)
+	assert.NoError(t, ValidateAbiValue(&unnamedTupleType, tupleValue))
+}
+
+// TestParseAbiSignature runs tests to ensure human-written signatures are parsed into the expected method name and
+// argument types, including tuples, arrays, and parameter names, and that malformed signatures are rejected.
+func TestParseAbiSignature(t *testing.T) {
+	// Parse a signature with simple types and parameter names.
+	name, args, err := ParseAbiSignature("transfer(address to, uint256 amount)")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "transfer", name)
+	assert.Len(t, args, 2)
+	assert.EqualValues(t, "to", args[0].Name)
+	assert.EqualValues(t, abi.AddressTy, args[0].Type.T)
+	assert.EqualValues(t, "uint256", args[1].Type.String())
+
+	// Parse a signature with nested tuples and arrays, and ensure it encodes to the expected method signature.
+	name, args, err = ParseAbiSignature("f((uint256,(bool,bytes)[])[2],string[],int8)")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "f", name)
+	assert.Len(t, args, 3)
+	assert.EqualValues(t, abi.ArrayTy, args[0].Type.T)
+	assert.EqualValues(t, abi.TupleTy, args[0].Type.Elem.T)
+	method := abi.NewMethod(name, name, abi.Function, "", false, false, args, nil)
+	assert.EqualValues(t, "f((uint256,(bool,bytes)[])[2],string[],int8)", method.Sig)
+
+	// Parse a signature without arguments.
+	_, args, err = ParseAbiSignature("fallback()")
+	assert.NoError(t, err)
+	assert.Len(t, args, 0)
+
+	// Ensure malformed signatures are rejected.
+	for _, signature := range []string{"f", "f(uint256", "f((uint256,bool)", "f(uint256,)", "f(notatype)"} {
+		_, _, err = ParseAbiSignature(signature)
+		assert.Error(t, err, "signature '%v' should not have parsed", signature)
+	}
+}
+
+// TestAbiTypesEqual runs tests to ensure types are compared structurally, including their sizes, element types, and
+// tuple names and elements, rather than by identity.
+func TestAbiTypesEqual(t *testing.T) {
+	newType := func(typeName string, internalType string, components []abi.ArgumentMarshaling) *abi.Type {
+		abiType, err := abi.NewType(typeName, internalType, components)
+		assert.NoError(t, err)
+		return &abiType
+	}
+	pointComponents := []abi.ArgumentMarshaling{{Name: "x", Type: "uint256"}, {Name: "y", Type: "uint256"}}
+
+	tests := []struct {
+		a     *abi.Type
+		b     *abi.Type
+		equal bool
+	}{
+		{a: newType("uint256", "", nil), b: newType("uint256", "", nil), equal: true},
+		{a: newType("uint256", "", nil), b: newType("uint128", "", nil), equal: false},
+		{a: newType("uint256", "", nil), b: newType("int256", "", nil), equal: false},
+		{a: newType("bytes32", "", nil), b: newType("bytes4", "", nil), equal: false},
+		{a: newType("address[]", "", nil), b: newType("address[]", "", nil), equal: true},
+		{a: newType("address[]", "", nil), b: newType("address[2]", "", nil), equal: false},
+		{a: newType("uint8[2]", "", nil), b: newType("uint16[2]", "", nil), equal: false},
+		{a: newType("uint8[][2]", "", nil), b: newType("uint8[][2]", "", nil), equal: true},
+		{a: newType("tuple", "struct Test.Point", pointComponents), b: newType("tuple", "struct Test.Point", pointComponents), equal: true},
+		{a: newType("tuple[]", "struct Test.Point[]", pointComponents), b: newType("tuple[]", "struct Test.Point[]", pointComponents), equal: true},
+		{a: newType("tuple", "struct Test.Point", pointComponents), b: newType("tuple", "struct Test.Coordinate", pointComponents), equal: false},
+		{
+			a:     newType("tuple", "struct Test.Point", pointComponents),
+			b:     newType("tuple", "struct Test.Point", []abi.ArgumentMarshaling{{Name: "x", Type: "uint256"}, {Name: "z", Type: "uint256"}}),
+			equal: false,
+		},
+		{
+			a:     newType("tuple", "struct Test.Point", pointComponents),
+			b:     newType("tuple", "struct Test.Point", []abi.ArgumentMarshaling{{Name: "x", Type: "uint256"}, {Name: "y", Type: "int256"}}),
+			equal: false,
+		},
+		{
+			a:     newType("tuple", "struct Test.Point", pointComponents),
+			b:     newType("tuple", "struct Test.Point", pointComponents[:1]),
+			equal: false,
+		},
+		{a: newType("uint256", "", nil), b: nil, equal: false},
+		{a: nil, b: nil, equal: true},
+	}
+	for i, test := range tests {
+		assert.EqualValues(t, test.equal, AbiTypesEqual(test.a, test.b), "test %d", i)
+		assert.EqualValues(t, test.equal, AbiTypesEqual(test.b, test.a), "test %d (reversed)", i)
+	}
+}
+
+// TestCheckAbiTypeNotRecursive runs tests to ensure types which contain themselves are detected as recursive, while
+// types containing the same struct multiple times without nesting it are not.
+func TestCheckAbiTypeNotRecursive(t *testing.T) {
+	// A struct containing two fields of the same struct type is not recursive.
+	pointComponents := []abi.ArgumentMarshaling{
+		{Name: "x", Type: "uint256"},
+		{Name: "y", Type: "uint256"},
+	}
+	pairType, err := abi.NewType("tuple", "struct Test.Pair", []abi.ArgumentMarshaling{
+		{Name: "a", Type: "tuple", InternalType: "struct Test.Point", Components: pointComponents},
+		{Name: "b", Type: "tuple[]", InternalType: "struct Test.Point[]", Components: pointComponents},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, CheckAbiTypeNotRecursive(&pairType))
+
+	// A type referencing itself is recursive.
+	selfReferencingType := &abi.Type{T: abi.SliceTy}
+	selfReferencingType.Elem = selfReferencingType
+	assert.Error(t, CheckAbiTypeNotRecursive(selfReferencingType))
+
+	// A struct containing an array of the same struct is recursive.
+	uintType := abi.Type{T: abi.UintTy, Size: 256}
+	innerNodeType := abi.Type{T: abi.TupleTy, TupleRawName: "TestNode", TupleElems: []*abi.Type{&uintType}, TupleRawNames: []string{"value"}}
+	nodeSliceType := abi.Type{T: abi.SliceTy, Elem: &innerNodeType}
+	nodeType := abi.Type{T: abi.TupleTy, TupleRawName: "TestNode", TupleElems: []*abi.Type{&uintType, &nodeSliceType}, TupleRawNames: []string{"value", "children"}}
+	assert.Error(t, CheckAbiTypeNotRecursive(&nodeType))
+}
+
+// TestGenerateAbiValueUnsupportedType runs a test to ensure generating a value of an unsupported type returns an error
+// rather than panicking.
+func TestGenerateAbiValueUnsupportedType(t *testing.T) {
+	valueGenerator := NewRandomValueGenerator(&RandomValueGeneratorConfig{}, rand.New(rand.NewSource(0)))
+	fixedPointType := abi.Type{T: abi.FixedPointTy, Size: 128}
+	_, err := GenerateAbiValue(valueGenerator, &fixedPointType)
+	assert.ErrorContains(t, err, "unsupported type")
+}
+
+// TestShrinkIntegerBySignReinterpretation runs tests to ensure integers are reinterpreted across the signed boundary
+// only when doing so yields a smaller value, using int256 and uint256 edge values.
+func TestShrinkIntegerBySignReinterpretation(t *testing.T) {
+	int256Max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	int256Min := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	uint256Max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	// Define our test cases as an input value, its signedness, and the expected shrunk value (nil if none).
+	testCases := []struct {
+		value    *big.Int
+		signed   bool
+		expected *big.Int
+	}{
+		{int256Max, true, big.NewInt(-1)},
+		{new(big.Int).Sub(int256Max, big.NewInt(4)), true, big.NewInt(-5)},
+		{int256Min, true, big.NewInt(0)},
+		{new(big.Int).Add(int256Min, big.NewInt(3)), true, big.NewInt(3)},
+		{big.NewInt(-1), true, nil},
+		{big.NewInt(0), true, nil},
+		{big.NewInt(1), true, nil},
+		{uint256Max, false, int256Max},
+		{new(big.Int).Lsh(big.NewInt(1), 255), false, big.NewInt(0)},
+		{big.NewInt(1), false, nil},
+	}
+	for _, testCase := range testCases {
+		shrunk, ok := ShrinkIntegerBySignReinterpretation(testCase.value, testCase.signed, 256)
+		if testCase.expected == nil {
+			assert.False(t, ok, "value %v should not have been shrunk", testCase.value)
+			continue
+		}
+		assert.True(t, ok, "value %v should have been shrunk", testCase.value)
+		assert.EqualValues(t, 0, testCase.expected.Cmp(shrunk), "value %v shrunk to %v, expected %v", testCase.value, shrunk, testCase.expected)
+
+		// Ensure the shrunk value remains in range for the type.
+		intType := abi.Type{T: abi.UintTy, Size: 256}
+		if testCase.signed {
+			intType.T = abi.IntTy
+		}
+		assert.NoError(t, ValidateAbiValue(&intType, shrunk))
+	}
+}
+
+// TestTupleFixup runs tests to ensure a TupleFixupFunc provided by the value generator is applied to generated and
+// mutated tuples of its type, so their fields satisfy consistency requirements, and that fixups producing invalid
+// values are rejected.
+func TestTupleFixup(t *testing.T) {
+	// Define a tuple type with a start and end time.
+	windowType, err := abi.NewType("tuple", "struct Test.Window", []abi.ArgumentMarshaling{
+		{Name: "startTime", Type: "uint256"},
+		{Name: "endTime", Type: "uint256"},
+	})
+	assert.NoError(t, err)
+
+	// Create a value generator with a fixup ensuring the end time is not before the start time.
+	valueGeneratorConfig := &RandomValueGeneratorConfig{
+		TupleFixups: map[string]TupleFixupFunc{
+			windowType.TupleRawName: func(tupleType *abi.Type, fields []any) {
+				if fields[1].(*big.Int).Cmp(fields[0].(*big.Int)) < 0 {
+					fields[0], fields[1] = fields[1], fields[0]
+				}
+			},
+		},
+	}
+	valueGenerator := NewRandomValueGenerator(valueGeneratorConfig, rand.New(rand.NewSource(time.Now().UnixNano())))
+
+	// Ensure every generated and mutated tuple satisfies our fixup.
+	for i := 0; i < 100; i++ {
+		value, err := GenerateAbiValue(valueGenerator, &windowType)
+		assert.NoError(t, err)
+		mutatedValue, err := MutateAbiValue(valueGenerator, &windowType, value)
+		assert.NoError(t, err)
+		for _, tuple := range []any{value, mutatedValue} {
+			reflectedTuple := reflect.ValueOf(tuple)
+			startTime := reflectedTuple.Field(0).Interface().(*big.Int)
+			endTime := reflectedTuple.Field(1).Interface().(*big.Int)
+			assert.True(t, endTime.Cmp(startTime) >= 0, "end time %v is before start time %v", endTime, startTime)
+		}
+	}
+
+	// Ensure the fixup is also applied through a SynchronizedValueGenerator.
+	synchronizedValueGenerator := NewSynchronizedValueGenerator(valueGenerator)
+	assert.NotNil(t, synchronizedValueGenerator.TupleFixup(&windowType))
+
+	// Ensure a fixup which produces a value that is invalid for the field's type is rejected.
+	valueGeneratorConfig.TupleFixups[windowType.TupleRawName] = func(tupleType *abi.Type, fields []any) {
+		fields[0] = big.NewInt(-1)
+	}
+	_, err = GenerateAbiValue(valueGenerator, &windowType)
+	assert.Error(t, err)
+	_, err = GenerateAbiValue(synchronizedValueGenerator, &windowType)
+	assert.Error(t, err)
+
+	// Ensure a fixup which replaces a field with a value of a different Go type is rejected.
+	valueGeneratorConfig.TupleFixups[windowType.TupleRawName] = func(tupleType *abi.Type, fields []any) {
+		fields[1] = uint64(1)
+	}
+	_, err = GenerateAbiValue(valueGenerator, &windowType)
+	assert.Error(t, err)
+
+	// Ensure tuples without a fixup are unaffected.
+	delete(valueGeneratorConfig.TupleFixups, windowType.TupleRawName)
+	_, err = GenerateAbiValue(valueGenerator, &windowType)
+	assert.NoError(t, err)
+}
+
+// TestGenerateZeroAbiValue runs tests to ensure zero values generated for every type are valid for their type, and
+// can be encoded.
+func TestGenerateZeroAbiValue(t *testing.T) {
+	for _, arg := range getTestABIArguments() {
+		value := GenerateZeroAbiValue(&arg.Type)
+		assert.NoError(t, ValidateAbiValue(&arg.Type, value), "zero value for '%v' was not valid", arg.Name)
+		_, err := abi.Arguments{arg}.Pack(value)
+		assert.NoError(t, err, "zero value for '%v' could not be encoded", arg.Name)
+	}
+
+	// Ensure integers are zero and dynamic arrays are empty.
+	uint256Type := abi.Type{T: abi.UintTy, Size: 256}
+	sliceType := abi.Type{T: abi.SliceTy, Elem: &uint256Type}
+	assert.EqualValues(t, 0, GenerateZeroAbiValue(&uint256Type).(*big.Int).Sign())
+	assert.Len(t, GenerateZeroAbiValue(&sliceType), 0)
+}
+
+// TestAbiValueGenerationStats runs tests to ensure value generation statistics are only collected by generators which
+// are configured with them, account for the elements of composite values, and are not shared between generators.
+func TestAbiValueGenerationStats(t *testing.T) {
+	newValueGenerator := func(stats *AbiValueGenerationStats) *RandomValueGenerator {
+		return NewRandomValueGenerator(&RandomValueGeneratorConfig{
+			GenerateRandomArrayMinSize: 3,
+			GenerateRandomArrayMaxSize: 3,
+			GenerationStats:            stats,
+		}, rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+	uint8Type := abi.Type{T: abi.UintTy, Size: 8}
+	sliceType := abi.Type{T: abi.SliceTy, Elem: &uint8Type}
+
+	// Generate a value with a generator without statistics, ensuring nothing is provided to record it in.
+	valueGenerator := newValueGenerator(nil)
+	_, err := GenerateAbiValue(valueGenerator, &sliceType)
+	assert.NoError(t, err)
+	assert.Nil(t, valueGenerator.GenerationStats())
+
+	// Generate a value with a generator with statistics, and ensure the slice and its elements were recorded.
+	stats := NewAbiValueGenerationStats()
+	_, err = GenerateAbiValue(newValueGenerator(stats), &sliceType)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, stats.TypeCounts["slice"])
+	assert.EqualValues(t, 3, stats.TypeCounts["uint"])
+	assert.EqualValues(t, 1, stats.ArrayLengths[3])
+
+	var integerCount uint64
+	for bitLength, count := range stats.IntegerBitLengths {
+		assert.LessOrEqual(t, bitLength, 8)
+		integerCount += count
+	}
+	assert.EqualValues(t, 3, integerCount)
+	assert.Contains(t, stats.String(), "uint: 3")
+
+	// Generate a value with another generator through a SynchronizedValueGenerator, and ensure it is recorded in
+	// that generator's statistics only.
+	otherStats := NewAbiValueGenerationStats()
+	_, err = GenerateAbiValue(NewSynchronizedValueGenerator(newValueGenerator(otherStats)), &uint8Type)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, otherStats.TypeCounts["uint"])
+	assert.EqualValues(t, 3, stats.TypeCounts["uint"])
+
+	// Merge the statistics and ensure the counts are summed, without modifying the merged statistics.
+	stats.Merge(otherStats)
+	assert.EqualValues(t, 4, stats.TypeCounts["uint"])
+	assert.EqualValues(t, 1, stats.TypeCounts["slice"])
+	assert.EqualValues(t, 1, otherStats.TypeCounts["uint"])
+}
+
+// TestJSONArgumentCodecs runs tests to ensure a provided JSONArgumentCodec is used when encoding and decoding JSON
+// arguments, including elements of composite types, and that the default representation is used otherwise.
+func TestJSONArgumentCodecs(t *testing.T) {
+	// Define an argument containing a slice of integers.
+	uintType := abi.Type{T: abi.UintTy, Size: 256}
+	args := abi.Arguments{
+		{
+			Name: "values",
+			Type: abi.Type{
+				T:    abi.SliceTy,
+				Elem: &uintType,
+			},
+		},
+	}
+	values := []any{[]*big.Int{big.NewInt(10), big.NewInt(255)}}
+
+	// Create codecs which encode integers as hex strings.
+	codecs := JSONArgumentCodecs{
+		abi.UintTy: {
+			Encode: func(inputType *abi.Type, value any) (any, error) {
+				return hexutil.EncodeBig(value.(*big.Int)), nil
+			},
+		},
+	}
+
+	// Encode our values and ensure the codec was used, then decode them to ensure the default decoding still applies.
+	encoded, err := EncodeJSONArgumentsToSlice(args, values, codecs)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []any{[]any{"0xa", "0xff"}}, encoded)
+	decoded, err := DecodeJSONArgumentsFromSlice(args, encoded, nil, nil, 0, codecs)
+	assert.NoError(t, err)
+	assert.EqualValues(t, values, decoded)
+
+	// Add a decoder for hex strings, and ensure it is used for the elements of the slice.
+	codecs[abi.UintTy] = JSONArgumentCodec{
+		Decode: func(inputType *abi.Type, value any) (any, error) {
+			return hexutil.DecodeBig(value.(string))
+		},
+	}
+	decoded, err = DecodeJSONArgumentsFromSlice(args, []any{[]any{"0xa", "0xff"}}, nil, nil, 0, codecs)
+	assert.NoError(t, err)
+	assert.EqualValues(t, values, decoded)
+
+	// Ensure the default representation is used without codecs.
+	encoded, err = EncodeJSONArgumentsToSlice(args, values, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []any{[]any{"10", "255"}}, encoded)
+}
+
+// TestGenerateAbiValueConcurrently runs tests to ensure a ValueGenerator wrapped by a SynchronizedValueGenerator can
+// be shared by multiple goroutines generating values concurrently. This should be run with the race detector enabled.
+func TestGenerateAbiValueConcurrently(t *testing.T) {
+	// Create a value generator shared by all goroutines
+	valueGenConfig := &MutatingValueGeneratorConfig{
+		MinMutationRounds:            0,
+		MaxMutationRounds:            1,
+		GenerateRandomIntegerBias:    0.5,
+		GenerateRandomStringBias:     0.5,
+		GenerateRandomBytesBias:      0.5,
+		MutateIntegerProbability:     0.5,
+		MutateIntegerGenerateNewBias: 0.5,
+		RandomValueGeneratorConfig: &RandomValueGeneratorConfig{
+			GenerateRandomArrayMinSize:  0,
+			GenerateRandomArrayMaxSize:  10,
+			GenerateRandomBytesMinSize:  0,
+			GenerateRandomBytesMaxSize:  100,
+			GenerateRandomStringMinSize: 0,
+			GenerateRandomStringMaxSize: 100,
+		},
+	}
+	valueGenerator := NewSynchronizedValueGenerator(
+		NewMutatingValueGenerator(valueGenConfig, NewValueSet(), rand.New(rand.NewSource(time.Now().UnixNano()))),
+	)
+
+	// Generate values for every test argument from multiple goroutines at once.
+	args := getTestABIArguments()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				for _, arg := range args {
+					value, err := GenerateAbiValue(valueGenerator, &arg.Type)
+					assert.NoError(t, err)
+					_, err = MutateAbiValue(valueGenerator, &arg.Type, value)
+					assert.NoError(t, err)
+				}
+				valueGenerator.RandomProvider().Intn(100)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestValueSetAFLDictionaryExport runs tests to ensure a ValueSet is exported to an AFL dictionary with each value
+// escaped, and integers written in their 32-byte representation, including fixed-sized byte arrays of any length.
+func TestValueSetAFLDictionaryExport(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.AddInteger(big.NewInt(-1))
+	valueSet.AddString("a\"b\\c\n")
+	valueSet.AddBytes([]byte{})
+	valueSet.AddAddress(common.HexToAddress("0x1000"))
+	valueSet.AddFixedBytes([]byte{0x12, 0x34, 0x56, 0x78})
+	valueSet.AddFixedBytes([]byte("ab"))
+
+	// Export the value set and read the dictionary back.
+	path := filepath.Join(t.TempDir(), "values.dict")
+	err := valueSet.WriteToAFLDictionaryFile(path)
+	assert.NoError(t, err)
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	// Verify each value was escaped, and the empty byte array was not written.
+	expected := "address_0=\"" + strings.Repeat("\\x00", 18) + "\\x10\\x00\"\n" +
+		"integer_0=\"" + strings.Repeat("\\xff", 32) + "\"\n" +
+		"string_0=\"a\\x22b\\x5cc\\x0a\"\n" +
+		"fixed_bytes_0=\"\\x124Vx\"\n" +
+		"fixed_bytes_1=\"ab\"\n"
+	assert.EqualValues(t, expected, string(b))
+}
@@ -0,0 +1,56 @@
+package valuegeneration
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/crytic/medusa/utils/reflectionutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// TupleFixupFunc describes a function which adjusts the field values of a generated or mutated tuple, so that they
+// satisfy internal consistency requirements (e.g. a start time which does not exceed an end time). The provided
+// fields are ordered as the tuple type's TupleElems, and may be replaced in place. Replacement values must be of the
+// same Go type as the values they replace.
+type TupleFixupFunc func(tupleType *abi.Type, fields []any)
+
+// TupleFixupProvider represents an optional interface which a ValueGenerator may implement to provide a
+// TupleFixupFunc for tuple types. If implemented, the provided fixup is applied after every field of a tuple has been
+// generated or mutated.
+type TupleFixupProvider interface {
+	// TupleFixup returns the TupleFixupFunc to apply to tuples of the provided type, or nil if there is none.
+	TupleFixup(tupleType *abi.Type) TupleFixupFunc
+}
+
+// applyTupleFixup calls the TupleFixupFunc provided by the ValueGenerator for the provided tuple type, if any, with
+// the field values of the provided tuple, then sets any adjusted field values on it. The tuple must be assignable.
+// Returns an error if the fixup produced field values which are not valid for the tuple type.
+func applyTupleFixup(generator ValueGenerator, inputType *abi.Type, tuple reflect.Value) error {
+	fixupProvider, ok := generator.(TupleFixupProvider)
+	if !ok {
+		return nil
+	}
+	fixup := fixupProvider.TupleFixup(inputType)
+	if fixup == nil {
+		return nil
+	}
+
+	// Obtain our field values, allow the fixup to adjust them, then set them on the tuple.
+	fields := make([]any, len(inputType.TupleElems))
+	for i := 0; i < len(fields); i++ {
+		fields[i] = reflectionutils.GetField(tuple.Field(i))
+	}
+	fixup(inputType, fields)
+	for i := 0; i < len(fields); i++ {
+		if reflect.TypeOf(fields[i]) != tuple.Field(i).Type() {
+			return fmt.Errorf("tuple fixup for '%v' replaced field %d with a value of type %T", inputType.TupleRawName, i, fields[i])
+		}
+		reflectionutils.SetField(tuple.Field(i), fields[i])
+	}
+
+	// Verify the adjusted tuple is still valid for its type.
+	if err := ValidateAbiValue(inputType, tuple.Interface()); err != nil {
+		return fmt.Errorf("tuple fixup for '%v' produced an invalid value: %v", inputType.TupleRawName, err)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package valuegeneration
+
+import (
+	"math/big"
+
+	"github.com/crytic/medusa/utils"
+	"github.com/crytic/medusa/utils/randomutils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ValueGenerationStrategy describes a strategy used by GenerateAbiValue to generate leaf values (addresses, integers,
+// dynamic bytes and strings). Each method returns a boolean indicating whether the strategy could produce a value.
+// If it could not, the ValueGenerator is used to generate the value instead.
+type ValueGenerationStrategy interface {
+	// GenerateAddress generates an address.
+	GenerateAddress(generator ValueGenerator) (common.Address, bool)
+	// GenerateInteger generates an integer of the provided signedness and bit length.
+	GenerateInteger(generator ValueGenerator, signed bool, bitLength int) (*big.Int, bool)
+	// GenerateBytes generates a dynamic-sized byte array.
+	GenerateBytes(generator ValueGenerator) ([]byte, bool)
+	// GenerateString generates a string.
+	GenerateString(generator ValueGenerator) (string, bool)
+}
+
+// ValueGenerationStrategyProvider describes a ValueGenerator which provides a chooser used to select a
+// ValueGenerationStrategy for each leaf value generated by GenerateAbiValue.
+type ValueGenerationStrategyProvider interface {
+	// StrategyChooser returns the chooser used to select a ValueGenerationStrategy, or nil if leaf values should
+	// always be generated by the ValueGenerator itself.
+	StrategyChooser() *randomutils.WeightedRandomChooser[ValueGenerationStrategy]
+}
+
+// ScaledIntegerGenerator describes a ValueGenerator which may generate integers as multiples of scale constants (e.g.
+// 1e18 for ether-denominated amounts) when GenerateAbiValue generates integer values.
+type ScaledIntegerGenerator interface {
+	// GenerateScaledInteger generates a multiple of a scale constant of the provided signedness and bit length.
+	// Returns the generated integer and a boolean indicating whether one was generated.
+	GenerateScaledInteger(signed bool, bitLength int) (*big.Int, bool)
+}
+
+// chooseStrategy selects a ValueGenerationStrategy using the chooser provided by the ValueGenerator, if it provides
+// one.
+// Returns the selected strategy, or nil if none could be selected.
+func chooseStrategy(generator ValueGenerator) ValueGenerationStrategy {
+	strategyProvider, ok := generator.(ValueGenerationStrategyProvider)
+	if !ok {
+		return nil
+	}
+	strategyChooser := strategyProvider.StrategyChooser()
+	if strategyChooser == nil || strategyChooser.ChoiceCount() == 0 {
+		return nil
+	}
+	strategy, err := strategyChooser.Choose()
+	if err != nil {
+		return nil
+	}
+	return *strategy
+}
+
+// generateAddress generates an address using a selected ValueGenerationStrategy, or the ValueGenerator otherwise.
+func generateAddress(generator ValueGenerator) common.Address {
+	if strategy := chooseStrategy(generator); strategy != nil {
+		if value, ok := strategy.GenerateAddress(generator); ok {
+			return value
+		}
+	}
+	return generator.GenerateAddress()
+}
+
+// generateInteger generates an integer as a multiple of a scale constant if the ValueGenerator chooses to, or otherwise
+// using a selected ValueGenerationStrategy, or the ValueGenerator itself.
+func generateInteger(generator ValueGenerator, signed bool, bitLength int) *big.Int {
+	if scaledIntegerGenerator, ok := generator.(ScaledIntegerGenerator); ok {
+		if value, ok := scaledIntegerGenerator.GenerateScaledInteger(signed, bitLength); ok {
+			return value
+		}
+	}
+	if strategy := chooseStrategy(generator); strategy != nil {
+		if value, ok := strategy.GenerateInteger(generator, signed, bitLength); ok {
+			return value
+		}
+	}
+	return generator.GenerateInteger(signed, bitLength)
+}
+
+// generateBytes generates a dynamic-sized byte array using a selected ValueGenerationStrategy, or the ValueGenerator
+// otherwise.
+func generateBytes(generator ValueGenerator) []byte {
+	if strategy := chooseStrategy(generator); strategy != nil {
+		if value, ok := strategy.GenerateBytes(generator); ok {
+			return value
+		}
+	}
+	return generator.GenerateBytes()
+}
+
+// generateString generates a string using a selected ValueGenerationStrategy, or the ValueGenerator otherwise.
+func generateString(generator ValueGenerator) string {
+	if strategy := chooseStrategy(generator); strategy != nil {
+		if value, ok := strategy.GenerateString(generator); ok {
+			return value
+		}
+	}
+	return generator.GenerateString()
+}
+
+// RandomValueGenerationStrategy is a ValueGenerationStrategy which generates fresh random values. It is not safe for
+// concurrent use, as the RandomValueGenerator it reuses is not.
+type RandomValueGenerationStrategy struct {
+	// config describes the configuration defining random value generation parameters.
+	config *RandomValueGeneratorConfig
+
+	// generator describes the RandomValueGenerator last used to generate values, which is reused as long as it is
+	// called with a ValueGenerator with the same random provider.
+	generator *RandomValueGenerator
+}
+
+// NewRandomValueGenerationStrategy creates a new RandomValueGenerationStrategy which generates values as a
+// RandomValueGenerator with the provided config would, using the random provider of the ValueGenerator it is called
+// with.
+func NewRandomValueGenerationStrategy(config *RandomValueGeneratorConfig) *RandomValueGenerationStrategy {
+	return &RandomValueGenerationStrategy{
+		config: config,
+	}
+}
+
+// randomGenerator returns a RandomValueGenerator using the random provider of the provided ValueGenerator. The
+// RandomValueGenerator is only created when the random provider changes, rather than for every generated value.
+func (s *RandomValueGenerationStrategy) randomGenerator(generator ValueGenerator) *RandomValueGenerator {
+	randomProvider := generator.RandomProvider()
+	if s.generator == nil || s.generator.RandomProvider() != randomProvider {
+		s.generator = NewRandomValueGenerator(s.config, randomProvider)
+	}
+	return s.generator
+}
+
+// GenerateAddress generates a random address.
+func (s *RandomValueGenerationStrategy) GenerateAddress(generator ValueGenerator) (common.Address, bool) {
+	return s.randomGenerator(generator).GenerateAddress(), true
+}
+
+// GenerateInteger generates a random integer of the provided signedness and bit length.
+func (s *RandomValueGenerationStrategy) GenerateInteger(generator ValueGenerator, signed bool, bitLength int) (*big.Int, bool) {
+	return s.randomGenerator(generator).GenerateInteger(signed, bitLength), true
+}
+
+// GenerateBytes generates a random dynamic-sized byte array.
+func (s *RandomValueGenerationStrategy) GenerateBytes(generator ValueGenerator) ([]byte, bool) {
+	return s.randomGenerator(generator).GenerateBytes(), true
+}
+
+// GenerateString generates a random string.
+func (s *RandomValueGenerationStrategy) GenerateString(generator ValueGenerator) (string, bool) {
+	return s.randomGenerator(generator).GenerateString(), true
+}
+
+// ValueSetValueGenerationStrategy is a ValueGenerationStrategy which reuses values from a ValueSet.
+type ValueSetValueGenerationStrategy struct {
+	// ValueSet describes the set of values to select from.
+	ValueSet *ValueSet
+}
+
+// GenerateAddress selects a random address from the ValueSet.
+func (s *ValueSetValueGenerationStrategy) GenerateAddress(generator ValueGenerator) (common.Address, bool) {
+	return s.ValueSet.RandomAddress(generator.RandomProvider())
+}
+
+// GenerateInteger selects a random integer from the ValueSet, constrained to the provided signedness and bit length.
+func (s *ValueSetValueGenerationStrategy) GenerateInteger(generator ValueGenerator, signed bool, bitLength int) (*big.Int, bool) {
+	value, ok := s.ValueSet.RandomInteger(generator.RandomProvider())
+	if !ok {
+		return nil, false
+	}
+	return utils.ConstrainIntegerToBitLength(new(big.Int).Set(value), signed, bitLength), true
+}
+
+// GenerateBytes selects a random dynamic-sized byte array from the ValueSet.
+func (s *ValueSetValueGenerationStrategy) GenerateBytes(generator ValueGenerator) ([]byte, bool) {
+	value, ok := s.ValueSet.RandomBytes(generator.RandomProvider())
+	if !ok {
+		return nil, false
+	}
+	return append([]byte{}, value...), true
+}
+
+// GenerateString selects a random string from the ValueSet.
+func (s *ValueSetValueGenerationStrategy) GenerateString(generator ValueGenerator) (string, bool) {
+	return s.ValueSet.RandomString(generator.RandomProvider())
+}
+
+// BoundaryValueGenerationStrategy is a ValueGenerationStrategy which generates values at or near the boundaries of
+// their type, such as the minimum and maximum values of an integer type, or empty byte arrays and strings.
+type BoundaryValueGenerationStrategy struct{}
+
+// GenerateAddress returns the zero address.
+func (s *BoundaryValueGenerationStrategy) GenerateAddress(generator ValueGenerator) (common.Address, bool) {
+	return common.Address{}, true
+}
+
+// GenerateInteger selects a random integer at or adjacent to the bounds of the provided signedness and bit length, or
+// zero.
+func (s *BoundaryValueGenerationStrategy) GenerateInteger(generator ValueGenerator, signed bool, bitLength int) (*big.Int, bool) {
+	min, max := utils.GetIntegerConstraints(signed, bitLength)
+	boundaries := []*big.Int{
+		min,
+		new(big.Int).Add(min, big.NewInt(1)),
+		max,
+		new(big.Int).Sub(max, big.NewInt(1)),
+		big.NewInt(0),
+	}
+	return boundaries[generator.RandomProvider().Intn(len(boundaries))], true
+}
+
+// GenerateBytes returns an empty dynamic-sized byte array.
+func (s *BoundaryValueGenerationStrategy) GenerateBytes(generator ValueGenerator) ([]byte, bool) {
+	return []byte{}, true
+}
+
+// GenerateString returns an empty string.
+func (s *BoundaryValueGenerationStrategy) GenerateString(generator ValueGenerator) (string, bool) {
+	return "", true
+}
+
+// DictionaryValueGenerationStrategy is a ValueGenerationStrategy which injects entries from a user-provided
+// dictionary (e.g. magic values or tokens known to be meaningful to the target contracts).
+type DictionaryValueGenerationStrategy struct {
+	// Entries describes the dictionary entries to select from.
+	Entries [][]byte
+}
+
+// GenerateAddress selects a random dictionary entry and interprets it as an address.
+func (s *DictionaryValueGenerationStrategy) GenerateAddress(generator ValueGenerator) (common.Address, bool) {
+	if len(s.Entries) == 0 {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(s.Entries[generator.RandomProvider().Intn(len(s.Entries))]), true
+}
+
+// GenerateInteger selects a random dictionary entry and interprets it as a big-endian integer, constrained to the
+// provided signedness and bit length.
+func (s *DictionaryValueGenerationStrategy) GenerateInteger(generator ValueGenerator, signed bool, bitLength int) (*big.Int, bool) {
+	if len(s.Entries) == 0 {
+		return nil, false
+	}
+	value := new(big.Int).SetBytes(s.Entries[generator.RandomProvider().Intn(len(s.Entries))])
+	return utils.ConstrainIntegerToBitLength(value, signed, bitLength), true
+}
+
+// GenerateBytes selects a random dictionary entry.
+func (s *DictionaryValueGenerationStrategy) GenerateBytes(generator ValueGenerator) ([]byte, bool) {
+	if len(s.Entries) == 0 {
+		return nil, false
+	}
+	return append([]byte{}, s.Entries[generator.RandomProvider().Intn(len(s.Entries))]...), true
+}
+
+// GenerateString selects a random dictionary entry and interprets it as a string.
+func (s *DictionaryValueGenerationStrategy) GenerateString(generator ValueGenerator) (string, bool) {
+	if len(s.Entries) == 0 {
+		return "", false
+	}
+	return string(s.Entries[generator.RandomProvider().Intn(len(s.Entries))]), true
+}
@@ -0,0 +1,191 @@
+package valuegeneration
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/crytic/medusa/utils"
+	"github.com/crytic/medusa/utils/randomutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestStrategyValueGenerator creates a RandomValueGenerator which generates every leaf value using the provided
+// ValueGenerationStrategy.
+func newTestStrategyValueGenerator(strategy ValueGenerationStrategy) *RandomValueGenerator {
+	randomProvider := rand.New(rand.NewSource(1))
+	chooser := randomutils.NewWeightedRandomChooserWithRand[ValueGenerationStrategy](randomProvider, &sync.Mutex{})
+	chooser.AddChoices(randomutils.NewWeightedRandomChoice(strategy, big.NewInt(1)))
+	return NewRandomValueGenerator(&RandomValueGeneratorConfig{
+		GenerateRandomBytesMinSize:  1,
+		GenerateRandomBytesMaxSize:  10,
+		GenerateRandomStringMinSize: 1,
+		GenerateRandomStringMaxSize: 10,
+		StrategyChooser:             chooser,
+	}, randomProvider)
+}
+
+// TestRandomValueGenerationStrategy runs tests to ensure the random strategy generates values within the bounds of
+// their type and configuration.
+func TestRandomValueGenerationStrategy(t *testing.T) {
+	strategy := NewRandomValueGenerationStrategy(&RandomValueGeneratorConfig{
+		GenerateRandomBytesMinSize:  3,
+		GenerateRandomBytesMaxSize:  3,
+		GenerateRandomStringMinSize: 4,
+		GenerateRandomStringMaxSize: 4,
+	})
+	generator := newTestStrategyValueGenerator(strategy)
+
+	min, max := utils.GetIntegerConstraints(true, 8)
+	for i := 0; i < 100; i++ {
+		value, ok := strategy.GenerateInteger(generator, true, 8)
+		assert.True(t, ok)
+		assert.True(t, value.Cmp(min) >= 0 && value.Cmp(max) <= 0)
+	}
+	b, ok := strategy.GenerateBytes(generator)
+	assert.True(t, ok)
+	assert.Len(t, b, 3)
+	s, ok := strategy.GenerateString(generator)
+	assert.True(t, ok)
+	assert.Len(t, s, 4)
+
+	// The same RandomValueGenerator is reused for a given random provider, and replaced when it changes.
+	randomGenerator := strategy.randomGenerator(generator)
+	assert.Same(t, randomGenerator, strategy.randomGenerator(generator))
+	otherGenerator := newTestStrategyValueGenerator(strategy)
+	assert.NotSame(t, randomGenerator, strategy.randomGenerator(otherGenerator))
+	assert.Same(t, otherGenerator.RandomProvider(), strategy.randomGenerator(otherGenerator).RandomProvider())
+}
+
+// TestValueSetValueGenerationStrategy runs tests to ensure the value set strategy only produces values from its
+// ValueSet, constrained to the requested integer type, and defers to the value generator when the set is empty.
+func TestValueSetValueGenerationStrategy(t *testing.T) {
+	valueSet := NewValueSet()
+	strategy := &ValueSetValueGenerationStrategy{ValueSet: valueSet}
+	generator := newTestStrategyValueGenerator(strategy)
+
+	// An empty value set cannot produce values.
+	_, ok := strategy.GenerateAddress(generator)
+	assert.False(t, ok)
+	_, ok = strategy.GenerateInteger(generator, false, 256)
+	assert.False(t, ok)
+
+	// Populate the value set and verify only its values are produced.
+	address := common.HexToAddress("0x1234")
+	valueSet.AddAddress(address)
+	valueSet.AddInteger(big.NewInt(300))
+	valueSet.AddString("value")
+	valueSet.AddBytes([]byte{0x01, 0x02})
+
+	value, ok := strategy.GenerateAddress(generator)
+	assert.True(t, ok)
+	assert.EqualValues(t, address, value)
+	integer, ok := strategy.GenerateInteger(generator, false, 256)
+	assert.True(t, ok)
+	assert.EqualValues(t, 0, integer.Cmp(big.NewInt(300)))
+	integer, ok = strategy.GenerateInteger(generator, false, 8)
+	assert.True(t, ok)
+	assert.EqualValues(t, 0, integer.Cmp(big.NewInt(300-256)))
+	str, ok := strategy.GenerateString(generator)
+	assert.True(t, ok)
+	assert.EqualValues(t, "value", str)
+	b, ok := strategy.GenerateBytes(generator)
+	assert.True(t, ok)
+	assert.EqualValues(t, []byte{0x01, 0x02}, b)
+
+	// Verify values are generated through GenerateAbiValue using the strategy.
+	addressType := abi.Type{T: abi.AddressTy}
+	generatedAddress, err := GenerateAbiValue(generator, &addressType)
+	assert.NoError(t, err)
+	assert.EqualValues(t, address, generatedAddress)
+}
+
+// TestBoundaryValueGenerationStrategy runs tests to ensure the boundary strategy only produces values at or adjacent
+// to the bounds of their type, or zero.
+func TestBoundaryValueGenerationStrategy(t *testing.T) {
+	strategy := &BoundaryValueGenerationStrategy{}
+	generator := newTestStrategyValueGenerator(strategy)
+
+	for _, signed := range []bool{false, true} {
+		min, max := utils.GetIntegerConstraints(signed, 16)
+		boundaries := []*big.Int{min, new(big.Int).Add(min, big.NewInt(1)), max, new(big.Int).Sub(max, big.NewInt(1)), big.NewInt(0)}
+		for i := 0; i < 50; i++ {
+			value, ok := strategy.GenerateInteger(generator, signed, 16)
+			assert.True(t, ok)
+			assert.True(t, utils.SliceAny(boundaries, func(b *big.Int) bool { return b.Cmp(value) == 0 }))
+		}
+	}
+	b, ok := strategy.GenerateBytes(generator)
+	assert.True(t, ok)
+	assert.Empty(t, b)
+	s, ok := strategy.GenerateString(generator)
+	assert.True(t, ok)
+	assert.Empty(t, s)
+}
+
+// TestDictionaryValueGenerationStrategy runs tests to ensure the dictionary strategy only produces values derived from
+// its entries, and cannot produce values without entries.
+func TestDictionaryValueGenerationStrategy(t *testing.T) {
+	emptyStrategy := &DictionaryValueGenerationStrategy{}
+	generator := newTestStrategyValueGenerator(emptyStrategy)
+	_, ok := emptyStrategy.GenerateBytes(generator)
+	assert.False(t, ok)
+
+	strategy := &DictionaryValueGenerationStrategy{Entries: [][]byte{[]byte("magic")}}
+	b, ok := strategy.GenerateBytes(generator)
+	assert.True(t, ok)
+	assert.EqualValues(t, []byte("magic"), b)
+	s, ok := strategy.GenerateString(generator)
+	assert.True(t, ok)
+	assert.EqualValues(t, "magic", s)
+	integer, ok := strategy.GenerateInteger(generator, false, 256)
+	assert.True(t, ok)
+	assert.EqualValues(t, 0, integer.Cmp(new(big.Int).SetBytes([]byte("magic"))))
+
+	// Verify values are generated through GenerateAbiValue using the strategy.
+	stringType := abi.Type{T: abi.StringTy}
+	value, err := GenerateAbiValue(newTestStrategyValueGenerator(strategy), &stringType)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "magic", value)
+}
+
+// TestSynchronizedValueGeneratorStrategies runs tests to ensure a SynchronizedValueGenerator generates leaf values
+// using the strategies of its underlying generator, and dynamic array lengths using its typed array lengths.
+func TestSynchronizedValueGeneratorStrategies(t *testing.T) {
+	// Leaf values are generated with the underlying generator's strategies, if it has any.
+	strategy := &DictionaryValueGenerationStrategy{Entries: [][]byte{[]byte("magic")}}
+	generator := NewSynchronizedValueGenerator(newTestStrategyValueGenerator(strategy))
+	assert.NotNil(t, generator.StrategyChooser())
+	stringType := abi.Type{T: abi.StringTy}
+	for i := 0; i < 10; i++ {
+		value, err := GenerateAbiValue(generator, &stringType)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "magic", value)
+	}
+
+	// If the selected strategy cannot produce a value, the underlying generator generates it instead.
+	generator = NewSynchronizedValueGenerator(newTestStrategyValueGenerator(&DictionaryValueGenerationStrategy{}))
+	value, err := GenerateAbiValue(generator, &stringType)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, value)
+
+	// Generators without strategies provide no chooser.
+	generator = NewSynchronizedValueGenerator(NewRandomValueGenerator(&RandomValueGeneratorConfig{}, rand.New(rand.NewSource(1))))
+	assert.Nil(t, generator.StrategyChooser())
+
+	// Dynamic array lengths are selected from the lengths observed for the type by the underlying generator.
+	uint256SliceType, err := abi.NewType("uint256[]", "", nil)
+	assert.NoError(t, err)
+	valueSet := NewValueSet()
+	valueSet.AddArrayLength(&uint256SliceType, 7)
+	generator = NewSynchronizedValueGenerator(NewMutatingValueGenerator(&MutatingValueGeneratorConfig{
+		GenerateRandomArrayLengthBias: 0,
+		RandomValueGeneratorConfig:    &RandomValueGeneratorConfig{GenerateRandomArrayMaxSize: 5},
+	}, valueSet, rand.New(rand.NewSource(1))))
+	for i := 0; i < 10; i++ {
+		assert.EqualValues(t, 7, generator.GenerateArrayOfLengthForType(&uint256SliceType))
+	}
+}
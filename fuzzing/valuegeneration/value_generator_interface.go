@@ -1,6 +1,7 @@
 package valuegeneration
 
 import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"math/big"
 	"math/rand"
@@ -49,3 +50,12 @@ type ValueGenerator interface {
 	// MutateInteger takes an integer input and returns a mutated value based off the input.
 	MutateInteger(i *big.Int, signed bool, bitLength int) *big.Int
 }
+
+// TypedArrayLengthGenerator represents an optional interface which a ValueGenerator may implement to generate
+// dynamic array lengths with knowledge of the array type being generated. If implemented, it is used instead of
+// ValueGenerator.GenerateArrayOfLength when generating ABI values.
+type TypedArrayLengthGenerator interface {
+	// GenerateArrayOfLengthForType generates/selects an array length to use when populating a dynamic array of the
+	// provided type.
+	GenerateArrayOfLengthForType(inputType *abi.Type) int
+}
@@ -2,6 +2,7 @@ package valuegeneration
 
 import (
 	"github.com/crytic/medusa/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/exp/slices"
 	"math/big"
@@ -10,6 +11,7 @@ import (
 
 // MutatingValueGenerator is a provider used to generate function inputs and call arguments using mutation-based
 // approaches against items within a base_value_set.ValueSet, such as AST literals.
+// Like the RandomValueGenerator it inherits from, it is not safe for concurrent use.
 type MutatingValueGenerator struct {
 	// config describes the configuration defining value generation parameters.
 	config *MutatingValueGeneratorConfig
@@ -46,6 +48,14 @@ type MutatingValueGeneratorConfig struct {
 	// GenerateRandomStringBias defines the probability in which a byte array generated by the value generator is
 	// entirely random, rather than mutated. Value range is [0.0, 1.0].
 	GenerateRandomBytesBias float32
+	// GenerateRandomFixedBytesBias defines the probability in which a fixed-sized byte array generated by the value
+	// generator is entirely random, rather than selected from the ValueSet (e.g. a known method selector or event ID).
+	// Value range is [0.0, 1.0].
+	GenerateRandomFixedBytesBias float32
+	// GenerateRandomArrayLengthBias defines the probability in which a dynamic array length generated by the value
+	// generator is entirely random, rather than selected from the lengths in the ValueSet observed to be interesting
+	// for arrays of the same type. Value range is [0.0, 1.0].
+	GenerateRandomArrayLengthBias float32
 
 	// MutateAddressProbability defines the probability in which an existing address value will be mutated by
 	// the value generator. Value range is [0.0, 1.0].
@@ -364,27 +374,42 @@ func (g *MutatingValueGenerator) GenerateAddress() common.Address {
 		return g.RandomValueGenerator.GenerateAddress()
 	}
 
-	// Obtain our addresses from our value set. If we have none, generate a random one instead.
-	addresses := g.valueSet.Addresses()
-	if len(addresses) == 0 {
+	// Select a random address from our value set. If we have none, generate a random one instead.
+	address, ok := g.valueSet.RandomAddress(g.randomProvider)
+	if !ok {
 		return g.RandomValueGenerator.GenerateAddress()
 	}
-
-	// Select a random address from our set of addresses.
-	address := addresses[g.randomProvider.Intn(len(addresses))]
 	return address
 }
 
-// MutateAddress takes an address input and sometimes returns a mutated value based off the input.
+// MutateAddress takes an address input and sometimes returns a mutated value based off the input. Mutated addresses
+// are drawn from the known addresses in the value set (e.g. deployed contracts and senders) with the same bias as
+// GenerateAddress, as they are more meaningful than entirely random addresses.
 func (g *MutatingValueGenerator) MutateAddress(addr common.Address) common.Address {
 	// Determine whether to perform mutations against this input or just return it as-is.
 	randomGeneratorDecision := g.randomProvider.Float32()
 	if randomGeneratorDecision < g.config.MutateAddressProbability {
-		return g.RandomValueGenerator.GenerateAddress()
+		return g.GenerateAddress()
 	}
 	return addr
 }
 
+// GenerateArrayOfLengthForType generates an array length to use when populating a dynamic array of the provided type.
+// Lengths observed to be interesting for arrays of the same type are selected from the ValueSet with some
+// probability, so structurally important lengths are preserved during exploration.
+func (g *MutatingValueGenerator) GenerateArrayOfLengthForType(inputType *abi.Type) int {
+	// Unless our bias directs us to use the random generator, select a length observed for this type from our value set.
+	randomGeneratorDecision := g.randomProvider.Float32()
+	if randomGeneratorDecision >= g.config.GenerateRandomArrayLengthBias {
+		if length, ok := g.valueSet.RandomArrayLength(inputType, g.randomProvider); ok {
+			return length
+		}
+	}
+
+	// If we have no lengths for this type or our bias directs us to, use the random generator instead
+	return g.RandomValueGenerator.GenerateArrayOfLength()
+}
+
 // MutateArray takes a dynamic or fixed sized array as input, and returns a mutated value based off of the input.
 // Returns the mutated value. If any element of the returned array is nil, the value generator will be called upon
 // to generate it new.
@@ -433,6 +458,21 @@ func (g *MutatingValueGenerator) MutateBytes(b []byte) []byte {
 	return b
 }
 
+// GenerateFixedBytes generates a fixed-sized byte array to use when populating inputs. Values of the same length in the
+// ValueSet (e.g. known method selectors for bytes4, or event IDs for bytes32) are selected with some probability.
+func (g *MutatingValueGenerator) GenerateFixedBytes(length int) []byte {
+	// Unless our bias directs us to use the random generator, select a value of the same length from our value set.
+	randomGeneratorDecision := g.randomProvider.Float32()
+	if randomGeneratorDecision >= g.config.GenerateRandomFixedBytesBias {
+		if b, ok := g.valueSet.RandomFixedBytes(length, g.randomProvider); ok {
+			return slices.Clone(b)
+		}
+	}
+
+	// If we have no inputs of this length or our bias directs us to, use the random generator instead
+	return g.RandomValueGenerator.GenerateFixedBytes(length)
+}
+
 // MutateFixedBytes takes a fixed-sized byte array input and returns a mutated value based off the input.
 func (g *MutatingValueGenerator) MutateFixedBytes(b []byte) []byte {
 	// Determine whether to perform mutations against this input or just return it as-is.
@@ -0,0 +1,114 @@
+package valuegeneration
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMutatingValueGeneratorGenerateFixedBytes runs tests to ensure fixed-sized byte arrays are selected from the
+// ValueSet values of the requested length according to GenerateRandomFixedBytesBias, and are generated randomly
+// otherwise.
+func TestMutatingValueGeneratorGenerateFixedBytes(t *testing.T) {
+	// Create a value set with method selectors and an event ID.
+	selectors := [][]byte{{0x01, 0x02, 0x03, 0x04}, {0x05, 0x06, 0x07, 0x08}}
+	eventID := make([]byte, 32)
+	eventID[0] = 0xff
+	valueSet := NewValueSet()
+	for _, selector := range selectors {
+		valueSet.AddFixedBytes(selector)
+	}
+	valueSet.AddFixedBytes(eventID)
+
+	// Values are indexed by their length.
+	assert.EqualValues(t, selectors, valueSet.FixedBytes(4))
+	assert.EqualValues(t, [][]byte{eventID}, valueSet.FixedBytes(32))
+	assert.Empty(t, valueSet.FixedBytes(8))
+
+	// Determine how often values are selected from the value set for each bias.
+	const iterations = 1000
+	tests := []struct {
+		bias             float32
+		minSelectedCount int
+		maxSelectedCount int
+	}{
+		{bias: 0, minSelectedCount: iterations, maxSelectedCount: iterations},
+		{bias: 0.5, minSelectedCount: iterations * 4 / 10, maxSelectedCount: iterations * 6 / 10},
+		{bias: 1, minSelectedCount: 0, maxSelectedCount: 0},
+	}
+	for _, test := range tests {
+		generator := NewMutatingValueGenerator(&MutatingValueGeneratorConfig{
+			GenerateRandomFixedBytesBias: test.bias,
+			RandomValueGeneratorConfig:   &RandomValueGeneratorConfig{},
+		}, valueSet, rand.New(rand.NewSource(1)))
+
+		selectedCount := 0
+		selected := make(map[string]bool)
+		for i := 0; i < iterations; i++ {
+			b := generator.GenerateFixedBytes(4)
+			assert.Len(t, b, 4)
+			for _, selector := range selectors {
+				if string(b) == string(selector) {
+					selectedCount++
+					selected[string(b)] = true
+				}
+			}
+
+			// Values of lengths which are not in the value set are always generated randomly.
+			assert.Len(t, generator.GenerateFixedBytes(8), 8)
+		}
+		assert.GreaterOrEqual(t, selectedCount, test.minSelectedCount)
+		assert.LessOrEqual(t, selectedCount, test.maxSelectedCount)
+		if test.minSelectedCount > 0 {
+			assert.Len(t, selected, len(selectors))
+		}
+	}
+
+	// Removing a value removes it from the index of a clone, without affecting the original.
+	clonedValueSet := valueSet.Clone()
+	clonedValueSet.RemoveFixedBytes(selectors[0])
+	assert.EqualValues(t, [][]byte{selectors[1]}, clonedValueSet.FixedBytes(4))
+	assert.EqualValues(t, selectors, valueSet.FixedBytes(4))
+}
+
+// TestMutatingValueGeneratorGenerateArrayOfLengthForType runs tests to ensure dynamic array lengths are selected from
+// the lengths observed for arrays of the same type, and that the amount of lengths stored for each type is bounded.
+func TestMutatingValueGeneratorGenerateArrayOfLengthForType(t *testing.T) {
+	uint256SliceType, err := abi.NewType("uint256[]", "", nil)
+	assert.NoError(t, err)
+	addressSliceType, err := abi.NewType("address[]", "", nil)
+	assert.NoError(t, err)
+
+	// Add the same lengths repeatedly, and verify they are stored once each.
+	valueSet := NewValueSet()
+	for i := 0; i < 3; i++ {
+		valueSet.AddArrayLength(&uint256SliceType, 7)
+		valueSet.AddArrayLength(&uint256SliceType, 13)
+	}
+	assert.EqualValues(t, []int{7, 13}, valueSet.ArrayLengths(&uint256SliceType))
+
+	// Lengths are only selected for the type they were observed for, and every observed length is selected.
+	generator := NewMutatingValueGenerator(&MutatingValueGeneratorConfig{
+		GenerateRandomArrayLengthBias: 0,
+		RandomValueGeneratorConfig: &RandomValueGeneratorConfig{
+			GenerateRandomArrayMinSize: 0,
+			GenerateRandomArrayMaxSize: 5,
+		},
+	}, valueSet, rand.New(rand.NewSource(1)))
+	selected := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		length := generator.GenerateArrayOfLengthForType(&uint256SliceType)
+		assert.Contains(t, []int{7, 13}, length)
+		selected[length] = true
+		assert.LessOrEqual(t, generator.GenerateArrayOfLengthForType(&addressSliceType), 5)
+	}
+	assert.Len(t, selected, 2)
+
+	// The amount of lengths stored for a type is bounded.
+	for length := 0; length < maxArrayLengthsPerType*2; length++ {
+		valueSet.AddArrayLength(&uint256SliceType, length)
+	}
+	assert.Len(t, valueSet.ArrayLengths(&uint256SliceType), maxArrayLengthsPerType)
+}
@@ -2,6 +2,8 @@ package valuegeneration
 
 import (
 	"github.com/crytic/medusa/utils"
+	"github.com/crytic/medusa/utils/randomutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"math/big"
 	"math/rand"
@@ -10,6 +12,8 @@ import (
 // RandomValueGenerator represents an interface for a provider used to generate transaction fields and call arguments
 // using a random provider. As such it may not be accurate in many test results with tightly-bound pre-conditions.
 // This provider does not mutate existing values and will leave them unaltered.
+// It is not safe for concurrent use, as its random provider is not. Each FuzzerWorker owns its own generator, and
+// a SynchronizedValueGenerator should be used to share one between goroutines.
 type RandomValueGenerator struct {
 	// config describes the configuration defining value generation parameters.
 	config *RandomValueGeneratorConfig
@@ -32,6 +36,29 @@ type RandomValueGeneratorConfig struct {
 	GenerateRandomStringMinSize int
 	// GenerateRandomStringMaxSize defines the maximum size which a generated string should be.
 	GenerateRandomStringMaxSize int
+	// GenerateBoundaryLengthProbability defines the probability that a generated byte slice or string has either the
+	// minimum or maximum size, rather than a uniformly random size. Empty and maximum-length values are frequent edge
+	// cases which are rarely produced by uniform sampling. Value range is [0.0, 1.0].
+	GenerateBoundaryLengthProbability float32
+	// GenerateScaledIntegerProbability defines the probability that an integer of at least 64 bits generated for an ABI
+	// value is a multiple of one of the ScaleConstants, rather than uniformly random. Value range is [0.0, 1.0].
+	GenerateScaledIntegerProbability float32
+	// ScaleConstants defines the constants (e.g. 1e18 for ether-denominated amounts) which scaled integers are generated
+	// as multiples of.
+	ScaleConstants []*big.Int
+
+	// StrategyChooser defines an optional chooser used by GenerateAbiValue to select a ValueGenerationStrategy for
+	// each leaf value it generates. If nil, leaf values are generated by the value generator itself.
+	StrategyChooser *randomutils.WeightedRandomChooser[ValueGenerationStrategy]
+
+	// TupleFixups defines optional functions which adjust the fields of generated or mutated tuples for consistency,
+	// keyed by the tuple's abi.Type.TupleRawName. go-ethereum derives the raw name from the struct's internal type name
+	// without separators (e.g. "AuctionHouseAuction" for the struct "AuctionHouse.Auction").
+	TupleFixups map[string]TupleFixupFunc
+
+	// GenerationStats defines optional statistics which GenerateAbiValue records the values it generates with this
+	// generator in. If nil, statistics are not collected.
+	GenerationStats *AbiValueGenerationStats
 }
 
 // NewRandomValueGenerator creates a new RandomValueGenerator with a new random provider.
@@ -44,6 +71,26 @@ func NewRandomValueGenerator(config *RandomValueGeneratorConfig, randomProvider
 	return generator
 }
 
+// StrategyChooser returns the chooser used to select a ValueGenerationStrategy for leaf values generated by
+// GenerateAbiValue, or nil if none was configured.
+func (g *RandomValueGenerator) StrategyChooser() *randomutils.WeightedRandomChooser[ValueGenerationStrategy] {
+	return g.config.StrategyChooser
+}
+
+// GenerationStats returns the statistics which values generated by GenerateAbiValue are recorded in, or nil if none
+// were configured.
+func (g *RandomValueGenerator) GenerationStats() *AbiValueGenerationStats {
+	return g.config.GenerationStats
+}
+
+// TupleFixup returns the TupleFixupFunc configured for tuples of the provided type, or nil if there is none.
+func (g *RandomValueGenerator) TupleFixup(tupleType *abi.Type) TupleFixupFunc {
+	if tupleType.TupleRawName == "" {
+		return nil
+	}
+	return g.config.TupleFixups[tupleType.TupleRawName]
+}
+
 // RandomProvider returns the internal random provider used for value generation.
 func (g *RandomValueGenerator) RandomProvider() *rand.Rand {
 	return g.randomProvider
@@ -89,10 +136,23 @@ func (g *RandomValueGenerator) MutateBool(bl bool) bool {
 	return bl
 }
 
+// generateLength generates a random length in the provided range to use for a dynamic-sized byte array or string.
+// With the configured boundary length probability, the minimum or maximum length is returned instead. If the
+// probability is zero, no additional random data is consumed, so generated values are unaffected by the feature.
+func (g *RandomValueGenerator) generateLength(minLength int, maxLength int) int {
+	if g.config.GenerateBoundaryLengthProbability > 0 && g.randomProvider.Float32() < g.config.GenerateBoundaryLengthProbability {
+		if g.randomProvider.Uint32()%2 == 0 {
+			return minLength
+		}
+		return maxLength
+	}
+	rangeSize := uint64(maxLength-minLength) + 1
+	return int(g.randomProvider.Uint64()%rangeSize) + minLength
+}
+
 // GenerateBytes generates a random dynamic-sized byte array to use when populating inputs.
 func (g *RandomValueGenerator) GenerateBytes() []byte {
-	rangeSize := uint64(g.config.GenerateRandomBytesMaxSize-g.config.GenerateRandomBytesMinSize) + 1
-	b := make([]byte, int(g.randomProvider.Uint64()%rangeSize)+g.config.GenerateRandomBytesMinSize)
+	b := make([]byte, g.generateLength(g.config.GenerateRandomBytesMinSize, g.config.GenerateRandomBytesMaxSize))
 	g.randomProvider.Read(b)
 	return b
 }
@@ -118,8 +178,7 @@ func (g *RandomValueGenerator) MutateFixedBytes(b []byte) []byte {
 
 // GenerateString generates a random dynamic-sized string to use when populating inputs.
 func (g *RandomValueGenerator) GenerateString() string {
-	rangeSize := uint64(g.config.GenerateRandomStringMaxSize-g.config.GenerateRandomStringMinSize) + 1
-	b := make([]byte, int(g.randomProvider.Uint64()%rangeSize)+g.config.GenerateRandomStringMinSize)
+	b := make([]byte, g.generateLength(g.config.GenerateRandomStringMinSize, g.config.GenerateRandomStringMaxSize))
 	g.randomProvider.Read(b)
 	return string(b)
 }
@@ -130,6 +189,38 @@ func (g *RandomValueGenerator) MutateString(s string) string {
 	return s
 }
 
+// maxScaledIntegerMultiplier describes the maximum multiple of a scale constant which a scaled integer is generated as.
+const maxScaledIntegerMultiplier = 100
+
+// GenerateScaledInteger generates a random multiple of one of the configured scale constants, between one and
+// maxScaledIntegerMultiplier times the constant, with the configured scaled integer probability. It is used when
+// generating ABI values, so integers generated for other purposes (e.g. array lengths) are unaffected. If the
+// probability is zero, no random data is consumed.
+// Returns the generated integer and a boolean indicating whether one was generated. An integer is not generated if it
+// is smaller than 64 bits, no scale constants are configured, or the selected multiple would not fit.
+func (g *RandomValueGenerator) GenerateScaledInteger(signed bool, bitLength int) (*big.Int, bool) {
+	if bitLength < 64 || len(g.config.ScaleConstants) == 0 || g.config.GenerateScaledIntegerProbability <= 0 {
+		return nil, false
+	}
+	if g.randomProvider.Float32() >= g.config.GenerateScaledIntegerProbability {
+		return nil, false
+	}
+
+	scaleConstant := g.config.ScaleConstants[g.randomProvider.Intn(len(g.config.ScaleConstants))]
+	multiplier := big.NewInt(int64(g.randomProvider.Intn(maxScaledIntegerMultiplier) + 1))
+	res := new(big.Int).Mul(scaleConstant, multiplier)
+
+	// Verify the value fits, accounting for the sign bit of signed integers.
+	maxBitLength := bitLength
+	if signed {
+		maxBitLength--
+	}
+	if res.BitLen() > maxBitLength {
+		return nil, false
+	}
+	return res, true
+}
+
 // GenerateInteger generates a random integer to use when populating inputs.
 func (g *RandomValueGenerator) GenerateInteger(signed bool, bitLength int) *big.Int {
 	// Fill a byte array of the appropriate size with random bytes
@@ -0,0 +1,115 @@
+package valuegeneration
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRandomValueGeneratorBoundaryLengths runs tests to ensure byte arrays and strings are generated with the minimum
+// or maximum length according to GenerateBoundaryLengthProbability.
+func TestRandomValueGeneratorBoundaryLengths(t *testing.T) {
+	const iterations = 1000
+	const minLength = 3
+	const maxLength = 50
+	newGenerator := func(probability float32) *RandomValueGenerator {
+		return NewRandomValueGenerator(&RandomValueGeneratorConfig{
+			GenerateRandomBytesMinSize:        minLength,
+			GenerateRandomBytesMaxSize:        maxLength,
+			GenerateRandomStringMinSize:       minLength,
+			GenerateRandomStringMaxSize:       maxLength,
+			GenerateBoundaryLengthProbability: probability,
+		}, rand.New(rand.NewSource(1)))
+	}
+
+	// Determine how often boundary lengths are generated for each probability.
+	tests := []struct {
+		probability      float32
+		minBoundaryCount int
+		maxBoundaryCount int
+	}{
+		{probability: 0, minBoundaryCount: 0, maxBoundaryCount: iterations / 10},
+		{probability: 0.5, minBoundaryCount: iterations * 4 / 10, maxBoundaryCount: iterations * 6 / 10},
+		{probability: 1, minBoundaryCount: iterations, maxBoundaryCount: iterations},
+	}
+	for _, test := range tests {
+		generator := newGenerator(test.probability)
+		boundaryCounts := make(map[int]int)
+		bytesBoundaryCount, stringBoundaryCount := 0, 0
+		for i := 0; i < iterations; i++ {
+			b := generator.GenerateBytes()
+			s := generator.GenerateString()
+			assert.GreaterOrEqual(t, len(b), minLength)
+			assert.LessOrEqual(t, len(b), maxLength)
+			assert.GreaterOrEqual(t, len(s), minLength)
+			assert.LessOrEqual(t, len(s), maxLength)
+			if len(b) == minLength || len(b) == maxLength {
+				bytesBoundaryCount++
+				boundaryCounts[len(b)]++
+			}
+			if len(s) == minLength || len(s) == maxLength {
+				stringBoundaryCount++
+			}
+		}
+		for _, count := range []int{bytesBoundaryCount, stringBoundaryCount} {
+			assert.GreaterOrEqual(t, count, test.minBoundaryCount)
+			assert.LessOrEqual(t, count, test.maxBoundaryCount)
+		}
+		if test.probability == 1 {
+			assert.Len(t, boundaryCounts, 2)
+		}
+	}
+}
+
+// TestRandomValueGeneratorScaledIntegers runs tests to ensure integer ABI values of at least 64 bits are generated as
+// multiples of the configured scale constants according to GenerateScaledIntegerProbability, while integers generated
+// directly by the value generator are never scaled.
+func TestRandomValueGeneratorScaledIntegers(t *testing.T) {
+	const iterations = 1000
+	scaleConstant := big.NewInt(1000)
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	assert.NoError(t, err)
+	int32Type, err := abi.NewType("int32", "", nil)
+	assert.NoError(t, err)
+	isScaled := func(value *big.Int) bool {
+		return value.Sign() > 0 && new(big.Int).Mod(value, scaleConstant).Sign() == 0 &&
+			value.Cmp(new(big.Int).Mul(scaleConstant, big.NewInt(maxScaledIntegerMultiplier))) <= 0
+	}
+
+	// Determine how often scaled integers are generated for each probability.
+	tests := []struct {
+		probability    float32
+		minScaledCount int
+		maxScaledCount int
+	}{
+		{probability: 0, minScaledCount: 0, maxScaledCount: 0},
+		{probability: 0.5, minScaledCount: iterations * 4 / 10, maxScaledCount: iterations * 6 / 10},
+		{probability: 1, minScaledCount: iterations, maxScaledCount: iterations},
+	}
+	for _, test := range tests {
+		generator := NewRandomValueGenerator(&RandomValueGeneratorConfig{
+			GenerateScaledIntegerProbability: test.probability,
+			ScaleConstants:                   []*big.Int{scaleConstant},
+		}, rand.New(rand.NewSource(1)))
+		scaledCount := 0
+		for i := 0; i < iterations; i++ {
+			value, err := GenerateAbiValue(generator, &uint256Type)
+			assert.NoError(t, err)
+			if isScaled(value.(*big.Int)) {
+				scaledCount++
+			}
+
+			// Integers which are too small to hold scaled amounts, or which are generated directly rather than as ABI
+			// values, are never scaled.
+			smallValue, err := GenerateAbiValue(generator, &int32Type)
+			assert.NoError(t, err)
+			assert.False(t, isScaled(big.NewInt(int64(smallValue.(int32)))))
+			assert.False(t, isScaled(generator.GenerateInteger(false, 256)))
+		}
+		assert.GreaterOrEqual(t, scaledCount, test.minScaledCount)
+		assert.LessOrEqual(t, scaledCount, test.maxScaledCount)
+	}
+}
@@ -0,0 +1,285 @@
+package valuegeneration
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+
+	"github.com/crytic/medusa/utils/randomutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SynchronizedValueGenerator wraps a ValueGenerator, offering thread-safety by serializing every call to it.
+// ValueGenerator implementations such as RandomValueGenerator and MutatingValueGenerator use a rand.Rand which is not
+// safe for concurrent use, and are intended to be owned by a single FuzzerWorker. This wrapper should be used if a
+// ValueGenerator must be shared between goroutines instead.
+// If the underlying ValueGenerator is a ValueGenerationStrategyProvider, its strategies are selected and invoked with
+// the underlying ValueGenerator while holding the lock, as ValueGenerationStrategy implementations may use its random
+// provider directly.
+type SynchronizedValueGenerator struct {
+	// generator describes the underlying ValueGenerator which calls are forwarded to.
+	generator ValueGenerator
+
+	// generatorLock provides thread-synchronization to avoid race conditions when calling the underlying generator.
+	generatorLock sync.Mutex
+
+	// randomProvider offers a source of random data which is safe for concurrent use, derived from the random
+	// provider of the underlying generator.
+	randomProvider *rand.Rand
+
+	// strategyChooser selects a synchronizedValueGenerationStrategy which forwards leaf value generation to the
+	// strategies of the underlying generator, or nil if the underlying generator provides no strategies.
+	strategyChooser *randomutils.WeightedRandomChooser[ValueGenerationStrategy]
+}
+
+// NewSynchronizedValueGenerator creates a new SynchronizedValueGenerator which serializes calls to the provided
+// ValueGenerator.
+func NewSynchronizedValueGenerator(generator ValueGenerator) *SynchronizedValueGenerator {
+	// Derive a new random provider from the generator's, backed by a source which is safe for concurrent use.
+	seed := generator.RandomProvider().Int63()
+	g := &SynchronizedValueGenerator{
+		generator:      generator,
+		randomProvider: rand.New(&synchronizedRandomSource{source: rand.NewSource(seed).(rand.Source64)}),
+	}
+
+	// If the underlying generator selects strategies for leaf values, do the same through a single strategy which
+	// selects and invokes them while holding our lock.
+	if strategyProvider, ok := generator.(ValueGenerationStrategyProvider); ok {
+		if strategyChooser := strategyProvider.StrategyChooser(); strategyChooser != nil && strategyChooser.ChoiceCount() > 0 {
+			g.strategyChooser = randomutils.NewWeightedRandomChooserWithRand[ValueGenerationStrategy](g.randomProvider, &sync.Mutex{})
+			g.strategyChooser.AddChoices(randomutils.NewWeightedRandomChoice[ValueGenerationStrategy](&synchronizedValueGenerationStrategy{generator: g}, big.NewInt(1)))
+		}
+	}
+	return g
+}
+
+// StrategyChooser returns a chooser which selects a ValueGenerationStrategy forwarding leaf value generation to the
+// strategies of the underlying generator, or nil if the underlying generator provides no strategies.
+func (g *SynchronizedValueGenerator) StrategyChooser() *randomutils.WeightedRandomChooser[ValueGenerationStrategy] {
+	return g.strategyChooser
+}
+
+// GenerationStats returns the statistics of the underlying generator, which values generated by GenerateAbiValue are
+// recorded in, or nil if it does not collect them. The statistics synchronize their own access, so no lock is held.
+func (g *SynchronizedValueGenerator) GenerationStats() *AbiValueGenerationStats {
+	if statsProvider, ok := g.generator.(AbiValueGenerationStatsProvider); ok {
+		return statsProvider.GenerationStats()
+	}
+	return nil
+}
+
+// RandomProvider returns a random provider derived from the underlying generator's, whose source is safe for
+// concurrent use. The rand.Rand.Read method keeps state outside its source, so it should not be called concurrently.
+func (g *SynchronizedValueGenerator) RandomProvider() *rand.Rand {
+	return g.randomProvider
+}
+
+// GenerateAddress generates/selects an address to use when populating inputs.
+func (g *SynchronizedValueGenerator) GenerateAddress() common.Address {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.GenerateAddress()
+}
+
+// MutateAddress takes an address input and returns a mutated value based off the input.
+func (g *SynchronizedValueGenerator) MutateAddress(addr common.Address) common.Address {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.MutateAddress(addr)
+}
+
+// GenerateArrayOfLength generates/selects an array length to use when populating inputs.
+func (g *SynchronizedValueGenerator) GenerateArrayOfLength() int {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.GenerateArrayOfLength()
+}
+
+// GenerateArrayOfLengthForType generates/selects an array length to use when populating a dynamic array of the
+// provided type, using the underlying generator's TypedArrayLengthGenerator implementation if it has one.
+func (g *SynchronizedValueGenerator) GenerateArrayOfLengthForType(inputType *abi.Type) int {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	if typedGenerator, ok := g.generator.(TypedArrayLengthGenerator); ok {
+		return typedGenerator.GenerateArrayOfLengthForType(inputType)
+	}
+	return g.generator.GenerateArrayOfLength()
+}
+
+// TupleFixup returns the TupleFixupFunc to apply to tuples of the provided type, using the underlying generator's
+// TupleFixupProvider implementation if it has one.
+func (g *SynchronizedValueGenerator) TupleFixup(tupleType *abi.Type) TupleFixupFunc {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	if fixupProvider, ok := g.generator.(TupleFixupProvider); ok {
+		return fixupProvider.TupleFixup(tupleType)
+	}
+	return nil
+}
+
+// MutateArray takes a dynamic or fixed sized array as input, and returns a mutated value based off of the input.
+func (g *SynchronizedValueGenerator) MutateArray(value []any, fixedLength bool) []any {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.MutateArray(value, fixedLength)
+}
+
+// GenerateBool generates/selects a bool to use when populating inputs.
+func (g *SynchronizedValueGenerator) GenerateBool() bool {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.GenerateBool()
+}
+
+// MutateBool takes a boolean input and returns a mutated value based off the input.
+func (g *SynchronizedValueGenerator) MutateBool(bl bool) bool {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.MutateBool(bl)
+}
+
+// GenerateBytes generates/selects a dynamic-sized byte array to use when populating inputs.
+func (g *SynchronizedValueGenerator) GenerateBytes() []byte {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.GenerateBytes()
+}
+
+// MutateBytes takes a dynamic-sized byte array input and returns a mutated value based off the input.
+func (g *SynchronizedValueGenerator) MutateBytes(b []byte) []byte {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.MutateBytes(b)
+}
+
+// GenerateFixedBytes generates/selects a fixed-sized byte array to use when populating inputs.
+func (g *SynchronizedValueGenerator) GenerateFixedBytes(length int) []byte {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.GenerateFixedBytes(length)
+}
+
+// MutateFixedBytes takes a fixed-sized byte array input and returns a mutated value based off the input.
+func (g *SynchronizedValueGenerator) MutateFixedBytes(b []byte) []byte {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.MutateFixedBytes(b)
+}
+
+// GenerateString generates/selects a dynamic-sized string to use when populating inputs.
+func (g *SynchronizedValueGenerator) GenerateString() string {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.GenerateString()
+}
+
+// MutateString takes a string input and returns a mutated value based off the input.
+func (g *SynchronizedValueGenerator) MutateString(s string) string {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.MutateString(s)
+}
+
+// GenerateInteger generates/selects an integer to use when populating inputs.
+func (g *SynchronizedValueGenerator) GenerateInteger(signed bool, bitLength int) *big.Int {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.GenerateInteger(signed, bitLength)
+}
+
+// GenerateScaledInteger generates a multiple of a scale constant to use when populating inputs, if the underlying
+// generator supports it. Returns the generated integer and a boolean indicating whether one was generated.
+func (g *SynchronizedValueGenerator) GenerateScaledInteger(signed bool, bitLength int) (*big.Int, bool) {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	if scaledIntegerGenerator, ok := g.generator.(ScaledIntegerGenerator); ok {
+		return scaledIntegerGenerator.GenerateScaledInteger(signed, bitLength)
+	}
+	return nil, false
+}
+
+// MutateInteger takes an integer input and returns a mutated value based off the input.
+func (g *SynchronizedValueGenerator) MutateInteger(i *big.Int, signed bool, bitLength int) *big.Int {
+	g.generatorLock.Lock()
+	defer g.generatorLock.Unlock()
+	return g.generator.MutateInteger(i, signed, bitLength)
+}
+
+// synchronizedValueGenerationStrategy is a ValueGenerationStrategy which selects a strategy of the underlying generator
+// of a SynchronizedValueGenerator, and invokes it with the underlying generator while holding its lock.
+type synchronizedValueGenerationStrategy struct {
+	// generator describes the SynchronizedValueGenerator whose underlying generator's strategies are used.
+	generator *SynchronizedValueGenerator
+}
+
+// GenerateAddress generates an address using a strategy of the underlying generator.
+func (s *synchronizedValueGenerationStrategy) GenerateAddress(_ ValueGenerator) (common.Address, bool) {
+	s.generator.generatorLock.Lock()
+	defer s.generator.generatorLock.Unlock()
+	if strategy := chooseStrategy(s.generator.generator); strategy != nil {
+		return strategy.GenerateAddress(s.generator.generator)
+	}
+	return common.Address{}, false
+}
+
+// GenerateInteger generates an integer of the provided signedness and bit length using a strategy of the underlying
+// generator.
+func (s *synchronizedValueGenerationStrategy) GenerateInteger(_ ValueGenerator, signed bool, bitLength int) (*big.Int, bool) {
+	s.generator.generatorLock.Lock()
+	defer s.generator.generatorLock.Unlock()
+	if strategy := chooseStrategy(s.generator.generator); strategy != nil {
+		return strategy.GenerateInteger(s.generator.generator, signed, bitLength)
+	}
+	return nil, false
+}
+
+// GenerateBytes generates a dynamic-sized byte array using a strategy of the underlying generator.
+func (s *synchronizedValueGenerationStrategy) GenerateBytes(_ ValueGenerator) ([]byte, bool) {
+	s.generator.generatorLock.Lock()
+	defer s.generator.generatorLock.Unlock()
+	if strategy := chooseStrategy(s.generator.generator); strategy != nil {
+		return strategy.GenerateBytes(s.generator.generator)
+	}
+	return nil, false
+}
+
+// GenerateString generates a string using a strategy of the underlying generator.
+func (s *synchronizedValueGenerationStrategy) GenerateString(_ ValueGenerator) (string, bool) {
+	s.generator.generatorLock.Lock()
+	defer s.generator.generatorLock.Unlock()
+	if strategy := chooseStrategy(s.generator.generator); strategy != nil {
+		return strategy.GenerateString(s.generator.generator)
+	}
+	return "", false
+}
+
+// synchronizedRandomSource is a rand.Source64 which serializes calls to an underlying source, so it is safe for
+// concurrent use.
+type synchronizedRandomSource struct {
+	// source describes the underlying source of random data.
+	source rand.Source64
+	// sourceLock provides thread-synchronization to avoid race conditions when accessing the source.
+	sourceLock sync.Mutex
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (s *synchronizedRandomSource) Int63() int64 {
+	s.sourceLock.Lock()
+	defer s.sourceLock.Unlock()
+	return s.source.Int63()
+}
+
+// Uint64 returns a pseudo-random 64-bit integer.
+func (s *synchronizedRandomSource) Uint64() uint64 {
+	s.sourceLock.Lock()
+	defer s.sourceLock.Unlock()
+	return s.source.Uint64()
+}
+
+// Seed uses the provided seed value to initialize the source to a deterministic state.
+func (s *synchronizedRandomSource) Seed(seed int64) {
+	s.sourceLock.Lock()
+	defer s.sourceLock.Unlock()
+	s.source.Seed(seed)
+}
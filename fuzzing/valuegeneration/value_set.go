@@ -4,22 +4,48 @@ import (
 	"encoding/hex"
 	"hash"
 	"math/big"
+	"math/rand"
+	"reflect"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"golang.org/x/crypto/sha3"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
-// ValueSet represents potential values of significance within the source code to be used in fuzz tests.
+// ValueSet represents potential values of significance within the source code to be used in fuzz tests. Values are
+// returned by its accessors in the order they were added, so that selection from them is deterministic.
 type ValueSet struct {
 	// addresses represents a set of common.Address to use in fuzz tests. A mapping is used to avoid duplicates.
 	addresses map[common.Address]any
+	// addressKeys represents the keys of addresses, in the order they were added.
+	addressKeys []common.Address
 	// integers represents a set of integers to use in fuzz tests. A mapping is used to avoid duplicates.
 	integers map[string]*big.Int
+	// integerKeys represents the keys of integers, in the order they were added.
+	integerKeys []string
 	// strings represents a set of strings to use in fuzz tests. A mapping is used to avoid duplicates.
 	strings map[string]any
+	// stringKeys represents the keys of strings, in the order they were added.
+	stringKeys []string
 	// bytes represents a set of bytes to use in fuzz tests. A mapping is used to avoid duplicates.
 	bytes map[string][]byte
+	// bytesKeys represents the keys of bytes, in the order they were added.
+	bytesKeys []string
+	// fixedBytes represents a set of fixed-sized byte arrays (e.g. method selectors and event IDs) to use in fuzz
+	// tests. A mapping is used to avoid duplicates.
+	fixedBytes map[string][]byte
+	// fixedBytesKeys represents the keys of fixedBytes, in the order they were added.
+	fixedBytesKeys []string
+	// fixedBytesKeysByLength represents the keys of fixedBytes indexed by the length of their byte array, in the order
+	// they were added, so values of a given length can be selected without scanning every fixed-sized byte array.
+	fixedBytesKeysByLength map[int][]string
+	// arrayLengths represents the dynamic array lengths observed to be interesting, keyed by the string
+	// representation of the array's abi.Type. Lengths are stored in the order they were added, without duplicates, and
+	// at most maxArrayLengthsPerType are stored for each type.
+	arrayLengths map[string][]int
 	// hashProvider represents a hash provider used to create keys for some data.
 	hashProvider hash.Hash
 }
@@ -27,11 +53,19 @@ type ValueSet struct {
 // NewValueSet initializes a new ValueSet object for use with a Fuzzer.
 func NewValueSet() *ValueSet {
 	baseValueSet := &ValueSet{
-		addresses:    make(map[common.Address]any, 0),
-		integers:     make(map[string]*big.Int, 0),
-		strings:      make(map[string]any, 0),
-		bytes:        make(map[string][]byte, 0),
-		hashProvider: sha3.NewLegacyKeccak256(),
+		addresses:              make(map[common.Address]any, 0),
+		addressKeys:            make([]common.Address, 0),
+		integers:               make(map[string]*big.Int, 0),
+		integerKeys:            make([]string, 0),
+		strings:                make(map[string]any, 0),
+		stringKeys:             make([]string, 0),
+		bytes:                  make(map[string][]byte, 0),
+		bytesKeys:              make([]string, 0),
+		fixedBytes:             make(map[string][]byte, 0),
+		fixedBytesKeys:         make([]string, 0),
+		fixedBytesKeysByLength: make(map[int][]string, 0),
+		arrayLengths:           make(map[string][]int, 0),
+		hashProvider:           sha3.NewLegacyKeccak256(),
 	}
 	return baseValueSet
 }
@@ -39,89 +73,151 @@ func NewValueSet() *ValueSet {
 // Clone creates a copy of the current ValueSet.
 func (vs *ValueSet) Clone() *ValueSet {
 	baseValueSet := &ValueSet{
-		addresses:    maps.Clone(vs.addresses),
-		integers:     maps.Clone(vs.integers),
-		strings:      maps.Clone(vs.strings),
-		bytes:        maps.Clone(vs.bytes),
-		hashProvider: sha3.NewLegacyKeccak256(),
+		addresses:              maps.Clone(vs.addresses),
+		addressKeys:            slices.Clone(vs.addressKeys),
+		integers:               maps.Clone(vs.integers),
+		integerKeys:            slices.Clone(vs.integerKeys),
+		strings:                maps.Clone(vs.strings),
+		stringKeys:             slices.Clone(vs.stringKeys),
+		bytes:                  maps.Clone(vs.bytes),
+		bytesKeys:              slices.Clone(vs.bytesKeys),
+		fixedBytes:             maps.Clone(vs.fixedBytes),
+		fixedBytesKeys:         slices.Clone(vs.fixedBytesKeys),
+		fixedBytesKeysByLength: make(map[int][]string, len(vs.fixedBytesKeysByLength)),
+		arrayLengths:           maps.Clone(vs.arrayLengths),
+		hashProvider:           sha3.NewLegacyKeccak256(),
+	}
+
+	// The keys indexed by length are removed from in place, so each slice is cloned rather than shared.
+	for length, keys := range vs.fixedBytesKeysByLength {
+		baseValueSet.fixedBytesKeysByLength[length] = slices.Clone(keys)
 	}
 	return baseValueSet
 }
 
-// Addresses returns a list of addresses contained within the set.
+// Counts returns the amount of integers, strings, bytes, and addresses contained within the set.
+func (vs *ValueSet) Counts() (integers, strings, bytes, addresses int) {
+	return len(vs.integers), len(vs.strings), len(vs.bytes), len(vs.addresses)
+}
+
+// Addresses returns a list of addresses contained within the set, in the order they were added.
 func (vs *ValueSet) Addresses() []common.Address {
-	res := make([]common.Address, len(vs.addresses))
-	count := 0
-	for k := range vs.addresses {
-		res[count] = k
-		count++
+	return slices.Clone(vs.addressKeys)
+}
+
+// RandomAddress selects a random address contained within the set using the provided random provider, without
+// copying the set.
+// Returns the address, or false if the set contains no addresses.
+func (vs *ValueSet) RandomAddress(randomProvider *rand.Rand) (common.Address, bool) {
+	if len(vs.addressKeys) == 0 {
+		return common.Address{}, false
 	}
-	return res
+	return vs.addressKeys[randomProvider.Intn(len(vs.addressKeys))], true
 }
 
 // AddAddress adds an address item to the ValueSet.
 func (vs *ValueSet) AddAddress(a common.Address) {
+	if _, exists := vs.addresses[a]; !exists {
+		vs.addressKeys = append(vs.addressKeys, a)
+	}
 	vs.addresses[a] = nil
 }
 
 // RemoveAddress removes an address item from the ValueSet.
 func (vs *ValueSet) RemoveAddress(a common.Address) {
+	if _, exists := vs.addresses[a]; exists {
+		vs.addressKeys = removeOrderedKey(vs.addressKeys, a)
+	}
 	delete(vs.addresses, a)
 }
 
-// Integers returns a list of integers contained within the set.
+// Integers returns a list of integers contained within the set, in the order they were added.
 func (vs *ValueSet) Integers() []*big.Int {
-	res := make([]*big.Int, len(vs.integers))
-	count := 0
-	for _, v := range vs.integers {
-		res[count] = v
-		count++
+	res := make([]*big.Int, len(vs.integerKeys))
+	for i, k := range vs.integerKeys {
+		res[i] = vs.integers[k]
 	}
 	return res
 }
 
+// RandomInteger selects a random integer contained within the set using the provided random provider, without
+// copying the set. The returned integer is owned by the set and must not be modified.
+// Returns the integer, or false if the set contains no integers.
+func (vs *ValueSet) RandomInteger(randomProvider *rand.Rand) (*big.Int, bool) {
+	if len(vs.integerKeys) == 0 {
+		return nil, false
+	}
+	return vs.integers[vs.integerKeys[randomProvider.Intn(len(vs.integerKeys))]], true
+}
+
 // AddInteger adds an integer item to the ValueSet.
 func (vs *ValueSet) AddInteger(b *big.Int) {
-	vs.integers[b.String()] = b
+	key := b.String()
+	if _, exists := vs.integers[key]; !exists {
+		vs.integerKeys = append(vs.integerKeys, key)
+	}
+	vs.integers[key] = b
 }
 
 // RemoveInteger removes an integer item from the ValueSet.
 func (vs *ValueSet) RemoveInteger(b *big.Int) {
-	delete(vs.integers, b.String())
+	key := b.String()
+	if _, exists := vs.integers[key]; exists {
+		vs.integerKeys = removeOrderedKey(vs.integerKeys, key)
+	}
+	delete(vs.integers, key)
 }
 
-// Strings returns a list of strings contained within the set.
+// Strings returns a list of strings contained within the set, in the order they were added.
 func (vs *ValueSet) Strings() []string {
-	res := make([]string, len(vs.strings))
-	count := 0
-	for k := range vs.strings {
-		res[count] = k
-		count++
+	return slices.Clone(vs.stringKeys)
+}
+
+// RandomString selects a random string contained within the set using the provided random provider, without
+// copying the set.
+// Returns the string, or false if the set contains no strings.
+func (vs *ValueSet) RandomString(randomProvider *rand.Rand) (string, bool) {
+	if len(vs.stringKeys) == 0 {
+		return "", false
 	}
-	return res
+	return vs.stringKeys[randomProvider.Intn(len(vs.stringKeys))], true
 }
 
 // AddString adds a string item to the ValueSet.
 func (vs *ValueSet) AddString(s string) {
+	if _, exists := vs.strings[s]; !exists {
+		vs.stringKeys = append(vs.stringKeys, s)
+	}
 	vs.strings[s] = nil
 }
 
 // RemoveString removes a string item from the ValueSet.
 func (vs *ValueSet) RemoveString(s string) {
+	if _, exists := vs.strings[s]; exists {
+		vs.stringKeys = removeOrderedKey(vs.stringKeys, s)
+	}
 	delete(vs.strings, s)
 }
 
-// Bytes returns a list of bytes contained within the set.
+// Bytes returns a list of bytes contained within the set, in the order they were added.
 func (vs *ValueSet) Bytes() [][]byte {
-	res := make([][]byte, len(vs.bytes))
-	count := 0
-	for _, v := range vs.bytes {
-		res[count] = v
-		count++
+	res := make([][]byte, len(vs.bytesKeys))
+	for i, k := range vs.bytesKeys {
+		res[i] = vs.bytes[k]
 	}
 	return res
 }
 
+// RandomBytes selects a random byte sequence contained within the set using the provided random provider, without
+// copying the set. The returned byte sequence is owned by the set and must not be modified.
+// Returns the byte sequence, or false if the set contains no byte sequences.
+func (vs *ValueSet) RandomBytes(randomProvider *rand.Rand) ([]byte, bool) {
+	if len(vs.bytesKeys) == 0 {
+		return nil, false
+	}
+	return vs.bytes[vs.bytesKeys[randomProvider.Intn(len(vs.bytesKeys))]], true
+}
+
 // AddBytes adds a byte sequence to the ValueSet.
 func (vs *ValueSet) AddBytes(b []byte) {
 	// Calculate hash and reset our hash provider
@@ -130,6 +226,9 @@ func (vs *ValueSet) AddBytes(b []byte) {
 	vs.hashProvider.Reset()
 
 	// Add our hash to our "set" (map)
+	if _, exists := vs.bytes[hashStr]; !exists {
+		vs.bytesKeys = append(vs.bytesKeys, hashStr)
+	}
 	vs.bytes[hashStr] = b
 }
 
@@ -140,5 +239,187 @@ func (vs *ValueSet) RemoveBytes(b []byte) {
 	hashStr := hex.EncodeToString(vs.hashProvider.Sum(nil))
 	vs.hashProvider.Reset()
 
+	if _, exists := vs.bytes[hashStr]; exists {
+		vs.bytesKeys = removeOrderedKey(vs.bytesKeys, hashStr)
+	}
 	delete(vs.bytes, hashStr)
 }
+
+// AllFixedBytes returns a list of fixed-sized byte arrays of any length contained within the set, in the order they
+// were added.
+func (vs *ValueSet) AllFixedBytes() [][]byte {
+	res := make([][]byte, len(vs.fixedBytesKeys))
+	for i, k := range vs.fixedBytesKeys {
+		res[i] = vs.fixedBytes[k]
+	}
+	return res
+}
+
+// FixedBytes returns a list of fixed-sized byte arrays of the provided length contained within the set, in the order
+// they were added.
+func (vs *ValueSet) FixedBytes(length int) [][]byte {
+	keys := vs.fixedBytesKeysByLength[length]
+	res := make([][]byte, len(keys))
+	for i, k := range keys {
+		res[i] = vs.fixedBytes[k]
+	}
+	return res
+}
+
+// RandomFixedBytes selects a random fixed-sized byte array of the provided length contained within the set using the
+// provided random provider, without copying the set. The returned byte array is owned by the set and must not be
+// modified.
+// Returns the byte array, or false if the set contains no byte arrays of the provided length.
+func (vs *ValueSet) RandomFixedBytes(length int, randomProvider *rand.Rand) ([]byte, bool) {
+	keys := vs.fixedBytesKeysByLength[length]
+	if len(keys) == 0 {
+		return nil, false
+	}
+	return vs.fixedBytes[keys[randomProvider.Intn(len(keys))]], true
+}
+
+// AddFixedBytes adds a fixed-sized byte array (e.g. a method selector or event ID) to the ValueSet.
+func (vs *ValueSet) AddFixedBytes(b []byte) {
+	key := hex.EncodeToString(b)
+	if _, exists := vs.fixedBytes[key]; !exists {
+		vs.fixedBytesKeys = append(vs.fixedBytesKeys, key)
+		vs.fixedBytesKeysByLength[len(b)] = append(vs.fixedBytesKeysByLength[len(b)], key)
+	}
+	vs.fixedBytes[key] = b
+}
+
+// RemoveFixedBytes removes a fixed-sized byte array from the ValueSet.
+func (vs *ValueSet) RemoveFixedBytes(b []byte) {
+	key := hex.EncodeToString(b)
+	if _, exists := vs.fixedBytes[key]; exists {
+		vs.fixedBytesKeys = removeOrderedKey(vs.fixedBytesKeys, key)
+		vs.fixedBytesKeysByLength[len(b)] = removeOrderedKey(vs.fixedBytesKeysByLength[len(b)], key)
+		if len(vs.fixedBytesKeysByLength[len(b)]) == 0 {
+			delete(vs.fixedBytesKeysByLength, len(b))
+		}
+	}
+	delete(vs.fixedBytes, key)
+}
+
+// maxArrayLengthsPerType describes the maximum amount of distinct dynamic array lengths stored by a ValueSet for
+// arrays of a given type. Further lengths are not added, so the set does not grow without bound over a campaign.
+const maxArrayLengthsPerType = 64
+
+// ArrayLengths returns a list of dynamic array lengths observed to be interesting for arrays of the provided type, in
+// the order they were added.
+func (vs *ValueSet) ArrayLengths(inputType *abi.Type) []int {
+	return slices.Clone(vs.arrayLengths[inputType.String()])
+}
+
+// RandomArrayLength selects a random dynamic array length observed to be interesting for arrays of the provided type
+// using the provided random provider, without copying the set.
+// Returns the length, or false if no lengths were observed for arrays of the provided type.
+func (vs *ValueSet) RandomArrayLength(inputType *abi.Type, randomProvider *rand.Rand) (int, bool) {
+	lengths := vs.arrayLengths[inputType.String()]
+	if len(lengths) == 0 {
+		return 0, false
+	}
+	return lengths[randomProvider.Intn(len(lengths))], true
+}
+
+// AddArrayLength adds a dynamic array length observed to be interesting for arrays of the provided type to the
+// ValueSet. The length is not added if it is already contained within the set, or if maxArrayLengthsPerType lengths
+// are already stored for the type.
+func (vs *ValueSet) AddArrayLength(inputType *abi.Type, length int) {
+	vs.addArrayLength(inputType.String(), length)
+}
+
+// addArrayLength adds a dynamic array length for arrays of the type with the provided string representation to the
+// ValueSet, as described by AddArrayLength.
+func (vs *ValueSet) addArrayLength(key string, length int) {
+	if len(vs.arrayLengths[key]) < maxArrayLengthsPerType && !slices.Contains(vs.arrayLengths[key], length) {
+		// We append to a clone, as the underlying slice may be shared with a ValueSet this one was cloned from.
+		vs.arrayLengths[key] = append(slices.Clone(vs.arrayLengths[key]), length)
+	}
+}
+
+// AddArrayLengthsFromAbiValue adds the length of every dynamic array within the provided ABI value of the given type
+// to the ValueSet, recursively. This is used to record the array lengths of an interesting call's arguments.
+func (vs *ValueSet) AddArrayLengthsFromAbiValue(inputType *abi.Type, value any) {
+	reflectedValue := reflect.ValueOf(value)
+	switch inputType.T {
+	case abi.SliceTy:
+		if reflectedValue.Kind() != reflect.Slice {
+			return
+		}
+		vs.AddArrayLength(inputType, reflectedValue.Len())
+		for i := 0; i < reflectedValue.Len(); i++ {
+			vs.AddArrayLengthsFromAbiValue(inputType.Elem, reflectedValue.Index(i).Interface())
+		}
+	case abi.ArrayTy:
+		if reflectedValue.Kind() != reflect.Array {
+			return
+		}
+		for i := 0; i < reflectedValue.Len(); i++ {
+			vs.AddArrayLengthsFromAbiValue(inputType.Elem, reflectedValue.Index(i).Interface())
+		}
+	case abi.TupleTy:
+		if reflectedValue.Kind() != reflect.Struct || reflectedValue.NumField() != len(inputType.TupleElems) {
+			return
+		}
+		for i := 0; i < len(inputType.TupleElems); i++ {
+			vs.AddArrayLengthsFromAbiValue(inputType.TupleElems[i], reflectedValue.Field(i).Interface())
+		}
+	}
+}
+
+// removeOrderedKey removes the provided key from a slice of keys tracking insertion order, preserving the order of the
+// remaining keys.
+// Returns the updated slice of keys.
+func removeOrderedKey[K comparable](keys []K, key K) []K {
+	if index := slices.Index(keys, key); index >= 0 {
+		return slices.Delete(keys, index, index+1)
+	}
+	return keys
+}
+
+// AddFromLog adds candidate values observed in an event log to the ValueSet. Each topic and each 32-byte word of the
+// log data is added as an integer, and additionally as an address if it appears to be one (see addFromWord).
+func (vs *ValueSet) AddFromLog(log *types.Log) {
+	for _, topic := range log.Topics {
+		vs.addFromWord(topic)
+	}
+	for i := 0; i+common.HashLength <= len(log.Data); i += common.HashLength {
+		vs.addFromWord(common.BytesToHash(log.Data[i : i+common.HashLength]))
+	}
+}
+
+// AddFromStorageWrite adds candidate values observed in a value written to storage to the ValueSet. The value is
+// added as an integer, and additionally as an address if it appears to be one (see addFromWord).
+func (vs *ValueSet) AddFromStorageWrite(value common.Hash) {
+	vs.addFromWord(value)
+}
+
+// AddFromComparisonOperand adds candidate values observed as an operand of a comparison to the ValueSet. The value is
+// added as with AddFromStorageWrite, and its neighbouring integers are added as well, so strict comparisons against it
+// (e.g. x > y) may be satisfied. Neighbouring integers outside the range of a 256-bit word are not added.
+func (vs *ValueSet) AddFromComparisonOperand(value common.Hash) {
+	vs.addFromWord(value)
+	if previous := new(big.Int).Sub(value.Big(), big.NewInt(1)); previous.Sign() >= 0 {
+		vs.AddInteger(previous)
+	}
+	if next := new(big.Int).Add(value.Big(), big.NewInt(1)); next.BitLen() <= common.HashLength*8 {
+		vs.AddInteger(next)
+	}
+}
+
+// minAddressWordBitLength describes the minimum amount of significant bits a 32-byte word must have to be considered
+// an address by addFromWord. Small integers (e.g. counters or amounts) fit within an address too, but are rarely one.
+const minAddressWordBitLength = 96
+
+// addFromWord adds a 32-byte word to the ValueSet as an integer. If the word has more significant bits than
+// minAddressWordBitLength and its value fits within an address (e.g. it is a left-padded address), it is added as an
+// address as well. Words matching addresses of significance with fewer significant bits (e.g. senders or deployed
+// contracts) are not added, as those are already contained within the set.
+func (vs *ValueSet) addFromWord(word common.Hash) {
+	value := word.Big()
+	vs.AddInteger(value)
+	if bitLength := value.BitLen(); bitLength > minAddressWordBitLength && bitLength <= common.AddressLength*8 {
+		vs.AddAddress(common.BytesToAddress(word.Bytes()))
+	}
+}
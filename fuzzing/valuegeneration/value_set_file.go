@@ -0,0 +1,233 @@
+package valuegeneration
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/crytic/medusa/utils/reflectionutils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"golang.org/x/exp/maps"
+)
+
+// valueSetMarshal is used as an internal struct to represent JSON serialized data for a ValueSet.
+type valueSetMarshal struct {
+	Addresses  []common.Address `json:"addresses"`
+	Integers   []string         `json:"integers"`
+	Strings    []string         `json:"strings"`
+	Bytes      []string         `json:"bytes"`
+	FixedBytes []string         `json:"fixedBytes"`
+}
+
+// AddFromAbiValue adds every address, integer, string, and byte array within the provided ABI value of the given
+// type to the ValueSet, recursively. This is used to record the argument values of interesting calls.
+func (vs *ValueSet) AddFromAbiValue(inputType *abi.Type, value any) {
+	reflectedValue := reflect.ValueOf(value)
+	switch inputType.T {
+	case abi.AddressTy:
+		if addr, ok := value.(common.Address); ok {
+			vs.AddAddress(addr)
+		}
+	case abi.UintTy, abi.IntTy:
+		// Integers may be represented by native integer types or big integers, depending on their size.
+		switch reflectedValue.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			vs.AddInteger(new(big.Int).SetUint64(reflectedValue.Uint()))
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			vs.AddInteger(big.NewInt(reflectedValue.Int()))
+		default:
+			if b, ok := value.(*big.Int); ok && b != nil {
+				vs.AddInteger(new(big.Int).Set(b))
+			}
+		}
+	case abi.StringTy:
+		if str, ok := value.(string); ok {
+			vs.AddString(str)
+		}
+	case abi.BytesTy:
+		if b, ok := value.([]byte); ok {
+			vs.AddBytes(b)
+		}
+	case abi.FixedBytesTy:
+		if reflectedValue.Kind() == reflect.Array {
+			vs.AddFixedBytes(reflectionutils.ArrayToSlice(reflectedValue).([]byte))
+		}
+	case abi.ArrayTy, abi.SliceTy:
+		if reflectedValue.Kind() != reflect.Array && reflectedValue.Kind() != reflect.Slice {
+			return
+		}
+		for i := 0; i < reflectedValue.Len(); i++ {
+			vs.AddFromAbiValue(inputType.Elem, reflectedValue.Index(i).Interface())
+		}
+	case abi.TupleTy:
+		if reflectedValue.Kind() != reflect.Struct || reflectedValue.NumField() != len(inputType.TupleElems) {
+			return
+		}
+		for i := 0; i < len(inputType.TupleElems); i++ {
+			vs.AddFromAbiValue(inputType.TupleElems[i], reflectedValue.Field(i).Interface())
+		}
+	}
+}
+
+// AddValueSet adds every value and array length contained within the provided ValueSet to this one, in the order they
+// were added to it.
+func (vs *ValueSet) AddValueSet(other *ValueSet) {
+	for _, addr := range other.Addresses() {
+		vs.AddAddress(addr)
+	}
+	for _, integer := range other.Integers() {
+		vs.AddInteger(integer)
+	}
+	for _, str := range other.Strings() {
+		vs.AddString(str)
+	}
+	for _, b := range other.Bytes() {
+		vs.AddBytes(b)
+	}
+	for _, b := range other.AllFixedBytes() {
+		vs.AddFixedBytes(b)
+	}
+
+	// Array lengths are keyed by type string, so we add them by key, sorting keys to remain deterministic.
+	arrayTypeKeys := maps.Keys(other.arrayLengths)
+	sort.Strings(arrayTypeKeys)
+	for _, key := range arrayTypeKeys {
+		for _, length := range other.arrayLengths[key] {
+			vs.addArrayLength(key, length)
+		}
+	}
+}
+
+// ReadValueSetFromFile reads a ValueSet previously written with ValueSet.WriteToFile from the provided file path.
+// Returns the ValueSet, or an error if one occurs.
+func ReadValueSetFromFile(path string) (*ValueSet, error) {
+	// Read and parse our value set file data
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var marshalData valueSetMarshal
+	err = json.Unmarshal(b, &marshalData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add every value to a new value set
+	vs := NewValueSet()
+	for _, addr := range marshalData.Addresses {
+		vs.AddAddress(addr)
+	}
+	for _, integerStr := range marshalData.Integers {
+		integer, ok := new(big.Int).SetString(integerStr, 10)
+		if !ok {
+			return nil, fmt.Errorf("could not read value set, invalid integer value: %v", integerStr)
+		}
+		vs.AddInteger(integer)
+	}
+	for _, str := range marshalData.Strings {
+		vs.AddString(str)
+	}
+	for _, bytesStr := range marshalData.Bytes {
+		b, err := hex.DecodeString(bytesStr)
+		if err != nil {
+			return nil, fmt.Errorf("could not read value set, invalid bytes value: %v", bytesStr)
+		}
+		vs.AddBytes(b)
+	}
+	for _, bytesStr := range marshalData.FixedBytes {
+		b, err := hex.DecodeString(bytesStr)
+		if err != nil {
+			return nil, fmt.Errorf("could not read value set, invalid fixed bytes value: %v", bytesStr)
+		}
+		vs.AddFixedBytes(b)
+	}
+	return vs, nil
+}
+
+// WriteToFile writes the values contained within the ValueSet to a provided file path in a JSON-serialized format.
+// Array lengths tracked by the ValueSet are not written.
+// Returns an error if one occurs.
+func (vs *ValueSet) WriteToFile(path string) error {
+	// Create our serializable structure
+	marshalData := valueSetMarshal{
+		Addresses:  vs.Addresses(),
+		Integers:   make([]string, 0),
+		Strings:    vs.Strings(),
+		Bytes:      make([]string, 0),
+		FixedBytes: make([]string, 0),
+	}
+	for _, integer := range vs.Integers() {
+		marshalData.Integers = append(marshalData.Integers, integer.String())
+	}
+	for _, b := range vs.Bytes() {
+		marshalData.Bytes = append(marshalData.Bytes, hex.EncodeToString(b))
+	}
+	for _, b := range vs.AllFixedBytes() {
+		marshalData.FixedBytes = append(marshalData.FixedBytes, hex.EncodeToString(b))
+	}
+
+	// Serialize it and save it to the provided output path
+	b, err := json.MarshalIndent(marshalData, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// aflDictionaryMaxTokenLength describes the maximum length of a token in an AFL dictionary. Longer tokens are rejected
+// by AFL-based fuzzers, so they are not written.
+const aflDictionaryMaxTokenLength = 128
+
+// WriteToAFLDictionaryFile writes the values contained within the ValueSet to a provided file path as an AFL-style
+// dictionary, so they may be shared with AFL-based fuzzers. Each value is written as a `name="value"` line, with
+// non-printable bytes hex-escaped. Addresses are written as their 20 bytes, and integers as their 32-byte big-endian
+// two's complement representation, as they would be encoded in call data. Empty values and values exceeding the
+// maximum AFL token length are not written.
+// Returns an error if one occurs.
+func (vs *ValueSet) WriteToAFLDictionaryFile(path string) error {
+	var builder strings.Builder
+	writeToken := func(name string, index int, value []byte) {
+		if len(value) == 0 || len(value) > aflDictionaryMaxTokenLength {
+			return
+		}
+		builder.WriteString(fmt.Sprintf("%s_%d=\"%s\"\n", name, index, escapeAFLDictionaryToken(value)))
+	}
+
+	for i, addr := range vs.Addresses() {
+		writeToken("address", i, addr.Bytes())
+	}
+	for i, integer := range vs.Integers() {
+		writeToken("integer", i, math.U256Bytes(new(big.Int).Set(integer)))
+	}
+	for i, str := range vs.Strings() {
+		writeToken("string", i, []byte(str))
+	}
+	for i, b := range vs.Bytes() {
+		writeToken("bytes", i, b)
+	}
+	for i, b := range vs.AllFixedBytes() {
+		writeToken("fixed_bytes", i, b)
+	}
+	return os.WriteFile(path, []byte(builder.String()), 0644)
+}
+
+// escapeAFLDictionaryToken escapes the provided value for use as a token in an AFL dictionary. Printable ASCII
+// characters are written as-is, except for quotes and backslashes, while all other bytes are written as "\xNN".
+func escapeAFLDictionaryToken(value []byte) string {
+	var builder strings.Builder
+	for _, b := range value {
+		if b >= 0x20 && b < 0x7f && b != '"' && b != '\\' {
+			builder.WriteByte(b)
+		} else {
+			builder.WriteString(fmt.Sprintf("\\x%02x", b))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,191 @@
+package valuegeneration
+
+import (
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValueSetAddFromStorageWrite runs tests to ensure values written to storage are added to the ValueSet as
+// integers, and additionally as addresses if they appear to be one. Small integers should not be added as addresses.
+func TestValueSetAddFromStorageWrite(t *testing.T) {
+	valueSet := NewValueSet()
+	address := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	largeValue := new(big.Int).Lsh(big.NewInt(1), 200)
+
+	valueSet.AddFromStorageWrite(common.BytesToHash(address.Bytes()))
+	valueSet.AddFromStorageWrite(common.BigToHash(largeValue))
+	for _, smallValue := range []int64{1, 2, 100, 1 << 40} {
+		valueSet.AddFromStorageWrite(common.BigToHash(big.NewInt(smallValue)))
+		assert.Contains(t, valueSet.Integers(), big.NewInt(smallValue))
+	}
+
+	assert.Contains(t, valueSet.Addresses(), address)
+	assert.Len(t, valueSet.Addresses(), 1)
+	assert.Contains(t, valueSet.Integers(), new(big.Int).SetBytes(address.Bytes()))
+	assert.Contains(t, valueSet.Integers(), largeValue)
+}
+
+// TestValueSetAddFromLog runs tests to ensure the topics and 32-byte data words of an event log are added to the
+// ValueSet.
+func TestValueSetAddFromLog(t *testing.T) {
+	valueSet := NewValueSet()
+	address := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	log := &types.Log{
+		Topics: []common.Hash{common.BytesToHash(address.Bytes())},
+		Data:   append(common.BigToHash(big.NewInt(7)).Bytes(), common.BigToHash(big.NewInt(9)).Bytes()...),
+	}
+	valueSet.AddFromLog(log)
+
+	assert.Contains(t, valueSet.Addresses(), address)
+	assert.Contains(t, valueSet.Integers(), big.NewInt(7))
+	assert.Contains(t, valueSet.Integers(), big.NewInt(9))
+}
+
+// TestValueSetAddFromComparisonOperand runs tests to ensure comparison operands are added to the ValueSet along with
+// their neighbouring integers, without adding integers outside the range of a 256-bit word.
+func TestValueSetAddFromComparisonOperand(t *testing.T) {
+	valueSet := NewValueSet()
+	maxValue := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	valueSet.AddFromComparisonOperand(common.BigToHash(big.NewInt(7)))
+	valueSet.AddFromComparisonOperand(common.Hash{})
+	valueSet.AddFromComparisonOperand(common.BigToHash(maxValue))
+
+	integerStrings := make([]string, 0)
+	for _, integer := range valueSet.Integers() {
+		integerStrings = append(integerStrings, integer.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"6", "7", "8", "0", "1", new(big.Int).Sub(maxValue, big.NewInt(1)).String(), maxValue.String(),
+	}, integerStrings)
+}
+
+// TestValueSetRandomValues runs tests to ensure values selected at random from the ValueSet are contained within it,
+// every value can be selected, and nothing is selected from an empty set.
+func TestValueSetRandomValues(t *testing.T) {
+	valueSet := NewValueSet()
+	randomProvider := rand.New(rand.NewSource(1))
+
+	// Nothing can be selected from an empty set.
+	_, ok := valueSet.RandomAddress(randomProvider)
+	assert.False(t, ok)
+	_, ok = valueSet.RandomInteger(randomProvider)
+	assert.False(t, ok)
+	_, ok = valueSet.RandomString(randomProvider)
+	assert.False(t, ok)
+	_, ok = valueSet.RandomBytes(randomProvider)
+	assert.False(t, ok)
+
+	// Populate the set, then verify every value is selected.
+	for i := int64(1); i <= 3; i++ {
+		valueSet.AddAddress(common.BigToAddress(big.NewInt(i)))
+		valueSet.AddInteger(big.NewInt(i))
+		valueSet.AddString(big.NewInt(i).String())
+		valueSet.AddBytes(big.NewInt(i).Bytes())
+	}
+	selectedAddresses := make(map[common.Address]bool)
+	selectedIntegers := make(map[string]bool)
+	selectedStrings := make(map[string]bool)
+	selectedBytes := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		address, ok := valueSet.RandomAddress(randomProvider)
+		assert.True(t, ok)
+		assert.Contains(t, valueSet.Addresses(), address)
+		selectedAddresses[address] = true
+
+		integer, ok := valueSet.RandomInteger(randomProvider)
+		assert.True(t, ok)
+		assert.Contains(t, valueSet.Integers(), integer)
+		selectedIntegers[integer.String()] = true
+
+		str, ok := valueSet.RandomString(randomProvider)
+		assert.True(t, ok)
+		assert.Contains(t, valueSet.Strings(), str)
+		selectedStrings[str] = true
+
+		b, ok := valueSet.RandomBytes(randomProvider)
+		assert.True(t, ok)
+		assert.Contains(t, valueSet.Bytes(), b)
+		selectedBytes[string(b)] = true
+	}
+	assert.Len(t, selectedAddresses, 3)
+	assert.Len(t, selectedIntegers, 3)
+	assert.Len(t, selectedStrings, 3)
+	assert.Len(t, selectedBytes, 3)
+}
+
+// TestValueSetAddValueSet runs tests to ensure every value and array length of a ValueSet is added to another, in the
+// order they were added, without duplicating values both contain.
+func TestValueSetAddValueSet(t *testing.T) {
+	uint256SliceType, err := abi.NewType("uint256[]", "", nil)
+	assert.NoError(t, err)
+	other := NewValueSet()
+	other.AddAddress(common.HexToAddress("0x1234"))
+	other.AddInteger(big.NewInt(2))
+	other.AddInteger(big.NewInt(1))
+	other.AddString("value")
+	other.AddBytes([]byte{0x01, 0x02})
+	other.AddFixedBytes([]byte{0x01, 0x02, 0x03, 0x04})
+	other.AddArrayLength(&uint256SliceType, 13)
+	other.AddArrayLength(&uint256SliceType, 7)
+
+	valueSet := NewValueSet()
+	valueSet.AddInteger(big.NewInt(1))
+	valueSet.AddArrayLength(&uint256SliceType, 7)
+	valueSet.AddValueSet(other)
+	assert.EqualValues(t, []common.Address{common.HexToAddress("0x1234")}, valueSet.Addresses())
+	assert.EqualValues(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, valueSet.Integers())
+	assert.EqualValues(t, []string{"value"}, valueSet.Strings())
+	assert.EqualValues(t, [][]byte{{0x01, 0x02}}, valueSet.Bytes())
+	assert.EqualValues(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, valueSet.FixedBytes(4))
+	assert.EqualValues(t, []int{7, 13}, valueSet.ArrayLengths(&uint256SliceType))
+
+	// The amount of lengths stored for a type remains bounded.
+	for length := 0; length < maxArrayLengthsPerType*2; length++ {
+		other.AddArrayLength(&uint256SliceType, length)
+	}
+	valueSet.AddValueSet(other)
+	assert.Len(t, valueSet.ArrayLengths(&uint256SliceType), maxArrayLengthsPerType)
+}
+
+// TestValueSetFileRoundTrip runs tests to ensure every value of a ValueSet written with WriteToFile is read back by
+// ReadValueSetFromFile in the order it was added, and that invalid files are rejected.
+func TestValueSetFileRoundTrip(t *testing.T) {
+	valueSet := NewValueSet()
+	valueSet.AddAddress(common.HexToAddress("0x1234"))
+	valueSet.AddAddress(common.HexToAddress("0x10"))
+	valueSet.AddInteger(big.NewInt(7))
+	valueSet.AddInteger(big.NewInt(-1))
+	valueSet.AddInteger(new(big.Int).Lsh(big.NewInt(1), 255))
+	valueSet.AddString("value")
+	valueSet.AddString("")
+	valueSet.AddBytes([]byte{0x00, 0xff})
+	valueSet.AddFixedBytes([]byte{0x01, 0x02, 0x03, 0x04})
+	valueSet.AddFixedBytes(make([]byte, 32))
+
+	path := filepath.Join(t.TempDir(), "values.json")
+	assert.NoError(t, valueSet.WriteToFile(path))
+	readValueSet, err := ReadValueSetFromFile(path)
+	assert.NoError(t, err)
+	assert.EqualValues(t, valueSet.Addresses(), readValueSet.Addresses())
+	assert.EqualValues(t, valueSet.Integers(), readValueSet.Integers())
+	assert.EqualValues(t, valueSet.Strings(), readValueSet.Strings())
+	assert.EqualValues(t, valueSet.Bytes(), readValueSet.Bytes())
+	assert.EqualValues(t, valueSet.FixedBytes(4), readValueSet.FixedBytes(4))
+	assert.EqualValues(t, valueSet.FixedBytes(32), readValueSet.FixedBytes(32))
+
+	// Invalid values are rejected.
+	assert.NoError(t, os.WriteFile(path, []byte(`{"integers": ["abc"]}`), 0644))
+	_, err = ReadValueSetFromFile(path)
+	assert.Error(t, err)
+	assert.NoError(t, os.WriteFile(path, []byte(`{"bytes": ["zz"]}`), 0644))
+	_, err = ReadValueSetFromFile(path)
+	assert.Error(t, err)
+}
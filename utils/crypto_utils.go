@@ -22,3 +22,23 @@ func GetPrivateKey(b []byte) (*ecdsa.PrivateKey, error) {
 	privateKey, err := crypto.ToECDSA(paddedPrivateKey[:])
 	return privateKey, errors.WithStack(err)
 }
+
+// EIP712TypedDataHash computes the EIP-712 digest to be signed for typed structured data, given the domain separator
+// and the hash of the struct being signed: keccak256("\x19\x01" || domainSeparator || structHash).
+func EIP712TypedDataHash(domainSeparator []byte, structHash []byte) []byte {
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, structHash)
+}
+
+// SignDigest signs a 32-byte digest with the given private key, returning the signature in the form expected by
+// Solidity's ecrecover: the recovery id v (27 or 28), followed by the r and s values.
+// Returns the signature components, or an error if one occurs.
+func SignDigest(privateKey *ecdsa.PrivateKey, digest []byte) (uint8, [32]byte, [32]byte, error) {
+	var r, s [32]byte
+	signature, err := crypto.Sign(digest, privateKey)
+	if err != nil {
+		return 0, r, s, errors.WithStack(err)
+	}
+	copy(r[:], signature[0:32])
+	copy(s[:], signature[32:64])
+	return signature[64] + 27, r, s, nil
+}
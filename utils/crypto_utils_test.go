@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSignEIP712Digest verifies EIP-712 digests and their signatures against the "Mail" example of the EIP-712
+// specification, signed by the private key keccak256("cow").
+func TestSignEIP712Digest(t *testing.T) {
+	domainSeparator := common.FromHex("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f")
+	structHash := common.FromHex("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e")
+	digest := EIP712TypedDataHash(domainSeparator, structHash)
+	assert.EqualValues(t, common.FromHex("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"), digest)
+
+	privateKey, err := GetPrivateKey(crypto.Keccak256([]byte("cow")))
+	assert.NoError(t, err)
+	assert.EqualValues(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), crypto.PubkeyToAddress(privateKey.PublicKey))
+
+	v, r, s, err := SignDigest(privateKey, digest)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 28, v)
+	assert.EqualValues(t, common.HexToHash("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"), common.Hash(r))
+	assert.EqualValues(t, common.HexToHash("0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"), common.Hash(s))
+
+	// The signature must recover to the signer, as ecrecover would.
+	signature := append(append(append([]byte{}, r[:]...), s[:]...), v-27)
+	publicKey, err := crypto.SigToPub(digest, signature)
+	assert.NoError(t, err)
+	assert.EqualValues(t, crypto.PubkeyToAddress(privateKey.PublicKey), crypto.PubkeyToAddress(*publicKey))
+
+	// Digests which are not 32 bytes cannot be signed.
+	_, _, _, err = SignDigest(privateKey, digest[:31])
+	assert.Error(t, err)
+}
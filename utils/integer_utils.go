@@ -3,10 +3,13 @@ package utils
 import (
 	"golang.org/x/exp/constraints"
 	"math/big"
+	"sync"
 )
 
 // ConstrainIntegerToBounds takes a provided big integer and minimum/maximum bounds (inclusive) and ensures
-// that the provided integer is represented in those bounds. In effect, this simulates overflow and underflow.
+// that the provided integer is represented in those bounds. In effect, this simulates overflow and underflow, wrapping
+// values which are out of bounds around to the other end of the range (e.g. max + 1 becomes min). To clamp values to
+// the bounds instead, use SaturateIntegerToBounds.
 // Returns the constrained integer.
 func ConstrainIntegerToBounds(b *big.Int, min *big.Int, max *big.Int) *big.Int {
 	// Get the bounding range
@@ -48,10 +51,75 @@ func ConstrainIntegerToBitLength(b *big.Int, signed bool, bitLength int) *big.In
 	return ConstrainIntegerToBounds(b, min, max)
 }
 
+// SaturateIntegerToBounds takes a provided big integer and minimum/maximum bounds (inclusive) and ensures that the
+// provided integer is represented in those bounds. Unlike ConstrainIntegerToBounds, values which are out of bounds are
+// clamped to the nearest bound rather than wrapped around (e.g. max + 1 becomes max).
+// Returns the saturated integer.
+func SaturateIntegerToBounds(b *big.Int, min *big.Int, max *big.Int) *big.Int {
+	// Clamp underflow to our minimum
+	if b.Cmp(min) < 0 {
+		return big.NewInt(0).Set(min)
+	}
+
+	// Clamp overflow to our maximum
+	if b.Cmp(max) > 0 {
+		return big.NewInt(0).Set(max)
+	}
+
+	// b is in range, return a copy of it
+	return big.NewInt(0).Set(b)
+}
+
+// SaturateIntegerToBitLength takes a provided big integer, signed indicator, and bit length and ensures that the
+// provided integer is represented in those bounds by clamping it to the nearest bound if it is out of range.
+// Returns the saturated integer.
+func SaturateIntegerToBitLength(b *big.Int, signed bool, bitLength int) *big.Int {
+	// Calculate our min and max bounds for this integer.
+	min, max := GetIntegerConstraints(signed, bitLength)
+
+	// Saturate to the calculated bounds.
+	return SaturateIntegerToBounds(b, min, max)
+}
+
+// integerConstraintsKey describes the properties of an integer which determine its value boundaries, used to key
+// cached results for GetIntegerConstraints.
+type integerConstraintsKey struct {
+	signed    bool
+	bitLength int
+}
+
+// integerConstraintsCache caches the minimum and maximum value boundaries computed by GetIntegerConstraints, so that
+// they are not recomputed on every call.
+var integerConstraintsCache = make(map[integerConstraintsKey][2]*big.Int)
+
+// integerConstraintsCacheLock provides thread-synchronization for access to integerConstraintsCache.
+var integerConstraintsCacheLock sync.RWMutex
+
 // GetIntegerConstraints takes a given signed indicator and bit length for a prospective integer and determines the
-// minimum/maximum value boundaries.
+// minimum/maximum value boundaries. Results are cached, with copies returned to callers so they may be freely modified.
 // Returns the minimum and maximum value for the provided integer properties. Minimums and maximums are inclusive.
 func GetIntegerConstraints(signed bool, bitLength int) (*big.Int, *big.Int) {
+	// Check if we have already computed the constraints for these integer properties.
+	key := integerConstraintsKey{signed: signed, bitLength: bitLength}
+	integerConstraintsCacheLock.RLock()
+	constraints, ok := integerConstraintsCache[key]
+	integerConstraintsCacheLock.RUnlock()
+
+	// If we have not, compute and cache them.
+	if !ok {
+		min, max := computeIntegerConstraints(signed, bitLength)
+		constraints = [2]*big.Int{min, max}
+		integerConstraintsCacheLock.Lock()
+		integerConstraintsCache[key] = constraints
+		integerConstraintsCacheLock.Unlock()
+	}
+	return new(big.Int).Set(constraints[0]), new(big.Int).Set(constraints[1])
+}
+
+// computeIntegerConstraints takes a given signed indicator and bit length for a prospective integer and computes the
+// minimum/maximum value boundaries.
+// Returns the minimum and maximum value for the provided integer properties. Minimums and maximums are inclusive.
+func computeIntegerConstraints(signed bool, bitLength int) (*big.Int, *big.Int) {
 	// Calculate our min and max bounds for this integer.
 	var min, max *big.Int
 	if signed {
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConstrainIntegerToBounds verifies that integers at the bounds are preserved, while integers out of range by
+// one wrap around to the opposite bound.
+func TestConstrainIntegerToBounds(t *testing.T) {
+	for _, signed := range []bool{false, true} {
+		for _, bitLength := range []int{8, 64, 256} {
+			min, max := GetIntegerConstraints(signed, bitLength)
+
+			// Values exactly at our bounds should be unchanged.
+			assert.EqualValues(t, 0, ConstrainIntegerToBounds(min, min, max).Cmp(min))
+			assert.EqualValues(t, 0, ConstrainIntegerToBounds(max, min, max).Cmp(max))
+
+			// Values out of range by one should wrap around.
+			belowMin := new(big.Int).Sub(min, big.NewInt(1))
+			aboveMax := new(big.Int).Add(max, big.NewInt(1))
+			assert.EqualValues(t, 0, ConstrainIntegerToBounds(belowMin, min, max).Cmp(max))
+			assert.EqualValues(t, 0, ConstrainIntegerToBounds(aboveMax, min, max).Cmp(min))
+			assert.EqualValues(t, 0, ConstrainIntegerToBitLength(aboveMax, signed, bitLength).Cmp(min))
+		}
+	}
+}
+
+// TestSaturateIntegerToBounds verifies that integers at the bounds are preserved, while integers out of range by
+// one are clamped to the nearest bound.
+func TestSaturateIntegerToBounds(t *testing.T) {
+	for _, signed := range []bool{false, true} {
+		for _, bitLength := range []int{8, 64, 256} {
+			min, max := GetIntegerConstraints(signed, bitLength)
+
+			// Values exactly at our bounds should be unchanged.
+			assert.EqualValues(t, 0, SaturateIntegerToBounds(min, min, max).Cmp(min))
+			assert.EqualValues(t, 0, SaturateIntegerToBounds(max, min, max).Cmp(max))
+
+			// Values out of range by one should be clamped.
+			belowMin := new(big.Int).Sub(min, big.NewInt(1))
+			aboveMax := new(big.Int).Add(max, big.NewInt(1))
+			assert.EqualValues(t, 0, SaturateIntegerToBounds(belowMin, min, max).Cmp(min))
+			assert.EqualValues(t, 0, SaturateIntegerToBounds(aboveMax, min, max).Cmp(max))
+			assert.EqualValues(t, 0, SaturateIntegerToBitLength(aboveMax, signed, bitLength).Cmp(max))
+
+			// The result should be a copy, not the bound itself.
+			assert.NotSame(t, max, SaturateIntegerToBounds(aboveMax, min, max))
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package utils
+
+import "golang.org/x/exp/maps"
+
+// MapKeys returns a slice of the keys in a map. The order of the keys is not defined. It is provided alongside the
+// other map helpers for discoverability, and defers to maps.Keys.
+func MapKeys[K comparable, V any](x map[K]V) []K {
+	return maps.Keys(x)
+}
+
+// MapValues returns a slice of the values in a map. The order of the values is not defined. It is provided alongside
+// the other map helpers for discoverability, and defers to maps.Values.
+func MapValues[K comparable, V any](x map[K]V) []V {
+	return maps.Values(x)
+}
+
+// MapFilter provides a way of querying specific entries which fit some criteria into a new map.
+func MapFilter[K comparable, V any](x map[K]V, f func(k K, v V) bool) map[K]V {
+	r := make(map[K]V)
+	for k, v := range x {
+		if f(k, v) {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// MapMerge returns a new map containing the entries of base and override. If a key exists in both maps, the provided
+// resolve function is called with the key, the base value and the override value to determine the resulting value.
+// If resolve is nil, the override value is used. Neither input map is modified.
+func MapMerge[K comparable, V any](base, override map[K]V, resolve func(k K, a, b V) V) map[K]V {
+	r := make(map[K]V, len(base)+len(override))
+	for k, v := range base {
+		r[k] = v
+	}
+	for k, v := range override {
+		if existing, ok := r[k]; ok && resolve != nil {
+			r[k] = resolve(k, existing, v)
+		} else {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// MapMergeDeep merges two generic JSON-like maps (e.g. nested ConstructorArgs). Where a key exists in both maps and
+// both values are maps of type map[string]any, they are merged recursively. Otherwise, the override value replaces the
+// base value. Neither input map is modified.
+func MapMergeDeep(base, override map[string]any) map[string]any {
+	return MapMerge(base, override, func(k string, a, b any) any {
+		aMap, aIsMap := a.(map[string]any)
+		bMap, bIsMap := b.(map[string]any)
+		if aIsMap && bIsMap {
+			return MapMergeDeep(aMap, bMap)
+		}
+		return b
+	})
+}
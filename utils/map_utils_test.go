@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMapKeysValuesFilter verifies map keys, values and filtered entries are obtained, and that the source map is not
+// modified.
+func TestMapKeysValuesFilter(t *testing.T) {
+	x := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, MapKeys(x))
+	assert.ElementsMatch(t, []int{1, 2, 3}, MapValues(x))
+	assert.EqualValues(t, map[string]int{"a": 1, "c": 3}, MapFilter(x, func(k string, v int) bool { return v%2 == 1 }))
+	assert.Len(t, x, 3)
+
+	// Verify empty maps.
+	assert.Empty(t, MapKeys(map[string]int{}))
+	assert.Empty(t, MapFilter(map[string]int{}, func(k string, v int) bool { return true }))
+}
+
+// TestMapMerge verifies conflicting keys are resolved by the provided function (or take the override value if none is
+// provided), and that nested maps are merged recursively by MapMergeDeep, without modifying either input.
+func TestMapMerge(t *testing.T) {
+	base := map[string]int{"a": 1, "b": 2}
+	override := map[string]int{"b": 3, "c": 4}
+
+	assert.EqualValues(t, map[string]int{"a": 1, "b": 3, "c": 4}, MapMerge(base, override, nil))
+	assert.EqualValues(t, map[string]int{"a": 1, "b": 5, "c": 4}, MapMerge(base, override, func(k string, a, b int) int { return a + b }))
+	assert.EqualValues(t, map[string]int{"a": 1, "b": 2}, base)
+
+	// Verify nested maps are merged deeply, while non-map values are replaced.
+	deepBase := map[string]any{
+		"TestContract": map[string]any{"x": 1, "y": 2},
+		"value":        1,
+	}
+	deepOverride := map[string]any{
+		"TestContract": map[string]any{"y": 3},
+		"value":        map[string]any{"z": 4},
+	}
+	merged := MapMergeDeep(deepBase, deepOverride)
+	assert.EqualValues(t, map[string]any{"x": 1, "y": 3}, merged["TestContract"])
+	assert.EqualValues(t, map[string]any{"z": 4}, merged["value"])
+	assert.EqualValues(t, map[string]any{"x": 1, "y": 2}, deepBase["TestContract"])
+}
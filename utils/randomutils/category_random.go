@@ -0,0 +1,124 @@
+package randomutils
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// weightedRandomCategory describes a named category of choices held by a CategoryChooser.
+type weightedRandomCategory[T any] struct {
+	// name describes the name of the category.
+	name string
+
+	// chooser describes the WeightedRandomChooser used to select an item within the category.
+	chooser *WeightedRandomChooser[T]
+
+	// weight describes the likelihood of the category being selected relative to all other selectable categories.
+	weight *big.Int
+
+	// selectable indicates whether the category was added to the CategoryChooser's category chooser, which occurs once
+	// it contains choices with a non-zero total weight.
+	selectable bool
+}
+
+// CategoryChooser performs a two-level weighted random selection. A category is first selected by its weight, and an
+// item is then selected from within that category by its own weight. This allows a group of choices to be allocated a
+// collective likelihood of selection, regardless of how many choices the group contains.
+type CategoryChooser[T any] struct {
+	// categoryChooser describes the WeightedRandomChooser used to select a category.
+	categoryChooser *WeightedRandomChooser[*weightedRandomCategory[T]]
+
+	// categories maps category names to their underlying category data.
+	categories map[string]*weightedRandomCategory[T]
+
+	// randomProvider offers a source of random data, shared with each category's chooser.
+	randomProvider *rand.Rand
+	// randomProviderLock is a lock to offer thread safety to the random number generator.
+	randomProviderLock *sync.Mutex
+}
+
+// NewCategoryChooser creates a CategoryChooser with a new random provider and mutex lock.
+func NewCategoryChooser[T any]() *CategoryChooser[T] {
+	return NewCategoryChooserWithRand[T](rand.New(rand.NewSource(time.Now().Unix())), &sync.Mutex{})
+}
+
+// NewCategoryChooserWithRand creates a CategoryChooser with the provided random provider and mutex lock to be acquired
+// when using it. Both are shared with the choosers created for each category.
+func NewCategoryChooserWithRand[T any](randomProvider *rand.Rand, randomProviderLock *sync.Mutex) *CategoryChooser[T] {
+	return &CategoryChooser[T]{
+		categoryChooser:    NewWeightedRandomChooserWithRand[*weightedRandomCategory[T]](randomProvider, randomProviderLock),
+		categories:         make(map[string]*weightedRandomCategory[T]),
+		randomProvider:     randomProvider,
+		randomProviderLock: randomProviderLock,
+	}
+}
+
+// CategoryCount returns the count of categories added to this chooser.
+func (c *CategoryChooser[T]) CategoryCount() int {
+	return len(c.categories)
+}
+
+// AddCategory adds a new named category with the provided weight, which determines the likelihood of the category
+// being selected relative to all other categories. The category is only selectable once choices with a non-zero total
+// weight are added to it. Returns an error if a category with the same name already exists.
+func (c *CategoryChooser[T]) AddCategory(name string, weight *big.Int) error {
+	if _, exists := c.categories[name]; exists {
+		return fmt.Errorf("could not add category '%v' because it already exists", name)
+	}
+
+	// Create our category with a chooser sharing our random provider. It is added as a weighted choice once it has
+	// choices to select from.
+	c.categories[name] = &weightedRandomCategory[T]{
+		name:    name,
+		chooser: NewWeightedRandomChooserWithRand[T](c.randomProvider, c.randomProviderLock),
+		weight:  new(big.Int).Set(weight),
+	}
+	return nil
+}
+
+// AddChoices adds weighted choices to the category with the provided name. Returns an error if the category does not
+// exist.
+func (c *CategoryChooser[T]) AddChoices(categoryName string, choices ...*WeightedRandomChoice[T]) error {
+	category, exists := c.categories[categoryName]
+	if !exists {
+		return fmt.Errorf("could not add choices to category '%v' because it does not exist", categoryName)
+	}
+	category.chooser.AddChoices(choices...)
+
+	// If the category now has choices with a non-zero total weight, it can be selected.
+	if !category.selectable && category.chooser.totalWeight.Sign() > 0 {
+		category.selectable = true
+		c.categoryChooser.AddChoices(NewWeightedRandomChoice(category, category.weight))
+	}
+	return nil
+}
+
+// ChoiceCount returns the count of choices added across all categories.
+func (c *CategoryChooser[T]) ChoiceCount() int {
+	count := 0
+	for _, category := range c.categories {
+		count += category.chooser.ChoiceCount()
+	}
+	return count
+}
+
+// Choose selects a random weighted category, followed by a random weighted item within it. Categories without choices
+// (or whose choices all have zero weight) are never selected. Returns the selected item, or an error if one occurs,
+// such as when no category has choices to select from.
+func (c *CategoryChooser[T]) Choose() (*T, error) {
+	// Select our category first.
+	category, err := c.categoryChooser.Choose()
+	if err != nil {
+		return nil, err
+	}
+
+	// Select an item from within the category.
+	choice, err := (*category).chooser.Choose()
+	if err != nil {
+		return nil, fmt.Errorf("could not choose from category '%v': %v", (*category).name, err)
+	}
+	return choice, nil
+}
@@ -0,0 +1,48 @@
+package randomutils
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCategoryChooserEmptyCategories verifies categories without choices, or whose choices all have zero weight, are
+// never selected, and that choosing fails only if no category has choices to select from.
+func TestCategoryChooserEmptyCategories(t *testing.T) {
+	chooser := NewCategoryChooserWithRand[string](rand.New(rand.NewSource(1)), &sync.Mutex{})
+	assert.NoError(t, chooser.AddCategory("empty", big.NewInt(100)))
+	assert.NoError(t, chooser.AddCategory("zero", big.NewInt(100)))
+	assert.NoError(t, chooser.AddCategory("populated", big.NewInt(1)))
+	assert.Error(t, chooser.AddCategory("populated", big.NewInt(1)))
+	assert.Error(t, chooser.AddChoices("missing", NewWeightedRandomChoice("x", big.NewInt(1))))
+
+	// No category has choices yet, so nothing can be chosen.
+	_, err := chooser.Choose()
+	assert.Error(t, err)
+
+	// Only the populated category is selected, despite its lower weight.
+	assert.NoError(t, chooser.AddChoices("zero", NewWeightedRandomChoice("z", big.NewInt(0))))
+	assert.NoError(t, chooser.AddChoices("populated", NewWeightedRandomChoice("a", big.NewInt(1)), NewWeightedRandomChoice("b", big.NewInt(1))))
+	assert.EqualValues(t, 3, chooser.CategoryCount())
+	assert.EqualValues(t, 3, chooser.ChoiceCount())
+	selected := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		choice, err := chooser.Choose()
+		assert.NoError(t, err)
+		selected[*choice] = true
+	}
+	assert.EqualValues(t, map[string]bool{"a": true, "b": true}, selected)
+
+	// Once populated, a previously empty category becomes selectable.
+	assert.NoError(t, chooser.AddChoices("empty", NewWeightedRandomChoice("c", big.NewInt(1))))
+	selected = make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		choice, err := chooser.Choose()
+		assert.NoError(t, err)
+		selected[*choice] = true
+	}
+	assert.EqualValues(t, map[string]bool{"a": true, "b": true, "c": true}, selected)
+}
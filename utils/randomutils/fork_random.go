@@ -1,6 +1,7 @@
 package randomutils
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
 	"math/rand"
 )
@@ -20,3 +21,17 @@ func ForkRandomProvider(randomProvider *rand.Rand) *rand.Rand {
 	forkSeed := int64(binary.LittleEndian.Uint64(b))
 	return rand.New(rand.NewSource(forkSeed))
 }
+
+// DeriveSeed derives a child seed from a master seed and an index by hashing them together. This allows multiple
+// components (e.g. workers) to each use a distinct, deterministic random provider, regardless of the order in which
+// they are created. Returns the derived seed.
+func DeriveSeed(masterSeed int64, index int) int64 {
+	// Hash the master seed and index together.
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint64(b[0:8], uint64(masterSeed))
+	binary.LittleEndian.PutUint64(b[8:16], uint64(index))
+	hash := sha256.Sum256(b)
+
+	// Use the leading bytes of the hash as our seed.
+	return int64(binary.LittleEndian.Uint64(hash[:8]))
+}
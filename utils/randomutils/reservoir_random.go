@@ -0,0 +1,151 @@
+package randomutils
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// weightedReservoirItem describes a WeightedRandomChoice held by a WeightedReservoirSampler, alongside the random key
+// it was assigned when it was offered.
+type weightedReservoirItem[T any] struct {
+	// choice describes the sampled choice.
+	choice *WeightedRandomChoice[T]
+
+	// key describes the random key assigned to the choice. Choices with the largest keys are retained.
+	key float64
+}
+
+// weightedReservoirHeap implements heap.Interface as a min-heap of weightedReservoirItem, so the item with the smallest
+// key can be evicted efficiently.
+type weightedReservoirHeap[T any] []*weightedReservoirItem[T]
+
+// Len returns the amount of items in the heap, as defined by heap.Interface.
+func (h weightedReservoirHeap[T]) Len() int { return len(h) }
+
+// Less reports whether the item at index i has a smaller key than the item at index j, as defined by heap.Interface.
+func (h weightedReservoirHeap[T]) Less(i, j int) bool { return h[i].key < h[j].key }
+
+// Swap swaps the items at the provided indexes, as defined by heap.Interface.
+func (h weightedReservoirHeap[T]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push adds an item to the heap, as defined by heap.Interface.
+func (h *weightedReservoirHeap[T]) Push(x any) { *h = append(*h, x.(*weightedReservoirItem[T])) }
+
+// Pop removes the last item of the heap, as defined by heap.Interface.
+func (h *weightedReservoirHeap[T]) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// WeightedReservoirSampler maintains a bounded weighted random sample of WeightedRandomChoice objects offered to it as
+// a stream, without storing every choice offered. Each choice is retained with a likelihood proportional to its
+// weight, using the A-Res algorithm by Efraimidis and Spirakis. This bounds memory when choices arrive in unbounded
+// amounts (e.g. contracts deployed dynamically by factories).
+type WeightedReservoirSampler[T any] struct {
+	// capacity describes the maximum amount of choices retained in the sample.
+	capacity int
+
+	// items describes the choices retained in the sample.
+	items weightedReservoirHeap[T]
+
+	// offeredCount describes the amount of choices offered to the sampler.
+	offeredCount uint64
+
+	// randomProvider offers a source of random data.
+	randomProvider *rand.Rand
+	// randomProviderLock is a lock to offer thread safety to the random number generator.
+	randomProviderLock *sync.Mutex
+}
+
+// NewWeightedReservoirSampler creates a WeightedReservoirSampler retaining at most the provided amount of choices, with
+// a new random provider and mutex lock.
+func NewWeightedReservoirSampler[T any](capacity int) *WeightedReservoirSampler[T] {
+	return NewWeightedReservoirSamplerWithRand[T](capacity, rand.New(rand.NewSource(time.Now().Unix())), &sync.Mutex{})
+}
+
+// NewWeightedReservoirSamplerWithRand creates a WeightedReservoirSampler retaining at most the provided amount of
+// choices, with the provided random provider and mutex lock to be acquired when using it.
+func NewWeightedReservoirSamplerWithRand[T any](capacity int, randomProvider *rand.Rand, randomProviderLock *sync.Mutex) *WeightedReservoirSampler[T] {
+	return &WeightedReservoirSampler[T]{
+		capacity:           capacity,
+		items:              make(weightedReservoirHeap[T], 0),
+		randomProvider:     randomProvider,
+		randomProviderLock: randomProviderLock,
+	}
+}
+
+// ChoiceCount returns the count of choices currently retained in the sample.
+func (s *WeightedReservoirSampler[T]) ChoiceCount() int {
+	s.randomProviderLock.Lock()
+	defer s.randomProviderLock.Unlock()
+	return len(s.items)
+}
+
+// OfferedCount returns the count of choices offered to the sampler, including those which were not retained.
+func (s *WeightedReservoirSampler[T]) OfferedCount() uint64 {
+	s.randomProviderLock.Lock()
+	defer s.randomProviderLock.Unlock()
+	return s.offeredCount
+}
+
+// Offer offers weighted choices to the sampler. Each choice is retained in the sample if its random key, biased by its
+// weight, is among the largest of all choices offered so far, evicting the choice with the smallest key if the sample
+// is full. Choices with a non-positive weight are never retained.
+func (s *WeightedReservoirSampler[T]) Offer(choices ...*WeightedRandomChoice[T]) {
+	// Acquire our lock during the duration of this method.
+	s.randomProviderLock.Lock()
+	defer s.randomProviderLock.Unlock()
+
+	for _, choice := range choices {
+		s.offeredCount++
+		if choice.weight.Sign() <= 0 || s.capacity <= 0 {
+			continue
+		}
+
+		// Compute the choice's key as log(u)/w, which orders choices identically to u^(1/w) without underflowing
+		// for large weights.
+		weight, _ := new(big.Float).SetInt(choice.weight).Float64()
+		key := math.Log(1-s.randomProvider.Float64()) / weight
+
+		// Retain the choice if the sample is not full, or it has a larger key than the smallest retained one.
+		if len(s.items) < s.capacity {
+			heap.Push(&s.items, &weightedReservoirItem[T]{choice: choice, key: key})
+		} else if key > s.items[0].key {
+			s.items[0] = &weightedReservoirItem[T]{choice: choice, key: key}
+			heap.Fix(&s.items, 0)
+		}
+	}
+}
+
+// Choices returns the choices currently retained in the sample, in no particular order.
+func (s *WeightedReservoirSampler[T]) Choices() []*WeightedRandomChoice[T] {
+	s.randomProviderLock.Lock()
+	defer s.randomProviderLock.Unlock()
+
+	choices := make([]*WeightedRandomChoice[T], len(s.items))
+	for i, item := range s.items {
+		choices[i] = item.choice
+	}
+	return choices
+}
+
+// Choose selects a random choice from those retained in the sample. Choices are selected uniformly, as they were
+// already retained with a likelihood proportional to their weight. Returns the selected choice's data, or an error if
+// the sample is empty.
+func (s *WeightedReservoirSampler[T]) Choose() (*T, error) {
+	// Acquire our lock during the duration of this method.
+	s.randomProviderLock.Lock()
+	defer s.randomProviderLock.Unlock()
+
+	if len(s.items) == 0 {
+		return nil, fmt.Errorf("could not return a weighted random choice because the sample is empty")
+	}
+	return &s.items[s.randomProvider.Intn(len(s.items))].choice.Data, nil
+}
@@ -0,0 +1,67 @@
+package randomutils
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWeightedReservoirSamplerSize runs tests to ensure the sample never exceeds its capacity, retains each offered
+// choice at most once, and never retains choices with a non-positive weight.
+func TestWeightedReservoirSamplerSize(t *testing.T) {
+	sampler := NewWeightedReservoirSamplerWithRand[int](10, rand.New(rand.NewSource(1)), &sync.Mutex{})
+	_, err := sampler.Choose()
+	assert.Error(t, err)
+
+	// Offer more choices than the sample can hold, alongside choices which should never be retained.
+	for i := 0; i < 1000; i++ {
+		sampler.Offer(NewWeightedRandomChoice(i, big.NewInt(int64(i%5+1))), NewWeightedRandomChoice(-1, big.NewInt(0)))
+		assert.LessOrEqual(t, sampler.ChoiceCount(), 10)
+	}
+	assert.EqualValues(t, 10, sampler.ChoiceCount())
+	assert.EqualValues(t, 2000, sampler.OfferedCount())
+
+	// Each retained choice is distinct, was offered with a positive weight, and can be chosen.
+	retained := make(map[int]bool)
+	for _, choice := range sampler.Choices() {
+		assert.False(t, retained[choice.Data])
+		assert.GreaterOrEqual(t, choice.Data, 0)
+		retained[choice.Data] = true
+	}
+	assert.Len(t, retained, 10)
+	for i := 0; i < 100; i++ {
+		choice, err := sampler.Choose()
+		assert.NoError(t, err)
+		assert.True(t, retained[*choice])
+	}
+
+	// A sampler without capacity retains nothing.
+	sampler = NewWeightedReservoirSamplerWithRand[int](0, rand.New(rand.NewSource(1)), &sync.Mutex{})
+	sampler.Offer(NewWeightedRandomChoice(1, big.NewInt(1)))
+	assert.EqualValues(t, 0, sampler.ChoiceCount())
+	assert.EqualValues(t, 1, sampler.OfferedCount())
+}
+
+// TestWeightedReservoirSamplerWeighting runs tests to ensure choices are retained with a likelihood proportional to
+// their weight.
+func TestWeightedReservoirSamplerWeighting(t *testing.T) {
+	// Offer a heavy and a light choice to a sampler which can retain only one, many times over.
+	const iterations = 1000
+	heavyCount := 0
+	for i := 0; i < iterations; i++ {
+		sampler := NewWeightedReservoirSamplerWithRand[string](1, rand.New(rand.NewSource(int64(i))), &sync.Mutex{})
+		sampler.Offer(NewWeightedRandomChoice("light", big.NewInt(1)), NewWeightedRandomChoice("heavy", big.NewInt(9)))
+		choice, err := sampler.Choose()
+		assert.NoError(t, err)
+		if *choice == "heavy" {
+			heavyCount++
+		}
+	}
+
+	// The heavy choice should be retained roughly 90% of the time.
+	assert.GreaterOrEqual(t, heavyCount, iterations*85/100)
+	assert.LessOrEqual(t, heavyCount, iterations*95/100)
+}
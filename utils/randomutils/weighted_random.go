@@ -78,6 +78,17 @@ func (c *WeightedRandomChooser[T]) AddChoices(choices ...*WeightedRandomChoice[T
 	c.choices = append(c.choices, choices...)
 }
 
+// ReseedRandom replaces the random provider used by the WeightedRandomChooser with a new one seeded with the provided
+// seed, so subsequent selections are deterministic without reconstructing the chooser and re-adding its choices. If
+// the chooser was created with a random provider shared with other objects, it no longer shares it afterwards.
+func (c *WeightedRandomChooser[T]) ReseedRandom(seed int64) {
+	// Acquire our lock during the duration of this method.
+	c.randomProviderLock.Lock()
+	defer c.randomProviderLock.Unlock()
+
+	c.randomProvider = rand.New(rand.NewSource(seed))
+}
+
 // Choose selects a random weighted item from the WeightedRandomChooser, or returns an error if one occurs.
 func (c *WeightedRandomChooser[T]) Choose() (*T, error) {
 	// If we have no choices or 0 total weight, return nil.
@@ -121,15 +132,37 @@ func (c *WeightedRandomChooser[T]) Choose() (*T, error) {
 		selectedWeightPosition = new(big.Int).Mod(selectedWeightPosition, c.totalWeight)
 	}
 
+	return c.chooseAt(selectedWeightPosition)
+}
+
+// ChooseAt selects the weighted item at the provided position in [0, total weight) of the WeightedRandomChooser,
+// without consuming any randomness. Each choice occupies a range of positions as wide as its weight, in the order
+// choices were added. This allows selections to be made deterministically, or the position space to be partitioned.
+// Returns the selected choice's data, or an error if the position is out of range.
+func (c *WeightedRandomChooser[T]) ChooseAt(position *big.Int) (*T, error) {
+	// Acquire our lock during the duration of this method.
+	c.randomProviderLock.Lock()
+	defer c.randomProviderLock.Unlock()
+
+	if position == nil || position.Sign() < 0 || position.Cmp(c.totalWeight) >= 0 {
+		return nil, fmt.Errorf("could not return a weighted choice because position %v is out of range [0, %v)", position, c.totalWeight)
+	}
+	return c.chooseAt(position)
+}
+
+// chooseAt selects the weighted item at the provided position in [0, total weight) of the WeightedRandomChooser. The
+// caller must hold the random provider lock.
+// Returns the selected choice's data, or an error if the position does not exist.
+func (c *WeightedRandomChooser[T]) chooseAt(position *big.Int) (*T, error) {
 	// Loop for each item
 	for _, choice := range c.choices {
 		// If our selected weight position is in range for this item, return it
-		if selectedWeightPosition.Cmp(choice.weight) < 0 {
+		if position.Cmp(choice.weight) < 0 {
 			return &choice.Data, nil
 		}
 
 		// Subtract the choice weight from the current position, and go to the next item to see if it's in range.
-		selectedWeightPosition = new(big.Int).Sub(selectedWeightPosition, choice.weight)
+		position = new(big.Int).Sub(position, choice.weight)
 	}
 
 	return nil, fmt.Errorf("could not obtain a weighted random choice, selected position does not exist")
@@ -0,0 +1,78 @@
+package randomutils
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWeightedRandomChooserChooseAt verifies each choice occupies a range of positions as wide as its weight, in the
+// order choices were added, and that positions outside of [0, total weight) are rejected.
+func TestWeightedRandomChooserChooseAt(t *testing.T) {
+	chooser := NewWeightedRandomChooserWithRand[string](rand.New(rand.NewSource(1)), &sync.Mutex{})
+	chooser.AddChoices(
+		NewWeightedRandomChoice("a", big.NewInt(3)),
+		NewWeightedRandomChoice("b", big.NewInt(0)),
+		NewWeightedRandomChoice("c", big.NewInt(2)),
+	)
+
+	tests := []struct {
+		position *big.Int
+		expected string
+	}{
+		{position: big.NewInt(0), expected: "a"},
+		{position: big.NewInt(2), expected: "a"},
+		{position: big.NewInt(3), expected: "c"},
+		{position: big.NewInt(4), expected: "c"},
+	}
+	for _, test := range tests {
+		choice, err := chooser.ChooseAt(test.position)
+		assert.NoError(t, err)
+		assert.EqualValues(t, test.expected, *choice)
+	}
+
+	// Positions outside of the total weight are rejected.
+	for _, position := range []*big.Int{big.NewInt(5), big.NewInt(6), big.NewInt(-1), nil} {
+		_, err := chooser.ChooseAt(position)
+		assert.Error(t, err)
+	}
+
+	// Every position is rejected by a chooser without choices.
+	emptyChooser := NewWeightedRandomChooserWithRand[string](rand.New(rand.NewSource(1)), &sync.Mutex{})
+	_, err := emptyChooser.ChooseAt(big.NewInt(0))
+	assert.Error(t, err)
+}
+
+// TestWeightedRandomChooserReseedRandom verifies choosers reseeded with the same seed make identical selections,
+// regardless of the random providers they were created with or selections made prior to reseeding.
+func TestWeightedRandomChooserReseedRandom(t *testing.T) {
+	choosers := []*WeightedRandomChooser[int]{
+		NewWeightedRandomChooserWithRand[int](rand.New(rand.NewSource(1)), &sync.Mutex{}),
+		NewWeightedRandomChooserWithRand[int](rand.New(rand.NewSource(2)), &sync.Mutex{}),
+	}
+	for _, chooser := range choosers {
+		for i := 0; i < 10; i++ {
+			chooser.AddChoices(NewWeightedRandomChoice(i, big.NewInt(int64(i+1))))
+		}
+	}
+	_, err := choosers[0].Choose()
+	assert.NoError(t, err)
+
+	// Reseed both choosers with the same seed and verify their selections match.
+	for _, chooser := range choosers {
+		chooser.ReseedRandom(7)
+	}
+	selected := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		choice0, err := choosers[0].Choose()
+		assert.NoError(t, err)
+		choice1, err := choosers[1].Choose()
+		assert.NoError(t, err)
+		assert.EqualValues(t, *choice0, *choice1)
+		selected[*choice0] = true
+	}
+	assert.Greater(t, len(selected), 1)
+}
@@ -5,9 +5,13 @@ import (
 	"reflect"
 )
 
-// ArrayToSlice converts a reflected array into a slice.
+// ArrayToSlice converts a reflected array into a slice of the same length.
+// This method panics if an array type is not provided.
 // Returns the slice.
 func ArrayToSlice(reflectedArray reflect.Value) any {
+	if reflectedArray.Kind() != reflect.Array {
+		panic("failed to convert reflected array to slice, type not supported")
+	}
 	sliceType := reflect.SliceOf(reflectedArray.Type().Elem())
 	resultingSlice := reflect.MakeSlice(sliceType, reflectedArray.Len(), reflectedArray.Len())
 	for i := 0; i < reflectedArray.Len(); i++ {
@@ -16,15 +20,21 @@ func ArrayToSlice(reflectedArray reflect.Value) any {
 	return resultingSlice.Interface()
 }
 
-// SliceToArray converts a reflected slice into an array of the same size.
-// Returns the array.
-func SliceToArray(reflectedSlice reflect.Value) any {
-	arrayType := reflect.ArrayOf(reflectedSlice.Len(), reflectedSlice.Type().Elem())
+// SliceToArray converts a reflected slice into an array of the provided length. This is the inverse of ArrayToSlice.
+// Returns the array, or an error if a slice is not provided or its length does not match the array length.
+func SliceToArray(reflectedSlice reflect.Value, arrayLength int) (any, error) {
+	if reflectedSlice.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("could not convert %v to array, a slice was expected", reflectedSlice.Kind())
+	}
+	if reflectedSlice.Len() != arrayLength {
+		return nil, fmt.Errorf("could not convert slice to array, expected %v elements, got %v", arrayLength, reflectedSlice.Len())
+	}
+	arrayType := reflect.ArrayOf(arrayLength, reflectedSlice.Type().Elem())
 	resultingArray := reflect.New(arrayType).Elem()
 	for i := 0; i < reflectedSlice.Len(); i++ {
 		resultingArray.Index(i).Set(reflect.ValueOf(reflectedSlice.Index(i).Interface()))
 	}
-	return resultingArray.Interface()
+	return resultingArray.Interface(), nil
 }
 
 // CopyReflectedType creates a shallow copy of a reflected value. It supports slices, arrays, or structs.
@@ -0,0 +1,37 @@
+package reflectionutils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestArrayToSliceToArray verifies that arrays converted to slices convert back to the same array, and that slices
+// which are not of the expected array length, or values which are not slices or arrays, are rejected.
+func TestArrayToSliceToArray(t *testing.T) {
+	array := [4]byte{0x12, 0x34, 0x56, 0x78}
+	slice := ArrayToSlice(reflect.ValueOf(array))
+	assert.EqualValues(t, []byte{0x12, 0x34, 0x56, 0x78}, slice)
+
+	convertedArray, err := SliceToArray(reflect.ValueOf(slice), len(array))
+	assert.NoError(t, err)
+	assert.EqualValues(t, array, convertedArray)
+
+	// Slices of a different length than the array are rejected.
+	for _, arrayLength := range []int{0, 3, 5} {
+		_, err = SliceToArray(reflect.ValueOf(slice), arrayLength)
+		assert.Error(t, err)
+	}
+
+	// Values which are not slices are rejected.
+	_, err = SliceToArray(reflect.ValueOf(array), len(array))
+	assert.Error(t, err)
+	assert.Panics(t, func() { ArrayToSlice(reflect.ValueOf(slice)) })
+
+	// Empty arrays and slices are converted.
+	assert.EqualValues(t, []int{}, ArrayToSlice(reflect.ValueOf([0]int{})))
+	convertedArray, err = SliceToArray(reflect.ValueOf([]int{}), 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, [0]int{}, convertedArray)
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// maxRetryBackoff describes the maximum duration Retry waits between attempts, regardless of how many attempts failed.
+const maxRetryBackoff = 30 * time.Second
+
+// Retry calls the provided function until it succeeds or has been attempted the provided number of times, waiting
+// between attempts with exponential backoff, starting at the provided backoff duration and doubling after each failed
+// attempt, up to maxRetryBackoff. This is intended for operations which may fail transiently, such as requests to a
+// remote node. If the number of attempts is not positive, the function is attempted once. If the provided context is
+// done, no further attempts are made.
+// Returns nil if an attempt succeeded, otherwise the error from the last attempt, or the context's error if it was
+// done before any attempt was made.
+func Retry(ctx context.Context, attempts int, backoff time.Duration, f func() error) error {
+	if attempts <= 0 {
+		attempts = 1
+	}
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		// Stop retrying if our context is done, returning the last error encountered if there was one.
+		if CheckContextDone(ctx) {
+			if err == nil {
+				err = ctx.Err()
+			}
+			return err
+		}
+
+		// Perform the attempt, returning if it succeeded.
+		err = f()
+		if err == nil {
+			return nil
+		}
+
+		// Wait before our next attempt, unless our context is done first.
+		if attempt < attempts-1 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff *= 2
+			if backoff > maxRetryBackoff {
+				backoff = maxRetryBackoff
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+// TestRetry verifies the function is retried until it succeeds or runs out of attempts, returning the last error,
+// and is attempted once if the number of attempts is not positive.
+func TestRetry(t *testing.T) {
+	attemptErr := errors.New("attempt failed")
+	tests := []struct {
+		attempts         int
+		succeedAt        int
+		expectedAttempts int
+		expectError      bool
+	}{
+		{attempts: 3, succeedAt: 2, expectedAttempts: 2, expectError: false},
+		{attempts: 3, succeedAt: 5, expectedAttempts: 3, expectError: true},
+		{attempts: 0, succeedAt: 1, expectedAttempts: 1, expectError: false},
+		{attempts: -1, succeedAt: 5, expectedAttempts: 1, expectError: true},
+	}
+	for _, test := range tests {
+		attemptCount := 0
+		err := Retry(context.Background(), test.attempts, time.Millisecond, func() error {
+			attemptCount++
+			if attemptCount < test.succeedAt {
+				return attemptErr
+			}
+			return nil
+		})
+		assert.EqualValues(t, test.expectedAttempts, attemptCount)
+		if test.expectError {
+			assert.ErrorIs(t, err, attemptErr)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+// TestRetryCancellation verifies no attempts are made once the context is done, and that cancelling the context while
+// waiting between attempts stops retrying immediately, returning the last error.
+func TestRetryCancellation(t *testing.T) {
+	// No attempts are made with a context which is already done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attemptCount := 0
+	err := Retry(ctx, 3, time.Millisecond, func() error {
+		attemptCount++
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, attemptCount)
+
+	// Cancelling the context during a long backoff stops retrying without waiting for it.
+	attemptErr := errors.New("attempt failed")
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	attemptCount = 0
+	start := time.Now()
+	err = Retry(ctx, 3, time.Hour, func() error {
+		attemptCount++
+		go cancel()
+		return attemptErr
+	})
+	assert.ErrorIs(t, err, attemptErr)
+	assert.EqualValues(t, 1, attemptCount)
+	assert.Less(t, time.Since(start), time.Minute)
+}
@@ -1,5 +1,11 @@
 package utils
 
+import (
+	"math/rand"
+
+	"golang.org/x/exp/slices"
+)
+
 // SlicePointersToValues takes a slice of pointers and returns a slice of values de-referenced from them.
 func SlicePointersToValues[T any](x []*T) []T {
 	r := make([]T, len(x))
@@ -37,3 +43,59 @@ func SliceWhere[T any](x []T, f func(x T) bool) []T {
 	}
 	return r
 }
+
+// SliceIntersect returns the elements of a which are also present in b, preserving their order in a.
+func SliceIntersect[T comparable](a, b []T) []T {
+	return SliceWhere(a, func(x T) bool {
+		return slices.Contains(b, x)
+	})
+}
+
+// SliceDifference returns the elements of a which are not present in b, preserving their order in a.
+func SliceDifference[T comparable](a, b []T) []T {
+	return SliceWhere(a, func(x T) bool {
+		return !slices.Contains(b, x)
+	})
+}
+
+// SliceCount returns the number of elements in a slice which satisfy the provided predicate.
+func SliceCount[T any](x []T, f func(x T) bool) int {
+	count := 0
+	for i := 0; i < len(x); i++ {
+		if f(x[i]) {
+			count++
+		}
+	}
+	return count
+}
+
+// SliceAny returns a boolean indicating whether any element in a slice satisfies the provided predicate. It returns
+// as soon as a satisfying element is found.
+func SliceAny[T any](x []T, f func(x T) bool) bool {
+	for i := 0; i < len(x); i++ {
+		if f(x[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// SliceAll returns a boolean indicating whether all elements in a slice satisfy the provided predicate. It returns
+// as soon as an element which does not satisfy it is found. Returns true for an empty slice.
+func SliceAll[T any](x []T, f func(x T) bool) bool {
+	for i := 0; i < len(x); i++ {
+		if !f(x[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SliceShuffle shuffles the elements of a slice in place using the Fisher-Yates algorithm. The provided random
+// provider is used so shuffles are reproducible for a given seed.
+func SliceShuffle[T any](x []T, r *rand.Rand) {
+	for i := len(x) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		x[i], x[j] = x[j], x[i]
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSliceIntersectDifference verifies that set operations preserve the order of the first slice, and handle
+// duplicates and empty slices.
+func TestSliceIntersectDifference(t *testing.T) {
+	a := []string{"a", "b", "c", "b"}
+	b := []string{"b", "d", "a"}
+
+	assert.EqualValues(t, []string{"a", "b", "b"}, SliceIntersect(a, b))
+	assert.EqualValues(t, []string{"c"}, SliceDifference(a, b))
+	assert.Empty(t, SliceIntersect(a, nil))
+	assert.EqualValues(t, a, SliceDifference(a, nil))
+	assert.Empty(t, SliceDifference(nil, b))
+}
+
+// TestSliceCountAnyAll verifies predicate helpers count matching elements, short-circuit once their result is known,
+// and handle empty slices.
+func TestSliceCountAnyAll(t *testing.T) {
+	x := []int{1, 2, 3, 4, 5}
+	isEven := func(i int) bool { return i%2 == 0 }
+
+	assert.EqualValues(t, 2, SliceCount(x, isEven))
+	assert.True(t, SliceAny(x, isEven))
+	assert.False(t, SliceAll(x, isEven))
+	assert.True(t, SliceAll(x, func(i int) bool { return i > 0 }))
+
+	// Verify evaluation stops at the first element which determines the result.
+	evaluated := 0
+	SliceAny(x, func(i int) bool { evaluated++; return isEven(i) })
+	assert.EqualValues(t, 2, evaluated)
+	evaluated = 0
+	SliceAll(x, func(i int) bool { evaluated++; return !isEven(i) })
+	assert.EqualValues(t, 2, evaluated)
+
+	// Verify empty slices.
+	assert.EqualValues(t, 0, SliceCount([]int{}, isEven))
+	assert.False(t, SliceAny([]int{}, isEven))
+	assert.True(t, SliceAll([]int{}, isEven))
+}
+
+// TestSliceShuffle verifies shuffles are a permutation of the original slice, and are reproducible for a given seed.
+func TestSliceShuffle(t *testing.T) {
+	original := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	x := append([]int{}, original...)
+	y := append([]int{}, original...)
+
+	SliceShuffle(x, rand.New(rand.NewSource(1)))
+	SliceShuffle(y, rand.New(rand.NewSource(1)))
+	assert.EqualValues(t, x, y)
+	assert.ElementsMatch(t, original, x)
+
+	// Verify empty and single element slices are unaffected.
+	empty := []int{}
+	SliceShuffle(empty, rand.New(rand.NewSource(1)))
+	assert.Empty(t, empty)
+	single := []int{7}
+	SliceShuffle(single, rand.New(rand.NewSource(1)))
+	assert.EqualValues(t, []int{7}, single)
+}